@@ -15,6 +15,7 @@ require (
 	github.com/openai/openai-go/v2 v2.0.2
 	github.com/stretchr/testify v1.10.0
 	github.com/stripe/stripe-go/v76 v76.8.0
+	golang.org/x/image v0.24.0
 	google.golang.org/api v0.237.0
 	google.golang.org/grpc v1.73.0
 )
@@ -18,6 +18,9 @@ type Config struct {
 	// CORS
 	CORSAllowedOrigins string
 
+	// Public URL the API is served from, used to build share links
+	PublicBaseURL string
+
 	// Firebase
 	FirebaseProjectID string
 	FirebaseAPIKey    string
@@ -32,6 +35,9 @@ type Config struct {
 	// Google Cloud Storage
 	GCSBucketName string
 
+	// ffmpeg binary used to render video animatics
+	FFmpegPath string
+
 	// Database
 	DatabaseURL string
 
@@ -65,6 +71,7 @@ func Load() *Config {
 // loadFromEnv loads configuration from environment variables (development)
 func (c *Config) loadFromEnv() {
 	c.CORSAllowedOrigins = getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:3001")
+	c.PublicBaseURL = getEnv("PUBLIC_BASE_URL", "http://localhost:8080")
 	c.FirebaseProjectID = getEnv("FIREBASE_PROJECT_ID", "")
 	c.FirebaseAPIKey = getEnv("FIREBASE_API_KEY", "")
 	c.OpenAIAPIKey = getEnv("OPENAI_API_KEY", "")
@@ -72,6 +79,7 @@ func (c *Config) loadFromEnv() {
 	c.StripeWebhookSecret = getEnv("STRIPE_WEBHOOK_SECRET", "")
 	c.GCSBucketName = getEnv("GCS_BUCKET_NAME", "")
 	c.DatabaseURL = getEnv("DATABASE_URL", "")
+	c.FFmpegPath = getEnv("FFMPEG_PATH", "ffmpeg")
 }
 
 // loadFromSecretManager loads configuration from Google Cloud Secret Manager (production)
@@ -85,6 +93,7 @@ func (c *Config) loadFromSecretManager() {
 
 	// Production CORS origins
 	c.CORSAllowedOrigins = "https://bezz-frontend-981046325818.us-central1.run.app"
+	c.PublicBaseURL = getEnv("PUBLIC_BASE_URL", "")
 
 	c.FirebaseProjectID = c.getSecret(ctx, client, "firebase-project-id")
 	c.FirebaseAPIKey = c.getSecret(ctx, client, "firebase-api-key")
@@ -93,6 +102,7 @@ func (c *Config) loadFromSecretManager() {
 	c.StripeWebhookSecret = c.getSecret(ctx, client, "stripe-webhook-secret")
 	c.GCSBucketName = c.getSecret(ctx, client, "gcs-bucket-name")
 	c.DatabaseURL = getEnv("DATABASE_URL", "") // Keep as env var if needed
+	c.FFmpegPath = getEnv("FFMPEG_PATH", "ffmpeg")
 }
 
 // getSecret retrieves a secret from Google Cloud Secret Manager
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"bezz-backend/internal/middleware"
 	"bezz-backend/internal/models"
 	"bezz-backend/internal/services"
 )
@@ -70,3 +73,63 @@ func (h *AdminHandler) GetUsers(c *gin.Context) {
 		Data:    users,
 	})
 }
+
+// GetModerationQueue returns briefs with moderation flags awaiting review
+func (h *AdminHandler) GetModerationQueue(c *gin.Context) {
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	briefs, err := h.briefService.ListModerationQueue(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list moderation queue",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    briefs,
+	})
+}
+
+// ReviewModeration records an admin's decision on a flagged brief
+func (h *AdminHandler) ReviewModeration(c *gin.Context) {
+	var req models.ModerationReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Decision must be approved or rejected",
+		})
+		return
+	}
+
+	brief, err := h.briefService.ReviewModeration(c.Request.Context(), c.Param("id"), middleware.GetUserID(c), &req)
+	if err != nil {
+		status, message := http.StatusInternalServerError, "Failed to record review"
+		switch {
+		case errors.Is(err, services.ErrBriefNotFound):
+			status, message = http.StatusNotFound, "Brief not found"
+		case errors.Is(err, services.ErrNoModerationReport):
+			status, message = http.StatusConflict, "Brief has no moderation report to review"
+		default:
+			log.Printf("❌ ADMIN: Failed to record review for brief %s: %v", c.Param("id"), err)
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Error:   message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    brief,
+		Message: "Review recorded",
+	})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"bezz-backend/internal/middleware"
+	"bezz-backend/internal/models"
+	"bezz-backend/internal/services"
+)
+
+// AssetHandler handles brand asset upload endpoints
+type AssetHandler struct {
+	assetService *services.AssetService
+}
+
+// NewAssetHandler creates a new asset handler
+func NewAssetHandler(assetService *services.AssetService) *AssetHandler {
+	return &AssetHandler{
+		assetService: assetService,
+	}
+}
+
+// Upload stores an uploaded logo or product shot (multipart form: kind, file)
+func (h *AssetHandler) Upload(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   "User not authenticated",
+		})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "A file is required",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	asset, err := h.assetService.UploadAsset(c.Request.Context(), userID, c.PostForm("kind"), fileHeader.Filename, file)
+	if err != nil {
+		log.Printf("❌ ASSETS: Upload failed for user %s: %v", userID, err)
+		if errors.Is(err, services.ErrInvalidAsset) {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to upload asset",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    asset,
+		Message: "Asset uploaded successfully",
+	})
+}
+
+// List lists the authenticated user's uploaded assets
+func (h *AssetHandler) List(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   "User not authenticated",
+		})
+		return
+	}
+
+	assets, err := h.assetService.ListAssets(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list assets",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    assets,
+	})
+}
+
+// Delete deletes one of the authenticated user's assets
+func (h *AssetHandler) Delete(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Error:   "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.assetService.DeleteAsset(c.Request.Context(), userID, c.Param("id")); err != nil {
+		if errors.Is(err, services.ErrAssetNotFound) {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Error:   "Asset not found",
+			})
+			return
+		}
+		log.Printf("❌ ASSETS: Failed to delete asset %s: %v", c.Param("id"), err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to delete asset",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Asset deleted successfully",
+	})
+}
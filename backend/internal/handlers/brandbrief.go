@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -14,15 +15,19 @@ import (
 
 // BrandBriefHandler handles brand brief endpoints
 type BrandBriefHandler struct {
-	briefService *services.BrandBriefService
-	userService  *services.UserService
+	briefService  *services.BrandBriefService
+	userService   *services.UserService
+	sectorService *services.SectorService
+	assetService  *services.AssetService
 }
 
 // NewBrandBriefHandler creates a new brand brief handler
-func NewBrandBriefHandler(briefService *services.BrandBriefService, userService *services.UserService) *BrandBriefHandler {
+func NewBrandBriefHandler(briefService *services.BrandBriefService, userService *services.UserService, sectorService *services.SectorService, assetService *services.AssetService) *BrandBriefHandler {
 	return &BrandBriefHandler{
-		briefService: briefService,
-		userService:  userService,
+		briefService:  briefService,
+		userService:   userService,
+		sectorService: sectorService,
+		assetService:  assetService,
 	}
 }
 
@@ -53,6 +58,78 @@ func (h *BrandBriefHandler) Create(c *gin.Context) {
 		return
 	}
 
+	services.SanitizeBriefRequest(&req)
+	if err := services.ValidateBriefInputs(&req); err != nil {
+		log.Printf("❌ CREATE BRIEF: Invalid brief fields: %v", err)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := services.ValidatePlacements(req.Placements); err != nil {
+		log.Printf("❌ CREATE BRIEF: Invalid placements: %v", err)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := services.ValidateStyles(req.Style, req.AdStyles); err != nil {
+		log.Printf("❌ CREATE BRIEF: Invalid styles: %v", err)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := services.ValidateVideoDurations(req.VideoDurations); err != nil {
+		log.Printf("❌ CREATE BRIEF: Invalid video durations: %v", err)
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if _, err := h.sectorService.ResolveSector(c.Request.Context(), req.Sector); err != nil {
+		if errors.Is(err, services.ErrUnknownSector) {
+			log.Printf("❌ CREATE BRIEF: Invalid sector: %v", err)
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		log.Printf("❌ CREATE BRIEF: Failed to load sectors: %v", err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to load sectors",
+		})
+		return
+	}
+
+	assets, err := h.assetService.ResolveBriefAssets(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAsset) || errors.Is(err, services.ErrAssetNotFound) {
+			log.Printf("❌ CREATE BRIEF: Invalid brand assets: %v", err)
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		log.Printf("❌ CREATE BRIEF: Failed to resolve brand assets: %v", err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to resolve brand assets",
+		})
+		return
+	}
+
 	log.Printf("📝 CREATE BRIEF: Request data - Company: %s, Sector: %s", req.CompanyName, req.Sector)
 
 	// Check user credits
@@ -69,6 +146,16 @@ func (h *BrandBriefHandler) Create(c *gin.Context) {
 
 	log.Printf("💳 CREATE BRIEF: User credits: %d", user.Credits)
 
+	// Video storyboards depend on the subscription plan
+	if err := services.CheckVideoPlan(user, req.VideoDurations); err != nil {
+		log.Printf("❌ CREATE BRIEF: Video storyboards not available: %v", err)
+		c.JSON(http.StatusForbidden, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	if user.Credits < 1 {
 		log.Printf("❌ CREATE BRIEF: Insufficient credits (%d)", user.Credits)
 		c.JSON(http.StatusPaymentRequired, models.APIResponse{
@@ -78,6 +165,21 @@ func (h *BrandBriefHandler) Create(c *gin.Context) {
 		return
 	}
 
+	// Check the brief against the content policy before charging for it
+	moderation := h.briefService.ModerateRequest(c.Request.Context(), &req)
+	if services.IsModerationBlocked(moderation) {
+		log.Printf("🛡️ CREATE BRIEF: Brief inputs flagged by moderation")
+		if _, err := h.briefService.RecordBlockedBrief(c.Request.Context(), userID, &req, assets, moderation); err != nil {
+			log.Printf("⚠️ CREATE BRIEF: Failed to record blocked brief: %v", err)
+		}
+		c.JSON(http.StatusUnprocessableEntity, models.APIResponse{
+			Success: false,
+			Error:   services.ModerationBlockMessage(moderation),
+			Code:    services.ModerationErrorCode,
+		})
+		return
+	}
+
 	// Deduct credits
 	log.Printf("💰 CREATE BRIEF: Deducting 1 credit...")
 	if err := h.userService.DeductCredits(c.Request.Context(), userID, 1); err != nil {
@@ -91,7 +193,7 @@ func (h *BrandBriefHandler) Create(c *gin.Context) {
 
 	// Create brief
 	log.Printf("📄 CREATE BRIEF: Creating brief document...")
-	brief, err := h.briefService.CreateBrief(c.Request.Context(), userID, &req)
+	brief, err := h.briefService.CreateBrief(c.Request.Context(), userID, &req, assets, moderation)
 	if err != nil {
 		log.Printf("❌ CREATE BRIEF: Failed to create brief: %v", err)
 		// Refund credits on failure
@@ -307,6 +409,14 @@ func (h *BrandBriefHandler) Retry(c *gin.Context) {
 
 	// Retry the brief processing
 	err = h.briefService.RetryProcessing(c.Request.Context(), briefID)
+	if errors.Is(err, services.ErrInsufficientCredits) {
+		log.Printf("❌ RETRY BRIEF: Insufficient credits to retry unpaid brief %s", briefID)
+		c.JSON(http.StatusPaymentRequired, models.APIResponse{
+			Success: false,
+			Error:   "Insufficient credits",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("❌ RETRY BRIEF: Failed to retry processing for brief %s: %v", briefID, err)
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
@@ -367,3 +477,11 @@ func (h *BrandBriefHandler) RefreshImageURLs(c *gin.Context) {
 		Message: "Image URLs refreshed successfully",
 	})
 }
+
+// ListStyles returns the visual style presets a brief or ad can use
+func (h *BrandBriefHandler) ListStyles(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    services.ListStylePresets(),
+	})
+}
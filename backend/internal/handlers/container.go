@@ -12,16 +12,20 @@ type Container struct {
 	Payment    *PaymentHandler
 	Admin      *AdminHandler
 	Export     *ExportHandler
+	Sector     *SectorHandler
+	Asset      *AssetHandler
 }
 
 // NewContainer creates a new handler container
 func NewContainer(services *services.Container) *Container {
 	return &Container{
 		Auth:       NewAuthHandler(services.AuthService, services.UserService),
-		BrandBrief: NewBrandBriefHandler(services.BrandBriefService, services.UserService),
+		BrandBrief: NewBrandBriefHandler(services.BrandBriefService, services.UserService, services.SectorService, services.AssetService),
 		User:       NewUserHandler(services.UserService),
 		Payment:    NewPaymentHandler(services.PaymentService, services.UserService),
 		Admin:      NewAdminHandler(services.UserService, services.BrandBriefService),
-		Export:     NewExportHandler(services.ExportService),
+		Export:     NewExportHandler(services.ExportService, services.Config.PublicBaseURL),
+		Sector:     NewSectorHandler(services.SectorService),
+		Asset:      NewAssetHandler(services.AssetService),
 	}
 }
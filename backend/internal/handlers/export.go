@@ -1,8 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"log"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"bezz-backend/internal/models"
 	"bezz-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -11,29 +18,156 @@ import (
 // ExportHandler handles export-related requests
 type ExportHandler struct {
 	exportService *services.ExportService
+	publicBaseURL string // share links are built from this rather than the request's Host header
 }
 
 // NewExportHandler creates a new export handler
-func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+func NewExportHandler(exportService *services.ExportService, publicBaseURL string) *ExportHandler {
 	return &ExportHandler{
 		exportService: exportService,
+		publicBaseURL: strings.TrimSuffix(publicBaseURL, "/"),
 	}
 }
 
-// CreateBatchExport generates a complete brand kit export
-func (h *ExportHandler) CreateBatchExport(c *gin.Context) {
-	briefID := c.Param("briefId")
+// attachmentDisposition is the Content-Disposition header for a download. Filenames include the company
+// name, so they are quoted (or RFC 2231 encoded when not ASCII) rather than appended as is.
+func attachmentDisposition(filename string) string {
+	return mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+}
+
+// batchExportOptions reads and validates the format and brand book template of a batch export request,
+// answering 400 itself when they are invalid
+func batchExportOptions(c *gin.Context) (string, string, bool) {
 	format := c.DefaultQuery("format", "zip") // Default to ZIP format
 
 	// Validate format
-	if format != "zip" && format != "pdf" {
+	if format != "zip" && format != "pdf" && format != "pptx" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid format. Supported formats: zip, pdf, pptx",
+		})
+		return "", "", false
+	}
+
+	// Validate the brand book template (only used by PDF exports)
+	template := c.DefaultQuery("template", services.DefaultBrandBookTemplate)
+	if err := services.ValidateBrandBookTemplate(template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return "", "", false
+	}
+
+	return format, template, true
+}
+
+// CreateExportJob starts a batch export in the background; poll GetExportJob for its download link
+func (h *ExportHandler) CreateExportJob(c *gin.Context) {
+	briefID := c.Param("briefId")
+	format, template, ok := batchExportOptions(c)
+	if !ok {
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.exportService.StartExportJob(c.Request.Context(), briefID, userID.(string), format, template)
+	if err != nil {
+		status, message := http.StatusInternalServerError, "Failed to start export"
+		switch {
+		case errors.Is(err, services.ErrUnsupportedExportFormat), errors.Is(err, services.ErrNothingToExport):
+			status, message = http.StatusBadRequest, err.Error()
+		case errors.Is(err, services.ErrBriefNotFound):
+			status, message = http.StatusNotFound, "Brief not found"
+		case errors.Is(err, services.ErrBriefAccessDenied):
+			status, message = http.StatusForbidden, "Access denied"
+		default:
+			log.Printf("❌ EXPORT: Failed to start export for brief %s: %v", briefID, err)
+		}
+		c.JSON(status, gin.H{
+			"error": message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Data:    job,
+		Message: "Export started",
+	})
+}
+
+// GetExportJob reports an export's status, with a signed download link once it has completed
+func (h *ExportHandler) GetExportJob(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.exportService.GetExportJob(c.Request.Context(), userID.(string), c.Param("jobId"))
+	if errors.Is(err, services.ErrExportJobNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to fetch export: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// ListExportJobs lists the user's past exports, optionally for one brief, so they can be downloaded again
+func (h *ExportHandler) ListExportJobs(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	jobs, err := h.exportService.ListExportJobs(c.Request.Context(), userID.(string), c.Query("briefId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list exports: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    jobs,
+	})
+}
+
+// GetDesignTokens downloads a brief's design tokens in one format
+func (h *ExportHandler) GetDesignTokens(c *gin.Context) {
+	briefID := c.Param("briefId")
+	format := c.Param("format")
+
+	if err := services.ValidateDesignTokenFormat(format); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid format. Supported formats: zip, pdf",
+			"error": err.Error(),
 		})
 		return
 	}
 
-	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -42,22 +176,252 @@ func (h *ExportHandler) CreateBatchExport(c *gin.Context) {
 		return
 	}
 
-	// Generate batch export
-	exportData, contentType, filename, err := h.exportService.GenerateBatchExport(c.Request.Context(), briefID, userID.(string), format)
+	data, contentType, filename, err := h.exportService.GenerateDesignTokensExport(c.Request.Context(), briefID, userID.(string), format)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to generate export: " + err.Error(),
+			"error": "Failed to generate design tokens: " + err.Error(),
 		})
 		return
 	}
 
-	// Set headers for file download
-	c.Header("Content-Type", contentType)
-	c.Header("Content-Disposition", "attachment; filename="+filename)
-	c.Header("Content-Length", string(rune(len(exportData))))
+	c.Header("Content-Disposition", attachmentDisposition(filename))
+	c.Data(http.StatusOK, contentType, data)
+}
 
-	// Send the file data
-	c.Data(http.StatusOK, contentType, exportData)
+// CreateCanvaExport downloads editable SVG templates of the brief's ads for Canva and Figma
+func (h *ExportHandler) CreateCanvaExport(c *gin.Context) {
+	briefID := c.Param("briefId")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	data, contentType, filename, err := h.exportService.GenerateCanvaExport(c.Request.Context(), briefID, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate Canva templates: " + err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", attachmentDisposition(filename))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// CreateMicrositeExport downloads the brand guidelines microsite as a ZIP of static HTML and assets
+func (h *ExportHandler) CreateMicrositeExport(c *gin.Context) {
+	briefID := c.Param("briefId")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	data, contentType, filename, err := h.exportService.GenerateMicrositeExport(c.Request.Context(), briefID, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate brand guidelines site: " + err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", attachmentDisposition(filename))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// ShareMicrosite turns on a brief's read-only brand guidelines link
+func (h *ExportHandler) ShareMicrosite(c *gin.Context) {
+	briefID := c.Param("briefId")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if h.publicBaseURL == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Share links are not configured",
+		})
+		return
+	}
+
+	token, err := h.exportService.ShareMicrosite(c.Request.Context(), briefID, userID.(string))
+	if errors.Is(err, services.ErrShareNotAllowed) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to share brand guidelines: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data: gin.H{
+			"token": token,
+			"url":   h.publicBaseURL + "/api/share/" + token,
+		},
+	})
+}
+
+// UnshareMicrosite revokes a brief's brand guidelines link
+func (h *ExportHandler) UnshareMicrosite(c *gin.Context) {
+	briefID := c.Param("briefId")
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.exportService.UnshareMicrosite(c.Request.Context(), briefID, userID.(string)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to revoke share link: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Share link revoked",
+	})
+}
+
+// GetSharedMicrosite serves a shared brief's brand guidelines as a read-only page
+func (h *ExportHandler) GetSharedMicrosite(c *gin.Context) {
+	token := c.Param("token")
+
+	if err := services.ValidateShareToken(token); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	page, err := h.exportService.SharedMicrosite(c.Request.Context(), token)
+	if errors.Is(err, services.ErrShareNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load brand guidelines: " + err.Error(),
+		})
+		return
+	}
+
+	// The page only embeds its own images, styles and copy script
+	c.Header("Content-Security-Policy", "default-src 'none'; img-src data:; style-src 'unsafe-inline'; script-src 'unsafe-inline'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'")
+	c.Header("X-Robots-Tag", "noindex")
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
+// CreateAdNetworkExport downloads a bulk upload bundle for an ad network (Meta, Google Ads, LinkedIn)
+func (h *ExportHandler) CreateAdNetworkExport(c *gin.Context) {
+	briefID := c.Param("briefId")
+	network := c.Param("network")
+
+	if err := h.exportService.ValidateAdNetwork(network); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	opts := services.AdNetworkExportOptions{
+		LandingURL: c.Query("url"),
+	}
+	budget, err := strconv.ParseFloat(c.Query("budget"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "budget is required and must be a number",
+		})
+		return
+	}
+	opts.TotalBudget = budget
+	if d := c.Query("days"); d != "" {
+		days, err := strconv.Atoi(d)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "days must be a whole number",
+			})
+			return
+		}
+		opts.Days = days
+	}
+	if start := c.Query("start"); start != "" {
+		date, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "start must be a date in YYYY-MM-DD format",
+			})
+			return
+		}
+		opts.StartDate = date
+	}
+	if countries := c.Query("countries"); countries != "" {
+		opts.Countries = strings.Split(countries, ",")
+	}
+	if err := services.ValidateAdNetworkExportOptions(&opts); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	data, contentType, filename, err := h.exportService.GenerateAdNetworkExport(c.Request.Context(), briefID, userID.(string), network, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to generate ad network export: " + err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", attachmentDisposition(filename))
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// GetAdNetworks lists the ad networks ads can be bulk exported to
+func (h *ExportHandler) GetAdNetworks(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.exportService.AdNetworks(),
+	})
+}
+
+// GetBrandBookTemplates lists the PDF brand book templates
+func (h *ExportHandler) GetBrandBookTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    services.BrandBookTemplates(),
+	})
 }
 
 // TestBrandIdentity tests brand identity generation for debugging
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"mime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentDisposition_QuotesCompanyNames(t *testing.T) {
+	for _, filename := range []string{"Acme-Coffee-tokens.css", `Joe's "Best"; Bakery.css`, "Café-Crème.zip"} {
+		disposition, params, err := mime.ParseMediaType(attachmentDisposition(filename))
+		require.NoError(t, err, filename)
+		assert.Equal(t, "attachment", disposition)
+		assert.Equal(t, filename, params["filename"])
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"bezz-backend/internal/models"
+	"bezz-backend/internal/services"
+)
+
+// SectorHandler handles the sector taxonomy endpoints
+type SectorHandler struct {
+	sectorService *services.SectorService
+}
+
+// NewSectorHandler creates a new sector handler
+func NewSectorHandler(sectorService *services.SectorService) *SectorHandler {
+	return &SectorHandler{
+		sectorService: sectorService,
+	}
+}
+
+// List returns the enabled sectors briefs can choose from
+func (h *SectorHandler) List(c *gin.Context) {
+	sectors, err := h.sectorService.ListSectors(c.Request.Context(), false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list sectors",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    sectors,
+	})
+}
+
+// AdminList returns every sector, including disabled ones
+func (h *SectorHandler) AdminList(c *gin.Context) {
+	sectors, err := h.sectorService.ListSectors(c.Request.Context(), true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to list sectors",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    sectors,
+	})
+}
+
+// Upsert creates or replaces a sector
+func (h *SectorHandler) Upsert(c *gin.Context) {
+	var sector models.Sector
+	if err := c.ShouldBindJSON(&sector); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   "Invalid request body",
+		})
+		return
+	}
+	sector.ID = c.Param("id")
+
+	if err := services.ValidateSector(&sector); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	saved, err := h.sectorService.UpsertSector(c.Request.Context(), &sector)
+	if err != nil {
+		log.Printf("❌ SECTORS: Failed to save sector %s: %v", sector.ID, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to save sector",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    saved,
+		Message: "Sector saved successfully",
+	})
+}
+
+// Delete removes an admin-defined sector or reverts a built-in one to its default
+func (h *SectorHandler) Delete(c *gin.Context) {
+	sectorID := c.Param("id")
+	if err := h.sectorService.DeleteSector(c.Request.Context(), sectorID); err != nil {
+		log.Printf("❌ SECTORS: Failed to delete sector %s: %v", sectorID, err)
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Error:   "Failed to delete sector",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Sector deleted successfully",
+	})
+}
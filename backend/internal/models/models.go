@@ -27,19 +27,51 @@ type Subscription struct {
 
 // BrandBrief represents a brand brief submission
 type BrandBrief struct {
-	ID                  string        `json:"id" firestore:"id"`
-	UserID              string        `json:"userId" firestore:"userId"`
-	CompanyName         string        `json:"companyName" firestore:"companyName"`
-	BusinessDescription string        `json:"businessDescription" firestore:"businessDescription"`
-	Sector              string        `json:"sector" firestore:"sector"`
-	Tone                string        `json:"tone" firestore:"tone"`
-	TargetAudience      string        `json:"targetAudience" firestore:"targetAudience"`
-	Language            string        `json:"language" firestore:"language"` // en, fr
-	AdditionalInfo      string        `json:"additionalInfo,omitempty" firestore:"additionalInfo,omitempty"`
-	Status              string        `json:"status" firestore:"status"` // processing, completed, failed
-	CreatedAt           time.Time     `json:"createdAt" firestore:"createdAt"`
-	UpdatedAt           time.Time     `json:"updatedAt" firestore:"updatedAt"`
-	Results             *BrandResults `json:"results,omitempty" firestore:"results,omitempty"`
+	ID                  string            `json:"id" firestore:"id"`
+	UserID              string            `json:"userId" firestore:"userId"`
+	CompanyName         string            `json:"companyName" firestore:"companyName"`
+	BusinessDescription string            `json:"businessDescription" firestore:"businessDescription"`
+	Sector              string            `json:"sector" firestore:"sector"`
+	SectorID            string            `json:"sectorId,omitempty" firestore:"sectorId,omitempty"`
+	Tone                string            `json:"tone" firestore:"tone"`
+	TargetAudience      string            `json:"targetAudience" firestore:"targetAudience"`
+	Language            string            `json:"language" firestore:"language"` // en, fr
+	AdditionalInfo      string            `json:"additionalInfo,omitempty" firestore:"additionalInfo,omitempty"`
+	Placements          []string          `json:"placements,omitempty" firestore:"placements,omitempty"`
+	Style               string            `json:"style,omitempty" firestore:"style,omitempty"`                   // style preset id for ad images
+	AdStyles            map[string]string `json:"adStyles,omitempty" firestore:"adStyles,omitempty"`             // placement id -> style preset id
+	Assets              *BrandAssets      `json:"assets,omitempty" firestore:"assets,omitempty"`                 // user-supplied logo, product shots and palette
+	VideoDurations      []int             `json:"videoDurations,omitempty" firestore:"videoDurations,omitempty"` // storyboard lengths in seconds
+	Moderation          *ModerationReport `json:"moderation,omitempty" firestore:"moderation,omitempty"`         // content-safety outcomes for admin review
+	Status              string            `json:"status" firestore:"status"`                                     // processing, completed, failed, blocked
+	ShareToken          string            `json:"shareToken,omitempty" firestore:"shareToken,omitempty"`         // enables the read-only brand guidelines page
+	Unpaid              bool              `json:"unpaid,omitempty" firestore:"unpaid,omitempty"`                 // no credit is held: blocked at submission or refunded when blocked later
+	CreatedAt           time.Time         `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt           time.Time         `json:"updatedAt" firestore:"updatedAt"`
+	Results             *BrandResults     `json:"results,omitempty" firestore:"results,omitempty"`
+}
+
+// ModerationReport records the content-safety checks run on a brief's inputs and generated content
+type ModerationReport struct {
+	Status      string           `json:"status" firestore:"status"` // clear, flagged, blocked, unchecked
+	Flags       []ModerationFlag `json:"flags,omitempty" firestore:"flags,omitempty"`
+	NeedsReview bool             `json:"needsReview" firestore:"needsReview"`
+	CheckedAt   time.Time        `json:"checkedAt" firestore:"checkedAt"`
+	Decision    string           `json:"decision,omitempty" firestore:"decision,omitempty"` // approved, rejected
+	ReviewedBy  string           `json:"reviewedBy,omitempty" firestore:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time       `json:"reviewedAt,omitempty" firestore:"reviewedAt,omitempty"`
+	ReviewNote  string           `json:"reviewNote,omitempty" firestore:"reviewNote,omitempty"`
+}
+
+// ModerationFlag is one piece of content the moderation check flagged and what the pipeline did about it
+type ModerationFlag struct {
+	Stage      string    `json:"stage" firestore:"stage"`   // input, strategy, names, identity, ad, video
+	Target     string    `json:"target" firestore:"target"` // e.g. "brief", "ad 3", "video_15s scene 2"
+	Field      string    `json:"field" firestore:"field"`
+	Excerpt    string    `json:"excerpt" firestore:"excerpt"`
+	Categories []string  `json:"categories" firestore:"categories"`
+	Action     string    `json:"action" firestore:"action"` // blocked, regenerated, dropped
+	FlaggedAt  time.Time `json:"flaggedAt" firestore:"flaggedAt"`
 }
 
 // BrandResults contains the AI-generated results
@@ -111,19 +143,45 @@ type AdCampaign struct {
 	Title         string   `json:"title" firestore:"title"`
 	Format        string   `json:"format" firestore:"format"` // social, display, video, print
 	Platform      string   `json:"platform" firestore:"platform"`
+	Placement     string   `json:"placement,omitempty" firestore:"placement,omitempty"`
+	AspectRatio   string   `json:"aspectRatio,omitempty" firestore:"aspectRatio,omitempty"`
 	Copy          AdCopy   `json:"copy" firestore:"copy"`
 	ImagePrompt   string   `json:"imagePrompt" firestore:"imagePrompt"`
+	Style         string   `json:"style,omitempty" firestore:"style,omitempty"` // style preset id the image was rendered in
 	ImageURL      string   `json:"imageUrl,omitempty" firestore:"imageUrl,omitempty"`
 	ObjectName    string   `json:"objectName,omitempty" firestore:"objectName,omitempty"` // GCS object name for signed URL generation
 	TargetSegment string   `json:"targetSegment" firestore:"targetSegment"`
 	Objectives    []string `json:"objectives" firestore:"objectives"`
+
+	CopyRulesVersion string          `json:"copyRulesVersion,omitempty" firestore:"copyRulesVersion,omitempty"`
+	CopyViolations   []CopyViolation `json:"copyViolations,omitempty" firestore:"copyViolations,omitempty"`
+
+	Creatives []AdCreative `json:"creatives,omitempty" firestore:"creatives,omitempty"`
+}
+
+// AdCreative is a finished, ready-to-post creative with copy, CTA and logo composited onto the ad image
+type AdCreative struct {
+	Size       string `json:"size" firestore:"size"` // e.g. 1080x1080
+	Width      int    `json:"width" firestore:"width"`
+	Height     int    `json:"height" firestore:"height"`
+	Layout     string `json:"layout" firestore:"layout"` // bottom_band, top_headline, side_panel, or clean for the image alone
+	ImageURL   string `json:"imageUrl,omitempty" firestore:"imageUrl,omitempty"`
+	ObjectName string `json:"objectName,omitempty" firestore:"objectName,omitempty"`
 }
 
 // AdCopy represents ad copy
 type AdCopy struct {
-	Headline string `json:"headline" firestore:"headline"`
-	Body     string `json:"body" firestore:"body"`
-	CTA      string `json:"cta" firestore:"cta"`
+	Headline    string `json:"headline" firestore:"headline"`
+	Body        string `json:"body" firestore:"body"`
+	Description string `json:"description,omitempty" firestore:"description,omitempty"`
+	CTA         string `json:"cta" firestore:"cta"`
+}
+
+// CopyViolation describes an ad copy field that breaks a platform rule
+type CopyViolation struct {
+	Field   string `json:"field" firestore:"field"` // headline, body, description, cta
+	Rule    string `json:"rule" firestore:"rule"`   // max_length, banned_character, capitalization, punctuation, cta_not_allowed
+	Message string `json:"message" firestore:"message"`
 }
 
 // VideoAd represents a video advertisement
@@ -132,18 +190,37 @@ type VideoAd struct {
 	Title        string       `json:"title" firestore:"title"`
 	Script       string       `json:"script" firestore:"script"`
 	Duration     int          `json:"duration" firestore:"duration"`
+	AspectRatio  string       `json:"aspectRatio,omitempty" firestore:"aspectRatio,omitempty"` // 9:16, 16:9
 	Scenes       []VideoScene `json:"scenes" firestore:"scenes"`
 	VideoURL     string       `json:"videoUrl,omitempty" firestore:"videoUrl,omitempty"`
 	ThumbnailURL string       `json:"thumbnailUrl,omitempty" firestore:"thumbnailUrl,omitempty"`
+
+	VideoObjectName     string `json:"videoObjectName,omitempty" firestore:"videoObjectName,omitempty"`         // animatic, stored as <objectName>.mp4
+	ThumbnailObjectName string `json:"thumbnailObjectName,omitempty" firestore:"thumbnailObjectName,omitempty"` // stored as <objectName>.png
+
+	SubtitlesURL         string `json:"subtitlesUrl,omitempty" firestore:"subtitlesUrl,omitempty"`
+	SubtitlesObjectName  string `json:"subtitlesObjectName,omitempty" firestore:"subtitlesObjectName,omitempty"` // stored as <objectName>.srt
+	StoryboardURL        string `json:"storyboardUrl,omitempty" firestore:"storyboardUrl,omitempty"`
+	StoryboardObjectName string `json:"storyboardObjectName,omitempty" firestore:"storyboardObjectName,omitempty"` // contact sheet, stored as <objectName>.png
+	Voice                string `json:"voice,omitempty" firestore:"voice,omitempty"`                               // text-to-speech voice used for the voiceover
 }
 
 // VideoScene represents a scene in a video ad
 type VideoScene struct {
 	ID           string `json:"id" firestore:"id"`
+	StartTime    int    `json:"startTime" firestore:"startTime"` // seconds from the start of the video
 	Duration     int    `json:"duration" firestore:"duration"`
 	Description  string `json:"description" firestore:"description"`
 	VisualPrompt string `json:"visualPrompt" firestore:"visualPrompt"`
 	Voiceover    string `json:"voiceover" firestore:"voiceover"`
+	OnScreenText string `json:"onScreenText,omitempty" firestore:"onScreenText,omitempty"`
+
+	KeyframeURL        string `json:"keyframeUrl,omitempty" firestore:"keyframeUrl,omitempty"`
+	KeyframeObjectName string `json:"keyframeObjectName,omitempty" firestore:"keyframeObjectName,omitempty"` // stored as <objectName>.png
+
+	VoiceoverURL        string  `json:"voiceoverUrl,omitempty" firestore:"voiceoverUrl,omitempty"`
+	VoiceoverObjectName string  `json:"voiceoverObjectName,omitempty" firestore:"voiceoverObjectName,omitempty"` // stored as <objectName>.wav
+	VoiceoverDuration   float64 `json:"voiceoverDuration,omitempty" firestore:"voiceoverDuration,omitempty"`     // seconds
 }
 
 // APIResponse represents a standard API response
@@ -152,16 +229,37 @@ type APIResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 	Message string      `json:"message,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"` // machine-readable error code, e.g. content_flagged
 }
 
 // BrandBriefRequest represents a brand brief creation request
 type BrandBriefRequest struct {
-	CompanyName    string `json:"companyName" binding:"required"`
-	Sector         string `json:"sector" binding:"required"`
-	Tone           string `json:"tone" binding:"required"`
-	TargetAudience string `json:"targetAudience" binding:"required"`
-	Language       string `json:"language" binding:"required,oneof=en fr"`
-	AdditionalInfo string `json:"additionalInfo,omitempty"`
+	CompanyName     string            `json:"companyName" binding:"required,max=100"`
+	Sector          string            `json:"sector" binding:"required"`
+	Tone            string            `json:"tone" binding:"required,max=200"`
+	TargetAudience  string            `json:"targetAudience" binding:"required,max=500"`
+	Language        string            `json:"language" binding:"required,oneof=en fr"`
+	AdditionalInfo  string            `json:"additionalInfo,omitempty" binding:"max=2000"`
+	Placements      []string          `json:"placements,omitempty"`
+	Style           string            `json:"style,omitempty"`
+	AdStyles        map[string]string `json:"adStyles,omitempty"`
+	LogoAssetID     string            `json:"logoAssetId,omitempty"`
+	ProductAssetIDs []string          `json:"productAssetIds,omitempty"`
+	Palette         []Color           `json:"palette,omitempty"`        // fixed brand colors to keep
+	VideoDurations  []int             `json:"videoDurations,omitempty"` // 15, 30, 60; availability depends on plan
+}
+
+// Sector is an entry in the sector taxonomy used to validate briefs and steer image prompts
+type Sector struct {
+	ID               string            `json:"id" firestore:"id"`
+	Labels           map[string]string `json:"labels" firestore:"labels"` // language code -> label, "en" is required
+	Description      string            `json:"description,omitempty" firestore:"description,omitempty"`
+	Icon             string            `json:"icon,omitempty" firestore:"icon,omitempty"`
+	Synonyms         []string          `json:"synonyms,omitempty" firestore:"synonyms,omitempty"`
+	PhotographyStyle string            `json:"photographyStyle,omitempty" firestore:"photographyStyle,omitempty"`
+	PromptHints      string            `json:"promptHints,omitempty" firestore:"promptHints,omitempty"`
+	Disabled         bool              `json:"disabled,omitempty" firestore:"disabled,omitempty"`
+	UpdatedAt        time.Time         `json:"updatedAt,omitempty" firestore:"updatedAt,omitempty"`
 }
 
 // Auth request/response models
@@ -239,10 +337,49 @@ type CreativeDirectorGPTResponse struct {
 
 // AdSpec represents an ad specification before image generation
 type AdSpec struct {
-	ID          int    `json:"id"`
-	Headline    string `json:"headline"`
-	Body        string `json:"body"`
-	DallePrompt string `json:"dalle_prompt"`
+	ID            int    `json:"id"`
+	Placement     string `json:"placement"`
+	TargetSegment string `json:"target_segment"`
+	Headline      string `json:"headline"`
+	Body          string `json:"body"`
+	Description   string `json:"description"`
+	CTA           string `json:"cta"`
+	DallePrompt   string `json:"dalle_prompt"`
+	Style         string `json:"style,omitempty"` // style preset id, assigned after generation
+}
+
+// VideoDirectorGPTResponse represents the response from Video-Director-GPT
+type VideoDirectorGPTResponse struct {
+	Videos []VideoScriptSpec `json:"videos"`
+}
+
+// VideoScriptSpec is one video script before timing is normalised and keyframes are rendered
+type VideoScriptSpec struct {
+	Duration int              `json:"duration"`
+	Title    string           `json:"title"`
+	Scenes   []VideoSceneSpec `json:"scenes"`
+}
+
+// VideoSceneSpec is one scene of a Video-Director-GPT script
+type VideoSceneSpec struct {
+	Duration     int    `json:"duration"`
+	Description  string `json:"description"`
+	VisualPrompt string `json:"visual_prompt"`
+	Voiceover    string `json:"voiceover"`
+	OnScreenText string `json:"on_screen_text"`
+}
+
+// CopyEditorGPTResponse holds the rewritten fields returned by Copy-Editor-GPT
+type CopyEditorGPTResponse struct {
+	Headline    string `json:"headline,omitempty"`
+	Body        string `json:"body,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ModerationReviewRequest is an admin's decision on a flagged brief
+type ModerationReviewRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approved rejected"`
+	Note     string `json:"note,omitempty"`
 }
 
 // BrandNameSuggestion represents a suggested brand name with rationale
@@ -262,19 +399,185 @@ type BrandIdentity struct {
 	ColorPalette   []Color `json:"colorPalette" firestore:"colorPalette"`
 	LogoImageURL   string  `json:"logoImageUrl,omitempty" firestore:"logoImageUrl,omitempty"`
 	LogoObjectName string  `json:"logoObjectName,omitempty" firestore:"logoObjectName,omitempty"`
+
+	PaletteAnalysis *PaletteAnalysis `json:"paletteAnalysis,omitempty" firestore:"paletteAnalysis,omitempty"`
+	Typography      *Typography      `json:"typography,omitempty" firestore:"typography,omitempty"`
+	LogoVariants    []LogoVariant    `json:"logoVariants,omitempty" firestore:"logoVariants,omitempty"`
+}
+
+// LogoVariant is one rendition of the logo suite derived from the main logo
+type LogoVariant struct {
+	Kind       string `json:"kind" firestore:"kind"` // transparent, icon, wordmark, mono_dark, mono_light, favicon, app_icon, avatar, vector
+	Name       string `json:"name" firestore:"name"` // file name without extension, e.g. "android-chrome-512"
+	Usage      string `json:"usage" firestore:"usage"`
+	Width      int    `json:"width" firestore:"width"`
+	Height     int    `json:"height" firestore:"height"`
+	Format     string `json:"format" firestore:"format"` // png, ico, svg
+	ImageURL   string `json:"imageUrl,omitempty" firestore:"imageUrl,omitempty"`
+	ObjectName string `json:"objectName" firestore:"objectName"` // GCS object name, stored as <objectName>.<format>
+}
+
+// Typography is the brand's type system, built from the open-licensed font catalogue
+type Typography struct {
+	Heading    FontSelection   `json:"heading" firestore:"heading"`
+	Body       FontSelection   `json:"body" firestore:"body"`
+	ScaleRatio float64         `json:"scaleRatio" firestore:"scaleRatio"`
+	ScaleName  string          `json:"scaleName" firestore:"scaleName"` // e.g. "major third"
+	Scale      []TypeScaleStep `json:"scale" firestore:"scale"`
+	UsageRules []string        `json:"usageRules" firestore:"usageRules"`
+	Rationale  string          `json:"rationale,omitempty" firestore:"rationale,omitempty"`
+}
+
+// FontSelection is a catalogue font and the weights the brand uses
+type FontSelection struct {
+	Family    string `json:"family" firestore:"family"`
+	Category  string `json:"category" firestore:"category"` // serif, sans-serif, display, monospace, handwriting
+	Weights   []int  `json:"weights" firestore:"weights"`
+	License   string `json:"license" firestore:"license"` // SPDX identifier
+	SourceURL string `json:"sourceUrl" firestore:"sourceUrl"`
+	Fallback  string `json:"fallback" firestore:"fallback"` // CSS fallback stack
+}
+
+// TypeScaleStep is one text style in the type scale
+type TypeScaleStep struct {
+	Name       string  `json:"name" firestore:"name"` // display, h1-h4, body, small, caption
+	Family     string  `json:"family" firestore:"family"`
+	Weight     int     `json:"weight" firestore:"weight"`
+	SizePx     float64 `json:"sizePx" firestore:"sizePx"`
+	SizeRem    float64 `json:"sizeRem" firestore:"sizeRem"`
+	LineHeight float64 `json:"lineHeight" firestore:"lineHeight"`
+}
+
+// PaletteAnalysis is the accessibility and harmony analysis of a brand palette
+type PaletteAnalysis struct {
+	Colors   []ColorSpec     `json:"colors" firestore:"colors"`
+	Contrast []ContrastCheck `json:"contrast" firestore:"contrast"`
+	Harmony  PaletteHarmony  `json:"harmony" firestore:"harmony"`
+	DarkMode []Color         `json:"darkMode" firestore:"darkMode"`
+	Issues   []string        `json:"issues,omitempty" firestore:"issues,omitempty"`
+}
+
+// ColorSpec describes one palette color in every color space designers and printers need
+type ColorSpec struct {
+	Name   string    `json:"name" firestore:"name"`
+	Hex    string    `json:"hex" firestore:"hex"`
+	Usage  string    `json:"usage" firestore:"usage"`
+	RGB    RGBValue  `json:"rgb" firestore:"rgb"`
+	HSL    HSLValue  `json:"hsl" firestore:"hsl"`
+	CMYK   CMYKValue `json:"cmyk" firestore:"cmyk"`
+	Tints  []string  `json:"tints" firestore:"tints"`   // lighter steps, mixed with white
+	Shades []string  `json:"shades" firestore:"shades"` // darker steps, mixed with black
+}
+
+// RGBValue is a color in 0-255 sRGB channels
+type RGBValue struct {
+	R int `json:"r" firestore:"r"`
+	G int `json:"g" firestore:"g"`
+	B int `json:"b" firestore:"b"`
+}
+
+// HSLValue is a color as hue in degrees and saturation/lightness in percent
+type HSLValue struct {
+	H float64 `json:"h" firestore:"h"`
+	S float64 `json:"s" firestore:"s"`
+	L float64 `json:"l" firestore:"l"`
+}
+
+// CMYKValue is a naive (uncalibrated) CMYK conversion in percent
+type CMYKValue struct {
+	C float64 `json:"c" firestore:"c"`
+	M float64 `json:"m" firestore:"m"`
+	Y float64 `json:"y" firestore:"y"`
+	K float64 `json:"k" firestore:"k"`
+}
+
+// ContrastCheck is the WCAG 2.2 contrast between two colors used as text and background
+type ContrastCheck struct {
+	Foreground string  `json:"foreground" firestore:"foreground"`
+	Background string  `json:"background" firestore:"background"`
+	Ratio      float64 `json:"ratio" firestore:"ratio"`
+	AA         bool    `json:"aa" firestore:"aa"`           // 4.5:1, normal text
+	AALarge    bool    `json:"aaLarge" firestore:"aaLarge"` // 3:1, large text and UI components
+	AAA        bool    `json:"aaa" firestore:"aaa"`         // 7:1, normal text
+	AAALarge   bool    `json:"aaaLarge" firestore:"aaaLarge"`
+	Usable     bool    `json:"usable" firestore:"usable"` // false when the pair fails even large-text AA
+}
+
+// PaletteHarmony classifies the palette's hue relationships and suggests harmonious companions
+type PaletteHarmony struct {
+	Scheme             string   `json:"scheme" firestore:"scheme"` // monochromatic, analogous, complementary, split_complementary, triadic, custom
+	Complementary      string   `json:"complementary" firestore:"complementary"`
+	Analogous          []string `json:"analogous" firestore:"analogous"`
+	SplitComplementary []string `json:"splitComplementary" firestore:"splitComplementary"`
+	Triadic            []string `json:"triadic" firestore:"triadic"`
+}
+
+// BrandAsset is an image a user uploaded to constrain generation
+type BrandAsset struct {
+	ID         string    `json:"id" firestore:"id"`
+	UserID     string    `json:"userId" firestore:"userId"`
+	Kind       string    `json:"kind" firestore:"kind"` // logo, product
+	FileName   string    `json:"fileName" firestore:"fileName"`
+	ObjectName string    `json:"objectName" firestore:"objectName"` // GCS object name, stored as PNG
+	URL        string    `json:"url,omitempty" firestore:"url,omitempty"`
+	Width      int       `json:"width" firestore:"width"`
+	Height     int       `json:"height" firestore:"height"`
+	Palette    []Color   `json:"palette,omitempty" firestore:"palette,omitempty"` // colors extracted from an uploaded logo
+	CreatedAt  time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// BrandAssets holds the existing brand assets a brief must honour
+type BrandAssets struct {
+	Logo          *BrandAsset  `json:"logo,omitempty" firestore:"logo,omitempty"`
+	ProductShots  []BrandAsset `json:"productShots,omitempty" firestore:"productShots,omitempty"`
+	Palette       []Color      `json:"palette,omitempty" firestore:"palette,omitempty"`
+	PaletteSource string       `json:"paletteSource,omitempty" firestore:"paletteSource,omitempty"` // user, logo
+}
+
+// ExportJob is a brand kit export built in the background and stored in GCS for download
+type ExportJob struct {
+	ID          string     `json:"id" firestore:"id"`
+	UserID      string     `json:"userId" firestore:"userId"`
+	BriefID     string     `json:"briefId" firestore:"briefId"`
+	CompanyName string     `json:"companyName" firestore:"companyName"`
+	Format      string     `json:"format" firestore:"format"`                         // zip, pdf, pptx
+	Template    string     `json:"template,omitempty" firestore:"template,omitempty"` // brand book template for PDF exports
+	Status      string     `json:"status" firestore:"status"`                         // queued, running, completed, failed
+	Error       string     `json:"error,omitempty" firestore:"error,omitempty"`
+	ObjectName  string     `json:"-" firestore:"objectName,omitempty"` // GCS object holding the finished export
+	Filename    string     `json:"filename,omitempty" firestore:"filename,omitempty"`
+	ContentType string     `json:"contentType,omitempty" firestore:"contentType,omitempty"`
+	Size        int64      `json:"size,omitempty" firestore:"size,omitempty"` // bytes
+	DownloadURL string     `json:"downloadUrl,omitempty" firestore:"-"`       // signed on read, never stored
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" firestore:"-"`         // when DownloadURL stops working
+	CreatedAt   time.Time  `json:"createdAt" firestore:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty" firestore:"startedAt,omitempty"` // when the export began running
+	CompletedAt *time.Time `json:"completedAt,omitempty" firestore:"completedAt,omitempty"`
 }
 
 // Color represents a brand color with psychology and usage
 type Color struct {
 	Name       string `json:"name" firestore:"name"`
 	Hex        string `json:"hex" firestore:"hex"`
-	Usage      string `json:"usage" firestore:"usage"` // "primary", "secondary", "accent"
+	Usage      string `json:"usage" firestore:"usage"` // "primary", "secondary", "accent", "neutral"
 	Psychology string `json:"psychology" firestore:"psychology"`
 }
 
 // LogoDesignerGPTResponse represents the response from Logo-Designer-GPT
 type LogoDesignerGPTResponse struct {
-	LogoConcept  string  `json:"logo_concept"`
-	ColorPalette []Color `json:"color_palette"`
-	DallePrompt  string  `json:"dalle_prompt"`
+	LogoConcept  string           `json:"logo_concept"`
+	ColorPalette []Color          `json:"color_palette"`
+	DallePrompt  string           `json:"dalle_prompt"`
+	Typography   TypographyChoice `json:"typography"`
+}
+
+// TypographyChoice is Logo-Designer-GPT's font selection, validated against the catalogue
+type TypographyChoice struct {
+	HeadingFont    string   `json:"heading_font"`
+	HeadingWeights []int    `json:"heading_weights"`
+	BodyFont       string   `json:"body_font"`
+	BodyWeights    []int    `json:"body_weights"`
+	ScaleRatio     float64  `json:"scale_ratio"`
+	UsageRules     []string `json:"usage_rules"`
+	Rationale      string   `json:"rationale"`
 }
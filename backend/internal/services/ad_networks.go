@@ -0,0 +1,368 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"bezz-backend/internal/models"
+)
+
+// Ad network export limits and defaults
+const (
+	DefaultAdNetworkDays  = 30
+	maxAdNetworkDays      = 365
+	adNetworkImagesFolder = "images"
+)
+
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// AdNetwork describes an ad network the brief's ads can be bulk exported to
+type AdNetwork struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AdNetworkExporter turns a brief's ads into a bulk upload bundle for one ad network
+type AdNetworkExporter interface {
+	Network() AdNetwork
+	Build(brief *models.BrandBrief, opts AdNetworkExportOptions) (*AdNetworkBundle, error)
+}
+
+// AdNetworkBundle is an exporter's output: upload sheets, the images they reference and import instructions
+type AdNetworkBundle struct {
+	Files  []AdNetworkFile
+	Images []AdNetworkImage
+	Readme string
+}
+
+// AdNetworkFile is a sheet written to the root of the bundle
+type AdNetworkFile struct {
+	Name string
+	Data []byte
+}
+
+// AdNetworkImage is an ad image copied into the bundle's images folder
+type AdNetworkImage struct {
+	Filename string
+	URL      string
+	Object   string // GCS object read in place of URL; empty for legacy records
+}
+
+// AdNetworkExportOptions are the user-supplied settings shared by every ad network export
+type AdNetworkExportOptions struct {
+	TotalBudget float64   // total spend in the ad account's currency, split across campaigns or ad sets
+	Days        int       // flight length
+	StartDate   time.Time // first day of the flight
+	LandingURL  string    // destination for every ad
+	Countries   []string  // ISO 3166 alpha-2 codes
+}
+
+// ValidateAdNetworkExportOptions checks the budget, flight, landing page and countries, filling in defaults
+func ValidateAdNetworkExportOptions(opts *AdNetworkExportOptions) error {
+	if opts.TotalBudget <= 0 || math.IsInf(opts.TotalBudget, 0) || math.IsNaN(opts.TotalBudget) {
+		return fmt.Errorf("budget must be a positive amount")
+	}
+	if opts.Days == 0 {
+		opts.Days = DefaultAdNetworkDays
+	}
+	if opts.Days < 1 || opts.Days > maxAdNetworkDays {
+		return fmt.Errorf("days must be between 1 and %d", maxAdNetworkDays)
+	}
+	if opts.StartDate.IsZero() {
+		opts.StartDate = time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	}
+
+	link, err := url.Parse(opts.LandingURL)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) landing page URL")
+	}
+
+	if len(opts.Countries) == 0 {
+		return fmt.Errorf("at least one country is required (ISO codes, e.g. NG,GH)")
+	}
+	for i, country := range opts.Countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !countryPattern.MatchString(country) {
+			return fmt.Errorf("invalid country code: %q", opts.Countries[i])
+		}
+		opts.Countries[i] = country
+	}
+	return nil
+}
+
+// AdNetworks lists the ad networks the service can export to
+func (s *ExportService) AdNetworks() []AdNetwork {
+	networks := make([]AdNetwork, len(s.adNetworks))
+	for i, exporter := range s.adNetworks {
+		networks[i] = exporter.Network()
+	}
+	return networks
+}
+
+// ValidateAdNetwork checks that a requested ad network has an exporter
+func (s *ExportService) ValidateAdNetwork(id string) error {
+	if _, ok := s.adNetworkExporter(id); !ok {
+		ids := make([]string, len(s.adNetworks))
+		for i, exporter := range s.adNetworks {
+			ids[i] = exporter.Network().ID
+		}
+		return fmt.Errorf("unknown ad network %q (supported: %s)", id, strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+func (s *ExportService) adNetworkExporter(id string) (AdNetworkExporter, bool) {
+	for _, exporter := range s.adNetworks {
+		if exporter.Network().ID == id {
+			return exporter, true
+		}
+	}
+	return nil, false
+}
+
+// GenerateAdNetworkExport builds a bulk upload ZIP for one ad network: its sheets, the images they reference and a README
+func (s *ExportService) GenerateAdNetworkExport(ctx context.Context, briefID string, userID string, network string, opts AdNetworkExportOptions) ([]byte, string, string, error) {
+	exporter, ok := s.adNetworkExporter(network)
+	if !ok {
+		return nil, "", "", fmt.Errorf("unknown ad network: %s", network)
+	}
+
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch brief: %w", err)
+	}
+	if brief.Results == nil {
+		return nil, "", "", fmt.Errorf("brief has no results to export")
+	}
+
+	bundle, err := exporter.Build(brief, opts)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+
+	for _, f := range bundle.Files {
+		file, err := zipWriter.Create(f.Name)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if _, err := file.Write(f.Data); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	images := make([]exportAsset, len(bundle.Images))
+	for i, img := range bundle.Images {
+		images[i] = exportAsset{object: img.Object, url: img.URL, filename: adNetworkImagesFolder + "/" + img.Filename}
+	}
+	missing, err := s.addAssetsToZip(ctx, zipWriter, images)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to add images to %s bundle: %w", network, err)
+	}
+
+	// The README goes last so it can point out images that are missing from the bundle
+	file, err := zipWriter.Create("README.txt")
+	if err != nil {
+		return nil, "", "", err
+	}
+	if _, err := file.Write([]byte(bundle.Readme + missingAssetsText(missing))); err != nil {
+		return nil, "", "", err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, "", "", fmt.Errorf("failed to finalize ZIP: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s-%s-ads-%s.zip", strings.ReplaceAll(brief.CompanyName, " ", "-"), network, time.Now().Format("2006-01-02"))
+	log.Printf("✅ EXPORT: %s bundle created for %s (%d images)", exporter.Network().Name, brief.CompanyName, len(bundle.Images))
+	return buf.Bytes(), "application/zip", filename, nil
+}
+
+// networkAd is an ad picked for a network export, numbered by its position in the brief
+type networkAd struct {
+	number int
+	ad     models.AdCampaign
+}
+
+// segmentAds are the network ads aimed at one target segment
+type segmentAds struct {
+	segment models.TargetSegment
+	ads     []networkAd
+}
+
+// networkAdsFor picks the brief's ads running on the given platforms; no platforms means every ad
+func networkAdsFor(brief *models.BrandBrief, platforms ...string) []networkAd {
+	var ads []networkAd
+	for i, ad := range brief.Results.Ads {
+		if len(platforms) == 0 || containsString(platforms, strings.ToLower(ad.Platform)) {
+			ads = append(ads, networkAd{number: i + 1, ad: ad})
+		}
+	}
+	return ads
+}
+
+// groupAdsBySegment groups ads under their target segment, in strategy order; ads for unknown segments
+// go to the first, and segments without ads are dropped
+func groupAdsBySegment(brief *models.BrandBrief, ads []networkAd) []segmentAds {
+	segments := brief.Results.Strategy.TargetSegments
+	if len(segments) == 0 {
+		segments = []models.TargetSegment{{Name: "Broad audience", Demographics: brief.TargetAudience}}
+	}
+
+	groups := make([]segmentAds, len(segments))
+	for i, segment := range segments {
+		groups[i].segment = segment
+	}
+	for _, ad := range ads {
+		group := 0
+		for i, segment := range segments {
+			if strings.EqualFold(strings.TrimSpace(ad.ad.TargetSegment), segment.Name) {
+				group = i
+				break
+			}
+		}
+		groups[group].ads = append(groups[group].ads, ad)
+	}
+
+	used := groups[:0]
+	for _, group := range groups {
+		if len(group.ads) > 0 {
+			used = append(used, group)
+		}
+	}
+	return used
+}
+
+// splitBudget divides a total into n shares in cents, so the shares add up to the total exactly
+func splitBudget(total float64, n int) []float64 {
+	if n == 0 {
+		return nil
+	}
+	cents := int64(math.Round(total * 100))
+	share, remainder := cents/int64(n), cents%int64(n)
+	shares := make([]float64, n)
+	for i := range shares {
+		budget := share
+		if int64(i) < remainder {
+			budget++
+		}
+		shares[i] = float64(budget) / 100
+	}
+	return shares
+}
+
+// networkAdImage is the image bundled for an ad: its generated image, or its first finished creative
+func networkAdImage(ad networkAd) (AdNetworkImage, bool) {
+	imageURL, object, ext := ad.ad.ImageURL, storedObject(ad.ad.ObjectName, ".png"), imageURLExt(ad.ad.ImageURL, ".jpg")
+	if imageURL == "" && len(ad.ad.Creatives) > 0 {
+		creative := ad.ad.Creatives[0]
+		imageURL, object, ext = creative.ImageURL, storedObject(creative.ObjectName, ".png"), ".png"
+	}
+	if imageURL == "" {
+		return AdNetworkImage{}, false
+	}
+	return AdNetworkImage{Filename: fmt.Sprintf("ad-%d%s", ad.number, ext), URL: imageURL, Object: object}, true
+}
+
+// imageURLExt is the file extension of an image URL, or fallback when it has none
+func imageURLExt(imageURL string, fallback string) string {
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return fallback
+	}
+	switch ext := strings.ToLower(path.Ext(parsed.Path)); ext {
+	case ".jpg", ".jpeg", ".png":
+		return ext
+	}
+	return fallback
+}
+
+// networkObjective maps ad objective keywords to a network's campaign objective
+type networkObjective struct {
+	keywords     []string
+	objective    string
+	optimization string
+}
+
+// matchObjective picks the first ad objective the network has an equivalent for; the last entry is the fallback
+func matchObjective(ads []networkAd, objectives []networkObjective) networkObjective {
+	for _, ad := range ads {
+		for _, objective := range ad.ad.Objectives {
+			objective = strings.ToLower(objective)
+			for _, candidate := range objectives {
+				for _, keyword := range candidate.keywords {
+					if strings.Contains(objective, keyword) {
+						return candidate
+					}
+				}
+			}
+		}
+	}
+	return objectives[len(objectives)-1]
+}
+
+// fitsLimit reports whether text is non-empty and at most max characters
+func fitsLimit(text string, max int) bool {
+	return text != "" && utf8.RuneCountInString(text) <= max
+}
+
+// truncateWords shortens text to at most max characters, cutting at a word boundary where possible
+func truncateWords(text string, max int) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)[:max]
+	if cut := strings.LastIndex(string(runes), " "); cut > 0 {
+		return strings.TrimRight(string(runes)[:cut], " ,;:-")
+	}
+	return string(runes)
+}
+
+// writeSheet writes CSV rows keyed by column name, leaving missing columns blank
+func writeSheet(columns []string, rows []map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// adNetworkReadme is the shared README header: what was exported and the flight
+func adNetworkReadme(title, companyName string, opts AdNetworkExportOptions) string {
+	return fmt.Sprintf(`%s - %s
+Generated: %s
+
+FLIGHT: %s to %s (%d days)
+COUNTRIES: %s
+LANDING PAGE: %s
+
+`, strings.ToUpper(companyName), title, time.Now().Format("January 2, 2006"),
+		opts.StartDate.Format("January 2, 2006"), opts.StartDate.AddDate(0, 0, opts.Days).Format("January 2, 2006"), opts.Days,
+		strings.Join(opts.Countries, ", "), opts.LandingURL)
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+// readSheet parses a CSV sheet into rows keyed by column name
+func readSheet(t *testing.T, data []byte) ([]string, []map[string]string) {
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	var rows []map[string]string
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, column := range records[0] {
+			if record[i] != "" {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return records[0], rows
+}
+
+func sampleAdNetworkBrief() *models.BrandBrief {
+	brief := sampleMetaAdsBrief()
+	brief.Results.Strategy.ValueProposition = "Fresh beans, roasted to order and delivered to your office every week."
+	brief.Results.Ads = append(brief.Results.Ads,
+		models.AdCampaign{Platform: "google", Placement: "google_display", TargetSegment: "Busy Bola", ImageURL: "https://cdn.example.com/ads/5.png",
+			Creatives: composedCreatives("ads/5", adPlacements["google_display"]),
+			Copy:      models.AdCopy{Headline: "Office coffee, fixed!", Body: "Specialty beans for teams who run on caffeine", Description: "Free first bag", CTA: "Shop now"}},
+		models.AdCampaign{Platform: "linkedin", TargetSegment: "Student Sam", Objectives: []string{"Lead generation"},
+			Copy: models.AdCopy{Headline: "Fuel your team", Body: "Coffee your team will actually thank you for", CTA: "Request Demo"}},
+	)
+	brief.Results.BrandIdentity.LogoImageURL = "https://cdn.example.com/logo.png"
+	return brief
+}
+
+// composedCreatives lists the creatives ComposeCreatives stores for a placement's ad
+func composedCreatives(objectName string, placement AdPlacement) []models.AdCreative {
+	var creatives []models.AdCreative
+	add := func(size CreativeSize, layout, object string) {
+		creatives = append(creatives, models.AdCreative{
+			Size: size.String(), Width: size.Width, Height: size.Height, Layout: layout,
+			ImageURL: "https://cdn.example.com/" + object + ".png", ObjectName: object,
+		})
+	}
+	for _, size := range placement.creativeSizes {
+		add(size, creativeLayoutFor(placement, size), fmt.Sprintf("%s_creative_%s", objectName, size))
+	}
+	for _, size := range placement.cleanSizes {
+		add(size, cleanCreativeLayout, fmt.Sprintf("%s_clean_%s", objectName, size))
+	}
+	return creatives
+}
+
+func TestGoogleAdsExporter_BuildsSearchAndDisplayAds(t *testing.T) {
+	bundle, err := googleAdsExporter{}.Build(sampleAdNetworkBrief(), sampleMetaAdsOptions())
+	require.NoError(t, err)
+	require.Len(t, bundle.Files, 1)
+
+	columns, rows := readSheet(t, bundle.Files[0].Data)
+	assert.Equal(t, googleAdsColumns, columns)
+
+	var campaigns, searchAds, displayAds []map[string]string
+	for _, row := range rows {
+		switch {
+		case row["Campaign Type"] != "":
+			campaigns = append(campaigns, row)
+		case row["Ad type"] == "Responsive search ad":
+			searchAds = append(searchAds, row)
+		case row["Ad type"] == "Responsive display ad":
+			displayAds = append(displayAds, row)
+		}
+	}
+
+	// Daily budgets split the total over the flight and both campaigns
+	require.Len(t, campaigns, 2)
+	assert.Equal(t, "3.57", campaigns[0]["Budget"])
+	assert.Equal(t, "3.57", campaigns[1]["Budget"], "budgets round down so the flight never overspends")
+	assert.Equal(t, "2026-11-15", campaigns[0]["End Date"])
+
+	// A search ad per segment, with headlines and descriptions inside Google's limits
+	require.Len(t, searchAds, 2)
+	for _, ad := range searchAds {
+		assert.NotEmpty(t, ad["Headline 3"], "responsive search ads need three headlines")
+		assert.NotEmpty(t, ad["Description 2"], "responsive search ads need two descriptions")
+		for column, value := range ad {
+			if strings.HasPrefix(column, "Headline") {
+				assert.LessOrEqual(t, utf8.RuneCountInString(value), googleHeadlineMax, column)
+				assert.NotContains(t, value, "!")
+			}
+			if strings.HasPrefix(column, "Description") {
+				assert.LessOrEqual(t, utf8.RuneCountInString(value), googleDescriptionMax, column)
+			}
+		}
+	}
+	assert.Equal(t, "Office coffee, fixed", searchAds[0]["Headline 4"])
+	assert.Equal(t, "acmecoffee", searchAds[0]["Path 1"])
+
+	// A display ad for the Google ad, referencing its bundled images
+	require.Len(t, displayAds, 1)
+	assert.Equal(t, "ad-5.png", displayAds[0]["Marketing image"])
+	assert.Equal(t, "ad-5-square.png", displayAds[0]["Square marketing image"])
+	assert.Equal(t, "logo.png", displayAds[0]["Logo image"])
+	assert.Equal(t, "Shop now", displayAds[0]["Call to action"])
+	assert.Equal(t, "Specialty beans for teams who run on caffeine", displayAds[0]["Long headline"])
+
+	var images, objects []string
+	for _, img := range bundle.Images {
+		images = append(images, img.Filename)
+		objects = append(objects, img.Object)
+	}
+	assert.Equal(t, []string{"logo.png", "ad-5.png", "ad-5-square.png"}, images)
+	assert.Equal(t, []string{"", "ads/5_clean_1200x628.png", "ads/5_clean_1200x1200.png"}, objects, "display ads use the text-free crops")
+}
+
+func TestGoogleAdsExporter_SkipsDisplayAdsWithoutCleanCrops(t *testing.T) {
+	brief := sampleAdNetworkBrief()
+	for i := range brief.Results.Ads {
+		brief.Results.Ads[i].Creatives = nil
+	}
+
+	bundle, err := googleAdsExporter{}.Build(brief, sampleMetaAdsOptions())
+	require.NoError(t, err)
+	_, rows := readSheet(t, bundle.Files[0].Data)
+	for _, row := range rows {
+		assert.NotEqual(t, "Responsive display ad", row["Ad type"], "the raw image is not a 1.91:1 marketing image")
+	}
+	assert.Empty(t, bundle.Images)
+}
+
+func TestLinkedInAdsExporter_BuildsSponsoredContent(t *testing.T) {
+	bundle, err := linkedInAdsExporter{}.Build(sampleAdNetworkBrief(), sampleMetaAdsOptions())
+	require.NoError(t, err)
+
+	columns, rows := readSheet(t, bundle.Files[0].Data)
+	assert.Equal(t, linkedInColumns, columns)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "Acme Coffee - Busy Bola", rows[0]["Campaign Name"])
+	assert.Equal(t, "Lead generation", rows[0]["Campaign Objective"])
+	assert.Equal(t, "50.01", rows[0]["Total Budget"])
+	assert.Equal(t, "Learn More", rows[0]["Call To Action"], "unknown CTAs fall back to the placement's first")
+	assert.Equal(t, "Acme Coffee - Student Sam", rows[1]["Campaign Name"])
+	assert.Equal(t, "Request Demo", rows[1]["Call To Action"])
+	assert.Equal(t, "Coffee your team will actually thank you for", rows[1]["Introductory Text"])
+	assert.Empty(t, bundle.Images)
+	assert.Contains(t, bundle.Readme, "CAMPAIGN GROUP: Acme Coffee - Nov 2026")
+}
+
+func TestLinkedInAdsExporter_NoLinkedInAds(t *testing.T) {
+	brief := sampleAdNetworkBrief()
+	brief.Results.Ads = brief.Results.Ads[:2]
+	_, err := linkedInAdsExporter{}.Build(brief, sampleMetaAdsOptions())
+	assert.Error(t, err)
+}
+
+func TestExportService_AdNetworks(t *testing.T) {
+	service := NewExportService(nil, nil, nil, "")
+	var ids []string
+	for _, network := range service.AdNetworks() {
+		ids = append(ids, network.ID)
+	}
+	assert.Equal(t, []string{"meta", "google", "linkedin"}, ids)
+	assert.NoError(t, service.ValidateAdNetwork("google"))
+	err := service.ValidateAdNetwork("tiktok")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "meta, google, linkedin")
+}
+
+func TestCollectCopy(t *testing.T) {
+	picked := collectCopy([]string{"Short", "short", "A much longer line that will not fit", ""}, 20, 5, 2, strings.TrimSpace)
+	assert.Equal(t, []string{"Short", "A much longer line"}, picked, "duplicates are dropped and long lines shortened only to reach the minimum")
+	assert.Equal(t, "Café", truncateWords("Café au lait", 7))
+	assert.Equal(t, []float64{33.34, 33.33, 33.33}, splitBudget(100, 3))
+}
+
+func TestValidateAdNetworkExportOptions(t *testing.T) {
+	opts := AdNetworkExportOptions{TotalBudget: 50, LandingURL: "https://acme.example.com", Countries: []string{" ng"}}
+	require.NoError(t, ValidateAdNetworkExportOptions(&opts))
+	assert.Equal(t, DefaultAdNetworkDays, opts.Days)
+	assert.Equal(t, []string{"NG"}, opts.Countries)
+	assert.True(t, opts.StartDate.After(time.Now()))
+
+	for name, broken := range map[string]AdNetworkExportOptions{
+		"budget":  {TotalBudget: 0, LandingURL: "https://a.com", Countries: []string{"NG"}},
+		"days":    {TotalBudget: 1, Days: 400, LandingURL: "https://a.com", Countries: []string{"NG"}},
+		"url":     {TotalBudget: 1, LandingURL: "acme.com", Countries: []string{"NG"}},
+		"country": {TotalBudget: 1, LandingURL: "https://a.com", Countries: []string{"Nigeria"}},
+		"none":    {TotalBudget: 1, LandingURL: "https://a.com"},
+	} {
+		assert.Error(t, ValidateAdNetworkExportOptions(&broken), name)
+	}
+}
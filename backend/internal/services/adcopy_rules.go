@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	openai "github.com/openai/openai-go/v2"
+
+	bezzmodels "bezz-backend/internal/models"
+	"bezz-backend/internal/prompts"
+)
+
+// AdCopyRules holds the editorial constraints a placement enforces on ad copy
+type AdCopyRules struct {
+	Version        string
+	HeadlineMax    int    // characters, counted as runes
+	BodyMax        int    // characters, counted as runes
+	DescriptionMax int    // 0 means the placement has no description field
+	BannedChars    string // characters rejected anywhere in the copy
+	NoEmoji        bool
+	NoHeadlineBang bool     // no "!" in the headline
+	MaxBangs       int      // maximum "!" across body and description, -1 for no limit
+	NoAllCaps      bool     // no shouted words (5+ letters in capitals)
+	NoRepeatPunct  bool     // no "!!", "??", "!?" style gimmicks
+	CTAs           []string // the platform's call-to-action values; the first is the fallback
+}
+
+// currentAdCopyRulesVersion is the rule set applied to newly generated ads
+const currentAdCopyRulesVersion = "2025-06"
+
+// Copy rules table, including the allowed CTAs - versioned so ads keep referring to the rules they were checked against
+var adCopyRules = map[string]map[string]AdCopyRules{
+	"2025-06": {
+		"instagram_feed": {
+			HeadlineMax: 40, BodyMax: 125, DescriptionMax: 30,
+			MaxBangs: -1, NoRepeatPunct: true,
+			CTAs: []string{"Learn More", "Shop Now", "Sign Up", "Book Now", "Contact Us", "Download", "Get Offer", "Order Now", "Subscribe"},
+		},
+		"instagram_story": {
+			HeadlineMax: 40, BodyMax: 125, DescriptionMax: 30,
+			MaxBangs: -1, NoRepeatPunct: true,
+			CTAs: []string{"Learn More", "Shop Now", "Sign Up", "Book Now", "Contact Us", "Download", "Get Offer", "Order Now", "Subscribe"},
+		},
+		"linkedin_sponsored": {
+			HeadlineMax: 70, BodyMax: 150, DescriptionMax: 70,
+			BannedChars: "<>", MaxBangs: 1, NoAllCaps: true, NoRepeatPunct: true,
+			CTAs: []string{"Learn More", "Apply", "Download", "View Quote", "Sign Up", "Subscribe", "Register", "Join", "Attend", "Request Demo"},
+		},
+		"google_display": {
+			HeadlineMax: 30, BodyMax: 90, DescriptionMax: 90,
+			BannedChars: "★☆✓✔►▶●■♥|^~<>{}[]", NoEmoji: true,
+			NoHeadlineBang: true, MaxBangs: 1, NoAllCaps: true, NoRepeatPunct: true,
+			CTAs: []string{"Learn more", "Shop now", "Sign up", "Get quote", "Contact us", "Book now", "Apply now", "Download", "Subscribe", "Visit site"},
+		},
+		"x_post": {
+			HeadlineMax: 70, BodyMax: 280,
+			MaxBangs: -1,
+			CTAs:     []string{"Learn more", "Shop now", "Sign up", "Book now", "Download", "Visit site"},
+		},
+		"print": {
+			HeadlineMax: 60, BodyMax: 300,
+			MaxBangs: 1, NoRepeatPunct: true,
+			CTAs: []string{"Visit our website", "Call today", "Visit us in store", "Scan the QR code"},
+		},
+	},
+}
+
+// copyRulesFor returns the current rules for a placement, falling back to the first default placement
+func copyRulesFor(placementID string) AdCopyRules {
+	return copyRulesForVersion(currentAdCopyRulesVersion, placementID)
+}
+
+// copyRulesForVersion returns a placement's rules as of the given version; ads saved before versioning
+// (or with an unknown version) are checked against the current rules
+func copyRulesForVersion(version string, placementID string) AdCopyRules {
+	table, ok := adCopyRules[version]
+	if !ok {
+		version, table = currentAdCopyRulesVersion, adCopyRules[currentAdCopyRulesVersion]
+	}
+	rules, ok := table[placementID]
+	if !ok {
+		rules = table[defaultPlacementIDs[0]]
+	}
+	rules.Version = version
+	return rules
+}
+
+// promptSummary describes the rules ValidateAdCopy enforces in the form Creative-Director-GPT and Copy-Editor-GPT expect
+func (r AdCopyRules) promptSummary() string {
+	summary := fmt.Sprintf("headline ≤ %d chars | body ≤ %d chars", r.HeadlineMax, r.BodyMax)
+	if r.DescriptionMax > 0 {
+		summary += fmt.Sprintf(" | description ≤ %d chars", r.DescriptionMax)
+	} else {
+		summary += " | no description (leave empty)"
+	}
+	if r.NoHeadlineBang {
+		summary += " | no \"!\" in headline"
+	}
+	switch {
+	case r.MaxBangs == 0:
+		summary += " | no \"!\" in body or description"
+	case r.MaxBangs > 0:
+		summary += fmt.Sprintf(" | at most %d \"!\" across body and description", r.MaxBangs)
+	}
+	if r.NoAllCaps {
+		summary += " | no ALL-CAPS words"
+	}
+	if r.NoEmoji {
+		summary += " | no emoji"
+	}
+	if r.BannedChars != "" {
+		summary += " | never use these characters: " + strings.Join(strings.Split(r.BannedChars, ""), " ")
+	}
+	return summary
+}
+
+// ValidateAdCopy checks ad copy against its placement's rules as of the version the ad was written for
+func ValidateAdCopy(version string, placementID string, adCopy bezzmodels.AdCopy) []bezzmodels.CopyViolation {
+	rules := copyRulesForVersion(version, placementID)
+	var violations []bezzmodels.CopyViolation
+
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"headline", adCopy.Headline, rules.HeadlineMax},
+		{"body", adCopy.Body, rules.BodyMax},
+		{"description", adCopy.Description, rules.DescriptionMax},
+	}
+
+	bangs := 0
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+
+		// Placements without a description field take none at all
+		if field.max == 0 {
+			violations = append(violations, bezzmodels.CopyViolation{
+				Field:   field.name,
+				Rule:    "not_allowed",
+				Message: fmt.Sprintf("this placement has no %s field", field.name),
+			})
+			continue
+		}
+
+		if length := utf8.RuneCountInString(field.value); length > field.max {
+			violations = append(violations, bezzmodels.CopyViolation{
+				Field:   field.name,
+				Rule:    "max_length",
+				Message: fmt.Sprintf("%s is %d characters; the limit is %d", field.name, length, field.max),
+			})
+		}
+
+		if banned := bannedCharsIn(field.value, rules); banned != "" {
+			violations = append(violations, bezzmodels.CopyViolation{
+				Field:   field.name,
+				Rule:    "banned_character",
+				Message: fmt.Sprintf("%s contains characters not allowed on this placement: %s", field.name, banned),
+			})
+		}
+
+		if rules.NoAllCaps {
+			if word := shoutedWord(field.value); word != "" {
+				violations = append(violations, bezzmodels.CopyViolation{
+					Field:   field.name,
+					Rule:    "capitalization",
+					Message: fmt.Sprintf("%s uses excessive capitalization (%q)", field.name, word),
+				})
+			}
+		}
+
+		if rules.NoRepeatPunct && hasRepeatedPunctuation(field.value) {
+			violations = append(violations, bezzmodels.CopyViolation{
+				Field:   field.name,
+				Rule:    "punctuation",
+				Message: fmt.Sprintf("%s repeats punctuation", field.name),
+			})
+		}
+
+		if field.name == "headline" {
+			if rules.NoHeadlineBang && strings.Contains(field.value, "!") {
+				violations = append(violations, bezzmodels.CopyViolation{
+					Field:   field.name,
+					Rule:    "punctuation",
+					Message: "headline may not contain an exclamation mark",
+				})
+			}
+			continue
+		}
+		bangs += strings.Count(field.value, "!")
+	}
+
+	if rules.MaxBangs >= 0 && bangs > rules.MaxBangs {
+		field := "body"
+		if !strings.Contains(adCopy.Body, "!") {
+			field = "description"
+		}
+		violations = append(violations, bezzmodels.CopyViolation{
+			Field:   field,
+			Rule:    "punctuation",
+			Message: fmt.Sprintf("copy uses %d exclamation marks; the limit is %d", bangs, rules.MaxBangs),
+		})
+	}
+
+	if placement, ok := adPlacements[placementID]; ok && !containsString(rules.CTAs, adCopy.CTA) {
+		violations = append(violations, bezzmodels.CopyViolation{
+			Field:   "cta",
+			Rule:    "cta_not_allowed",
+			Message: fmt.Sprintf("%q is not an allowed call to action for %s", adCopy.CTA, placement.Name),
+		})
+	}
+
+	return violations
+}
+
+// storedAdCopyViolations re-checks a saved ad against the rules version it was written for, so later rule
+// changes don't rewrite the warnings on old ads. Ads from before placements had no rules to check against.
+func storedAdCopyViolations(ad bezzmodels.AdCampaign) []bezzmodels.CopyViolation {
+	if ad.Placement == "" {
+		return nil
+	}
+	return ValidateAdCopy(ad.CopyRulesVersion, ad.Placement, ad.Copy)
+}
+
+// bannedCharsIn returns the distinct banned characters (and emoji, when disallowed) found in value
+func bannedCharsIn(value string, rules AdCopyRules) string {
+	var found []string
+	seen := make(map[rune]bool)
+	for _, r := range value {
+		if seen[r] {
+			continue
+		}
+		if strings.ContainsRune(rules.BannedChars, r) || (rules.NoEmoji && isEmoji(r)) {
+			seen[r] = true
+			found = append(found, string(r))
+		}
+	}
+	return strings.Join(found, " ")
+}
+
+// isEmoji reports whether r falls in the common emoji and pictograph blocks
+func isEmoji(r rune) bool {
+	return (r >= 0x1F300 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) || (r >= 0x1F1E6 && r <= 0x1F1FF)
+}
+
+// shoutedWord returns the first word of five or more letters written entirely in capitals
+func shoutedWord(value string) string {
+	for _, word := range strings.Fields(value) {
+		letters, upper := 0, 0
+		for _, r := range word {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters >= 5 && letters == upper {
+			return word
+		}
+	}
+	return ""
+}
+
+// hasRepeatedPunctuation reports gimmicky runs such as "!!", "??" or "?!"
+func hasRepeatedPunctuation(value string) bool {
+	var prev rune
+	for _, r := range value {
+		if (r == '!' || r == '?') && (prev == '!' || prev == '?') {
+			return true
+		}
+		prev = r
+	}
+	return false
+}
+
+// containsString reports whether values contains value exactly
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// shortenViolatingCopy asks Copy-Editor-GPT to rewrite only the fields that break their placement's rules
+func (s *AIService) shortenViolatingCopy(ctx context.Context, specs []bezzmodels.AdSpec) {
+	const maxRewriteAttempts = 2
+
+	for i := range specs {
+		// There is nothing to rewrite when the placement has no description field
+		if copyRulesFor(specs[i].Placement).DescriptionMax == 0 {
+			specs[i].Description = ""
+		}
+
+		for attempt := 1; attempt <= maxRewriteAttempts; attempt++ {
+			adCopy := bezzmodels.AdCopy{Headline: specs[i].Headline, Body: specs[i].Body, Description: specs[i].Description, CTA: specs[i].CTA}
+			violations := ValidateAdCopy(currentAdCopyRulesVersion, specs[i].Placement, adCopy)
+			fields := rewritableFields(violations)
+			if len(fields) == 0 {
+				break
+			}
+
+			log.Printf("✂️ AI PIPELINE: Ad %d breaks %d copy rule(s) on %s, asking Copy-Editor-GPT to rewrite %v (attempt %d/%d)",
+				specs[i].ID, len(violations), specs[i].Placement, fields, attempt, maxRewriteAttempts)
+
+			rewrite, err := s.rewriteAdCopy(ctx, specs[i], violations, fields)
+			if err != nil {
+				log.Printf("⚠️ AI PIPELINE: Copy-Editor-GPT failed for ad %d, keeping original copy: %v", specs[i].ID, err)
+				break
+			}
+
+			// Only accept rewrites for the fields we asked about
+			for _, field := range fields {
+				switch field {
+				case "headline":
+					if rewrite.Headline != "" {
+						specs[i].Headline = rewrite.Headline
+					}
+				case "body":
+					if rewrite.Body != "" {
+						specs[i].Body = rewrite.Body
+					}
+				case "description":
+					if rewrite.Description != "" {
+						specs[i].Description = rewrite.Description
+					}
+				}
+			}
+		}
+	}
+}
+
+// rewritableFields returns the distinct text fields named in violations, in headline/body/description order
+func rewritableFields(violations []bezzmodels.CopyViolation) []string {
+	var fields []string
+	for _, name := range []string{"headline", "body", "description"} {
+		for _, v := range violations {
+			if v.Field == name {
+				fields = append(fields, name)
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// rewriteAdCopy calls Copy-Editor-GPT for a single ad
+func (s *AIService) rewriteAdCopy(ctx context.Context, spec bezzmodels.AdSpec, violations []bezzmodels.CopyViolation, fields []string) (*bezzmodels.CopyEditorGPTResponse, error) {
+	current, err := json.Marshal(bezzmodels.CopyEditorGPTResponse{Headline: spec.Headline, Body: spec.Body, Description: spec.Description})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ad copy: %w", err)
+	}
+
+	problems := make([]string, len(violations))
+	for i, v := range violations {
+		problems[i] = "- " + v.Message
+	}
+
+	prompt := fmt.Sprintf(prompts.CopyEditorGPTPrompt,
+		placementForSpec(spec).Name,
+		copyRulesFor(spec.Placement).promptSummary(),
+		string(current),
+		strings.Join(problems, "\n"),
+		strings.Join(fields, ", "),
+	)
+
+	temperature := float64(0.4)
+	var response bezzmodels.CopyEditorGPTResponse
+	modelUsed, _, err := s.chatJSONWithFallback(ctx, []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("You are Copy-Editor-GPT, an expert at tightening ad copy to platform limits. Always respond with valid JSON only."),
+		openai.UserMessage(prompt),
+	}, 500, &temperature, &response)
+	if err != nil {
+		return nil, fmt.Errorf("Copy-Editor-GPT API call failed: %w", err)
+	}
+
+	log.Printf("✅ AI PIPELINE: Copy-Editor-GPT rewrote ad %d using %s", spec.ID, modelUsed)
+	return &response, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"bezz-backend/internal/models"
+)
+
+func violationRules(violations []models.CopyViolation) map[string]string {
+	rules := make(map[string]string, len(violations))
+	for _, v := range violations {
+		rules[v.Field+":"+v.Rule] = v.Message
+	}
+	return rules
+}
+
+func TestValidateAdCopy_Clean(t *testing.T) {
+	violations := ValidateAdCopy(currentAdCopyRulesVersion, "google_display", models.AdCopy{
+		Headline:    "Fresh bread, daily",
+		Body:        "Order warm loaves from local bakers and get them delivered before breakfast.",
+		Description: "Free delivery on your first order.",
+		CTA:         "Shop now",
+	})
+
+	assert.Empty(t, violations)
+}
+
+func TestValidateAdCopy_GoogleDisplayRules(t *testing.T) {
+	violations := ValidateAdCopy(currentAdCopyRulesVersion, "google_display", models.AdCopy{
+		Headline: "The BEST bread in town, delivered!",
+		Body:     "Amazing!! Order now ★ and save!",
+		CTA:      "Click Here",
+	})
+
+	rules := violationRules(violations)
+	assert.Contains(t, rules, "headline:max_length")
+	assert.Contains(t, rules, "headline:punctuation")
+	assert.Contains(t, rules, "body:banned_character")
+	assert.Contains(t, rules, "body:punctuation")
+	assert.Contains(t, rules, "cta:cta_not_allowed")
+	assert.NotContains(t, rules, "headline:capitalization", "four-letter capitals are treated as acronyms")
+}
+
+func TestValidateAdCopy_DescriptionOnPlacementsWithoutOne(t *testing.T) {
+	for _, placement := range []struct{ id, cta string }{{"x_post", "Learn more"}, {"print", "Call today"}} {
+		adCopy := models.AdCopy{Headline: "Fresh bread, daily", Body: "Warm loaves from local bakers.", Description: "Free delivery.", CTA: placement.cta}
+
+		rules := violationRules(ValidateAdCopy(currentAdCopyRulesVersion, placement.id, adCopy))
+		assert.Len(t, rules, 1, placement.id)
+		assert.Contains(t, rules, "description:not_allowed", placement.id)
+
+		adCopy.Description = ""
+		assert.Empty(t, ValidateAdCopy(currentAdCopyRulesVersion, placement.id, adCopy), placement.id)
+	}
+}
+
+func TestShortenViolatingCopy_DropsDescriptionsThePlacementHasNoFieldFor(t *testing.T) {
+	specs := []models.AdSpec{{Placement: "x_post", Headline: "Fresh bread, daily", Body: "Warm loaves.", Description: "Free delivery.", CTA: "Learn more"}}
+
+	(&AIService{}).shortenViolatingCopy(context.Background(), specs)
+
+	assert.Empty(t, specs[0].Description)
+	assert.Equal(t, "Warm loaves.", specs[0].Body)
+}
+
+func TestValidateAdCopy_Capitalization(t *testing.T) {
+	violations := ValidateAdCopy(currentAdCopyRulesVersion, "linkedin_sponsored", models.AdCopy{
+		Headline: "Hire FASTER with Acme",
+		Body:     "Screen candidates with AI and cut time-to-hire in half.",
+		CTA:      "Request Demo",
+	})
+
+	rules := violationRules(violations)
+	assert.Len(t, rules, 1)
+	assert.Contains(t, rules["headline:capitalization"], "FASTER")
+}
+
+func TestValidateAdCopy_CountsRunesNotBytes(t *testing.T) {
+	// 40 characters, but more than 40 bytes because of the accents
+	headline := "Café crème brûlée pour tous les gourmets"
+	assert.Equal(t, 40, len([]rune(headline)))
+
+	violations := ValidateAdCopy(currentAdCopyRulesVersion, "instagram_feed", models.AdCopy{Headline: headline, Body: "Bon appétit.", CTA: "Order Now"})
+
+	assert.Empty(t, violations)
+}
+
+func TestValidateAdCopy_UsesTheAdsRulesVersion(t *testing.T) {
+	adCopyRules["2024-01"] = map[string]AdCopyRules{"instagram_feed": {HeadlineMax: 20, BodyMax: 125, MaxBangs: -1, CTAs: []string{"Shop Now"}}}
+	defer delete(adCopyRules, "2024-01")
+
+	adCopy := models.AdCopy{Headline: "Fresh coffee for busy offices", Body: "Delivered weekly.", CTA: "Shop Now"}
+	assert.Empty(t, ValidateAdCopy(currentAdCopyRulesVersion, "instagram_feed", adCopy))
+	assert.Contains(t, violationRules(ValidateAdCopy("2024-01", "instagram_feed", adCopy)), "headline:max_length")
+	assert.Empty(t, ValidateAdCopy("", "instagram_feed", adCopy), "unversioned ads use the current rules")
+	assert.Equal(t, "2024-01", copyRulesForVersion("2024-01", "print").Version)
+}
+
+func TestStoredAdCopyViolations_UseTheAdsRulesVersion(t *testing.T) {
+	adCopyRules["2024-01"] = map[string]AdCopyRules{"instagram_feed": {HeadlineMax: 40, BodyMax: 125, MaxBangs: -1, CTAs: []string{"Buy Now"}}}
+	defer delete(adCopyRules, "2024-01")
+
+	ad := models.AdCampaign{Placement: "instagram_feed", CopyRulesVersion: "2024-01", Copy: models.AdCopy{Headline: "Fresh coffee", CTA: "Buy Now"}}
+	assert.Empty(t, storedAdCopyViolations(ad), "a CTA allowed when the ad was written stays allowed")
+
+	ad.CopyRulesVersion = currentAdCopyRulesVersion
+	assert.Contains(t, violationRules(storedAdCopyViolations(ad)), "cta:cta_not_allowed")
+
+	assert.Empty(t, storedAdCopyViolations(models.AdCampaign{Copy: models.AdCopy{CTA: "Buy Now"}}), "ads from before placements aren't checked")
+}
+
+func TestPromptSummary_DescribesEveryCheckedRule(t *testing.T) {
+	summary := copyRulesFor("google_display").promptSummary()
+	assert.Contains(t, summary, "headline ≤ 30 chars")
+	assert.Contains(t, summary, `no "!" in headline`)
+	assert.Contains(t, summary, `at most 1 "!" across body and description`)
+	assert.Contains(t, summary, "never use these characters: ★ ☆ ✓")
+	assert.Contains(t, summary, "{ } [ ]")
+
+	summary = copyRulesFor("instagram_feed").promptSummary()
+	assert.NotContains(t, summary, `"!"`, "placements without a limit don't mention one")
+	assert.NotContains(t, summary, "never use")
+}
+
+func TestRewritableFields(t *testing.T) {
+	fields := rewritableFields([]models.CopyViolation{
+		{Field: "cta", Rule: "cta_not_allowed"},
+		{Field: "body", Rule: "max_length"},
+		{Field: "headline", Rule: "punctuation"},
+		{Field: "body", Rule: "banned_character"},
+	})
+
+	assert.Equal(t, []string{"headline", "body"}, fields)
+}
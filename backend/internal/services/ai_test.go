@@ -137,7 +137,7 @@ func (s *TestableAIService) GenerateAds(ctx context.Context, strategy *models.Br
 		return nil, fmt.Errorf("failed to marshal strategy: %w", err)
 	}
 
-	prompt := fmt.Sprintf(prompts.CreativeDirectorGPTPrompt, string(strategyJSON))
+	prompt := fmt.Sprintf(prompts.CreativeDirectorGPTPrompt, string(strategyJSON), "{}", placementPromptContext(resolvePlacements(nil), StyleSelection{}))
 
 	// Create parameters using the new SDK structure
 	temperature := float64(0.7)
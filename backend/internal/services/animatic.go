@@ -0,0 +1,290 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+const (
+	animaticFPS     = 25
+	animaticZoom    = 0.12 // how far Ken Burns moves push in or pull out
+	animaticTimeout = 5 * time.Minute
+)
+
+// AnimaticRenderer stitches storyboard keyframes and voiceover into a rough-cut MP4 with ffmpeg
+type AnimaticRenderer struct {
+	ffmpegPath string
+}
+
+// NewAnimaticRenderer resolves the ffmpeg binary; the renderer is disabled when it is not installed
+func NewAnimaticRenderer(ffmpegPath string) *AnimaticRenderer {
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	resolved, err := exec.LookPath(ffmpegPath)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: ffmpeg not found (%s), animatics are disabled", ffmpegPath)
+		return &AnimaticRenderer{}
+	}
+	return &AnimaticRenderer{ffmpegPath: resolved}
+}
+
+// Available reports whether ffmpeg was found
+func (r *AnimaticRenderer) Available() bool {
+	return r != nil && r.ffmpegPath != ""
+}
+
+// animaticScene is one scene's input files, relative to the scratch directory, and timing
+type animaticScene struct {
+	framePath string
+	audioPath string // empty for silent scenes
+	start     int    // seconds
+	duration  int    // seconds
+}
+
+// animaticJob describes one render
+type animaticJob struct {
+	scenes       []animaticScene
+	subtitlePath string // empty to skip captions
+	outputPath   string
+	width        int
+	height       int
+}
+
+// Render writes the inputs to a scratch directory, runs ffmpeg and returns the MP4.
+// frames and audio are indexed like video.Scenes; nil frames are replaced by a brand-colored card.
+func (r *AnimaticRenderer) Render(ctx context.Context, video bezzmodels.VideoAd, frames []image.Image, audio []*SpeechAudio, subtitles []byte, palette []bezzmodels.Color) ([]byte, error) {
+	if !r.Available() {
+		return nil, fmt.Errorf("ffmpeg is not available")
+	}
+	if len(video.Scenes) == 0 {
+		return nil, fmt.Errorf("video %s has no scenes", video.ID)
+	}
+
+	dir, err := os.MkdirTemp("", "animatic-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	width, height := animaticSize(video.AspectRatio)
+	job := animaticJob{outputPath: "animatic.mp4", width: width, height: height}
+
+	for i, scene := range video.Scenes {
+		var frame image.Image
+		if i < len(frames) {
+			frame = frames[i]
+		}
+		if frame == nil {
+			frame = placeholderFrame(width, height, palette)
+		}
+		data, err := encodePNG(frame)
+		if err != nil {
+			return nil, err
+		}
+		input := animaticScene{framePath: fmt.Sprintf("frame_%02d.png", i), start: scene.StartTime, duration: max(1, scene.Duration)}
+		if err := os.WriteFile(filepath.Join(dir, input.framePath), data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write frame: %w", err)
+		}
+
+		if i < len(audio) && audio[i] != nil {
+			input.audioPath = fmt.Sprintf("voice_%02d.wav", i)
+			if err := os.WriteFile(filepath.Join(dir, input.audioPath), audio[i].Data, 0o600); err != nil {
+				return nil, fmt.Errorf("failed to write voiceover: %w", err)
+			}
+		}
+		job.scenes = append(job.scenes, input)
+	}
+
+	if len(bytes.TrimSpace(subtitles)) > 0 {
+		job.subtitlePath = "captions.srt"
+		if err := os.WriteFile(filepath.Join(dir, job.subtitlePath), subtitles, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write captions: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, animaticTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	// Running inside the scratch directory keeps file names free of characters the filter syntax would need escaped
+	cmd := exec.CommandContext(ctx, r.ffmpegPath, animaticArgs(job)...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, lastLines(stderr.String(), 5))
+	}
+
+	return os.ReadFile(filepath.Join(dir, job.outputPath))
+}
+
+// animaticSize is the rough cut's frame size; 720p keeps renders fast
+func animaticSize(aspectRatio string) (int, int) {
+	if aspectRatio == "16:9" {
+		return 1280, 720
+	}
+	return 720, 1280
+}
+
+// animaticArgs builds the ffmpeg command line: every keyframe becomes a Ken Burns clip of its scene's length,
+// the clips are concatenated, captions are burned in and the voiceover clips are laid at their scene start times
+func animaticArgs(job animaticJob) []string {
+	args := []string{"-hide_banner", "-loglevel", "error", "-y"}
+
+	total := 0
+	for _, scene := range job.scenes {
+		args = append(args, "-i", scene.framePath)
+		total += scene.duration
+	}
+	audioInputs := 0
+	for _, scene := range job.scenes {
+		if scene.audioPath != "" {
+			args = append(args, "-i", scene.audioPath)
+			audioInputs++
+		}
+	}
+
+	var filters []string
+	var clips strings.Builder
+	for i, scene := range job.scenes {
+		filters = append(filters, fmt.Sprintf("[%d:v]%s[v%d]", i, kenBurnsFilter(i, scene.duration, job.width, job.height), i))
+		fmt.Fprintf(&clips, "[v%d]", i)
+	}
+
+	video := fmt.Sprintf("%sconcat=n=%d:v=1:a=0", clips.String(), len(job.scenes))
+	if job.subtitlePath != "" {
+		video += fmt.Sprintf(",subtitles=filename=%s:force_style='%s'", job.subtitlePath, captionStyle)
+	}
+	filters = append(filters, video+",format=yuv420p[vout]")
+
+	if audioInputs > 0 {
+		var delayed strings.Builder
+		input := len(job.scenes)
+		for _, scene := range job.scenes {
+			if scene.audioPath == "" {
+				continue
+			}
+			filters = append(filters, fmt.Sprintf("[%d:a]adelay=%d:all=1[a%d]", input, scene.start*1000, input))
+			fmt.Fprintf(&delayed, "[a%d]", input)
+			input++
+		}
+		filters = append(filters, fmt.Sprintf("%samix=inputs=%d:duration=longest:normalize=0,apad[aout]", delayed.String(), audioInputs))
+	}
+
+	args = append(args, "-filter_complex", strings.Join(filters, ";"), "-map", "[vout]")
+	if audioInputs > 0 {
+		args = append(args, "-map", "[aout]", "-c:a", "aac", "-b:a", "128k")
+	} else {
+		args = append(args, "-an")
+	}
+	args = append(args,
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-r", fmt.Sprint(animaticFPS),
+		"-t", fmt.Sprint(total), "-movflags", "+faststart", job.outputPath,
+	)
+	return args
+}
+
+// captionStyle is the libass style for burned-in captions: white with a dark outline, clear of the frame edge
+const captionStyle = "FontName=DejaVu Sans,FontSize=16,PrimaryColour=&H00FFFFFF,OutlineColour=&H80000000,BorderStyle=1,Outline=2,Shadow=0,MarginV=36"
+
+// kenBurnsFilter scales a keyframe to cover the frame and slowly pushes in, pulls out or pans across it,
+// cycling through the moves so consecutive scenes don't repeat. Scaling up first keeps zoompan from jittering.
+func kenBurnsFilter(index, seconds, width, height int) string {
+	frames := seconds * animaticFPS
+	cover := fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=increase,crop=%d:%d", width*2, height*2, width*2, height*2)
+
+	progress := fmt.Sprintf("on/%d", frames)
+	centerX, centerY := "iw/2-(iw/zoom/2)", "ih/2-(ih/zoom/2)"
+	var zoom, x, y string
+	switch index % 4 {
+	case 0: // push in
+		zoom, x, y = fmt.Sprintf("1+%.2f*%s", animaticZoom, progress), centerX, centerY
+	case 1: // pan left to right
+		zoom, x, y = fmt.Sprintf("%.2f", 1+animaticZoom), fmt.Sprintf("(iw-iw/zoom)*%s", progress), centerY
+	case 2: // pull out
+		zoom, x, y = fmt.Sprintf("%.2f-%.2f*%s", 1+animaticZoom, animaticZoom, progress), centerX, centerY
+	default: // pan right to left
+		zoom, x, y = fmt.Sprintf("%.2f", 1+animaticZoom), fmt.Sprintf("(iw-iw/zoom)*(1-%s)", progress), centerY
+	}
+
+	return fmt.Sprintf("%s,zoompan=z='%s':x='%s':y='%s':d=%d:s=%dx%d:fps=%d,setsar=1",
+		cover, zoom, x, y, frames, width, height, animaticFPS)
+}
+
+// placeholderFrame is a flat brand-colored card used when a keyframe could not be generated
+func placeholderFrame(width, height int, palette []bezzmodels.Color) image.Image {
+	primary := paletteColor(palette, "primary", color.RGBA{R: 0x1f, G: 0x29, B: 0x37, A: 0xff})
+	card := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(card, card.Bounds(), image.NewUniform(primary), image.Point{}, draw.Src)
+	return card
+}
+
+// lastLines returns the last n lines of ffmpeg's error output
+func lastLines(text string, n int) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, " | ")
+}
+
+// renderAnimatic renders the rough cut and its thumbnail and stores both in GCS (best effort)
+func (s *AIService) renderAnimatic(ctx context.Context, video *bezzmodels.VideoAd, frames []image.Image, audio []*SpeechAudio, subtitles []byte, palette []bezzmodels.Color, baseName string) {
+	if thumb, ok := renderThumbnail(*video, frames); ok {
+		if data, err := encodePNG(thumb); err != nil {
+			log.Printf("⚠️ AI PIPELINE: %v", err)
+		} else if thumbURL, err := s.uploadImageBytesToGCS(ctx, data, baseName+"_thumbnail"); err != nil {
+			log.Printf("⚠️ AI PIPELINE: Uploading thumbnail for %s failed: %v", video.ID, err)
+		} else {
+			video.ThumbnailURL = thumbURL
+			video.ThumbnailObjectName = baseName + "_thumbnail"
+		}
+	}
+
+	if !s.animatic.Available() {
+		return
+	}
+
+	started := time.Now()
+	data, err := s.animatic.Render(ctx, *video, frames, audio, subtitles, palette)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Animatic for %s failed: %v", video.ID, err)
+		return
+	}
+
+	videoName := baseName + "_animatic"
+	videoURL, err := s.uploadBytesToGCS(ctx, data, videoName+".mp4", "video/mp4")
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Uploading animatic for %s failed: %v", video.ID, err)
+		return
+	}
+	video.VideoURL = videoURL
+	video.VideoObjectName = videoName
+	log.Printf("🎞️ AI PIPELINE: Rendered %s animatic (%d KB) in %s", video.ID, len(data)>>10, time.Since(started).Round(time.Second))
+}
+
+// renderThumbnail crops the first available keyframe to the video's frame for use as its poster image
+func renderThumbnail(video bezzmodels.VideoAd, frames []image.Image) (image.Image, bool) {
+	for _, frame := range frames {
+		if frame == nil {
+			continue
+		}
+		width, height := animaticSize(video.AspectRatio)
+		thumb := image.NewRGBA(image.Rect(0, 0, width, height))
+		drawImageCover(thumb, thumb.Bounds(), frame)
+		return thumb, true
+	}
+	return nil, false
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestAnimaticArgs_LaysVoiceoverAtSceneStarts(t *testing.T) {
+	job := animaticJob{
+		scenes: []animaticScene{
+			{framePath: "frame_00.png", audioPath: "voice_00.wav", start: 0, duration: 4},
+			{framePath: "frame_01.png", start: 4, duration: 3},
+			{framePath: "frame_02.png", audioPath: "voice_02.wav", start: 7, duration: 8},
+		},
+		subtitlePath: "captions.srt",
+		outputPath:   "animatic.mp4",
+		width:        720,
+		height:       1280,
+	}
+
+	args := animaticArgs(job)
+	joined := strings.Join(args, " ")
+	assert.Contains(t, joined, "-i frame_00.png -i frame_01.png -i frame_02.png -i voice_00.wav -i voice_02.wav")
+	assert.Equal(t, "animatic.mp4", args[len(args)-1])
+	assert.Contains(t, joined, "-t 15 ")
+
+	var filter string
+	for i, arg := range args {
+		if arg == "-filter_complex" {
+			filter = args[i+1]
+		}
+	}
+	require.NotEmpty(t, filter)
+	assert.Contains(t, filter, "[v0][v1][v2]concat=n=3:v=1:a=0,subtitles=filename=captions.srt")
+	assert.Contains(t, filter, "[3:a]adelay=0:all=1[a3]")
+	assert.Contains(t, filter, "[4:a]adelay=7000:all=1[a4]")
+	assert.Contains(t, filter, "[a3][a4]amix=inputs=2")
+	assert.Contains(t, joined, "-map [aout]")
+}
+
+func TestAnimaticArgs_SilentWithoutVoiceover(t *testing.T) {
+	job := animaticJob{
+		scenes:     []animaticScene{{framePath: "frame_00.png", duration: 5}},
+		outputPath: "animatic.mp4",
+		width:      1280,
+		height:     720,
+	}
+
+	args := animaticArgs(job)
+	joined := strings.Join(args, " ")
+	assert.Contains(t, args, "-an")
+	assert.NotContains(t, joined, "amix")
+	assert.NotContains(t, joined, "subtitles=")
+}
+
+func TestKenBurnsFilter_CoversSceneLength(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		filter := kenBurnsFilter(i, 3, 720, 1280)
+		assert.Contains(t, filter, "d=75:s=720x1280:fps=25", "move %d", i)
+	}
+	assert.NotEqual(t, kenBurnsFilter(0, 3, 720, 1280), kenBurnsFilter(1, 3, 720, 1280), "consecutive scenes use different moves")
+}
+
+func TestRenderThumbnail_UsesFirstAvailableKeyframe(t *testing.T) {
+	frame := image.NewRGBA(image.Rect(0, 0, 1024, 1536))
+
+	thumb, ok := renderThumbnail(models.VideoAd{AspectRatio: "9:16"}, []image.Image{nil, frame})
+	require.True(t, ok)
+	assert.Equal(t, image.Rect(0, 0, 720, 1280), thumb.Bounds())
+
+	_, ok = renderThumbnail(models.VideoAd{AspectRatio: "16:9"}, []image.Image{nil})
+	assert.False(t, ok)
+}
+
+func TestAnimaticRenderer_UnavailableWithoutFFmpeg(t *testing.T) {
+	renderer := NewAnimaticRenderer("/nonexistent/ffmpeg")
+	assert.False(t, renderer.Available())
+
+	_, err := renderer.Render(context.Background(), models.VideoAd{ID: "video_15s"}, nil, nil, nil, nil)
+	assert.Error(t, err)
+}
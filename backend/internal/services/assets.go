@@ -0,0 +1,344 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register JPEG decoding for uploads
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+const (
+	maxAssetBytes        = 10 << 20 // 10 MB per upload
+	maxProductShots      = 4        // gpt-image-1 accepts up to 16 references; more than a few dilutes the prompt
+	maxPaletteColors     = 6
+	minAssetDimension    = 64
+	maxAssetDimension    = 8000 // per side; a small, highly compressed PNG can otherwise decode to gigabytes
+	assetKindLogo        = "logo"
+	assetKindProductShot = "product"
+)
+
+// ErrInvalidAsset is returned when an upload has the wrong kind, size or format
+var ErrInvalidAsset = errors.New("invalid asset")
+
+// ErrAssetNotFound is returned when an asset doesn't exist or belongs to another user
+var ErrAssetNotFound = errors.New("asset not found")
+
+// AssetService stores user-uploaded brand assets in GCS and resolves them for briefs
+type AssetService struct {
+	db         *firestore.Client
+	aiService  *AIService
+	storage    *storage.Client
+	bucketName string
+}
+
+// NewAssetService creates a new asset service
+func NewAssetService(db *firestore.Client, aiService *AIService, storage *storage.Client, bucketName string) *AssetService {
+	return &AssetService{
+		db:         db,
+		aiService:  aiService,
+		storage:    storage,
+		bucketName: bucketName,
+	}
+}
+
+// UploadAsset validates an uploaded PNG or JPEG, stores it as PNG and records it for the user
+func (s *AssetService) UploadAsset(ctx context.Context, userID, kind, fileName string, file io.Reader) (*bezzmodels.BrandAsset, error) {
+	if kind != assetKindLogo && kind != assetKindProductShot {
+		return nil, fmt.Errorf("%w: asset kind must be %q or %q", ErrInvalidAsset, assetKindLogo, assetKindProductShot)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxAssetBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if len(data) > maxAssetBytes {
+		return nil, fmt.Errorf("%w: file is larger than %d MB", ErrInvalidAsset, maxAssetBytes>>20)
+	}
+
+	img, format, err := decodeAssetImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAsset, err)
+	}
+
+	// Normalise to PNG so logos keep transparency and every asset follows the <objectName>.png convention
+	pngData, err := encodePNG(img)
+	if err != nil {
+		return nil, err
+	}
+
+	assetID := generateID()
+	asset := &bezzmodels.BrandAsset{
+		ID:         assetID,
+		UserID:     userID,
+		Kind:       kind,
+		FileName:   fileName,
+		ObjectName: fmt.Sprintf("uploads/%s/%s_%s", userID, kind, assetID),
+		Width:      img.Bounds().Dx(),
+		Height:     img.Bounds().Dy(),
+		CreatedAt:  time.Now(),
+	}
+
+	// Derive the brand colors from an existing logo
+	if kind == assetKindLogo {
+		asset.Palette = ExtractPalette(img)
+	}
+
+	asset.URL, err = s.aiService.uploadImageBytesToGCS(ctx, pngData, asset.ObjectName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store asset: %w", err)
+	}
+
+	if _, err := s.db.Collection("assets").Doc(asset.ID).Set(ctx, asset); err != nil {
+		return nil, fmt.Errorf("failed to save asset: %w", err)
+	}
+
+	log.Printf("✅ ASSETS: Stored %s %s (%s %dx%d) for user %s", kind, asset.ID, format, asset.Width, asset.Height, userID)
+	return asset, nil
+}
+
+// ListAssets returns the user's uploaded assets with fresh signed URLs
+func (s *AssetService) ListAssets(ctx context.Context, userID string) ([]bezzmodels.BrandAsset, error) {
+	iter := s.db.Collection("assets").Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	var assets []bezzmodels.BrandAsset
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var asset bezzmodels.BrandAsset
+		if err := doc.DataTo(&asset); err != nil {
+			continue
+		}
+		s.refreshURL(ctx, &asset)
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// GetAsset retrieves one of the user's assets
+func (s *AssetService) GetAsset(ctx context.Context, userID, assetID string) (*bezzmodels.BrandAsset, error) {
+	doc, err := s.db.Collection("assets").Doc(assetID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, assetID)
+		}
+		return nil, err
+	}
+
+	var asset bezzmodels.BrandAsset
+	if err := doc.DataTo(&asset); err != nil {
+		return nil, err
+	}
+
+	// Don't reveal other users' assets
+	if asset.UserID != userID {
+		return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, assetID)
+	}
+
+	s.refreshURL(ctx, &asset)
+	return &asset, nil
+}
+
+// DeleteAsset removes an asset record and its stored file
+func (s *AssetService) DeleteAsset(ctx context.Context, userID, assetID string) error {
+	asset, err := s.GetAsset(ctx, userID, assetID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.Bucket(s.bucketName).Object(asset.ObjectName + ".png").Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		log.Printf("⚠️ ASSETS: Failed to delete stored file for %s: %v", assetID, err)
+	}
+
+	_, err = s.db.Collection("assets").Doc(assetID).Delete(ctx)
+	return err
+}
+
+// ResolveBriefAssets turns the asset IDs and palette in a brief request into the assets the pipeline honours.
+// Requests the user can fix fail with ErrInvalidAsset or ErrAssetNotFound.
+func (s *AssetService) ResolveBriefAssets(ctx context.Context, userID string, req *bezzmodels.BrandBriefRequest) (*bezzmodels.BrandAssets, error) {
+	if req.LogoAssetID == "" && len(req.ProductAssetIDs) == 0 && len(req.Palette) == 0 {
+		return nil, nil
+	}
+
+	if len(req.ProductAssetIDs) > maxProductShots {
+		return nil, fmt.Errorf("%w: at most %d product shots can be used per brief", ErrInvalidAsset, maxProductShots)
+	}
+
+	palette, err := NormalizePalette(req.Palette)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAsset, err)
+	}
+	assets := &bezzmodels.BrandAssets{Palette: palette}
+	if len(palette) > 0 {
+		assets.PaletteSource = "user"
+	}
+
+	if req.LogoAssetID != "" {
+		logo, err := s.GetAsset(ctx, userID, req.LogoAssetID)
+		if err != nil {
+			return nil, err
+		}
+		if logo.Kind != assetKindLogo {
+			return nil, fmt.Errorf("%w: asset %s is not a logo", ErrInvalidAsset, logo.ID)
+		}
+		assets.Logo = logo
+
+		// Without an explicit palette, the colors extracted from the logo become the fixed palette
+		if len(assets.Palette) == 0 && len(logo.Palette) > 0 {
+			assets.Palette = logo.Palette
+			assets.PaletteSource = "logo"
+		}
+	}
+
+	for _, assetID := range req.ProductAssetIDs {
+		shot, err := s.GetAsset(ctx, userID, assetID)
+		if err != nil {
+			return nil, err
+		}
+		if shot.Kind != assetKindProductShot {
+			return nil, fmt.Errorf("%w: asset %s is not a product shot", ErrInvalidAsset, shot.ID)
+		}
+		assets.ProductShots = append(assets.ProductShots, *shot)
+	}
+
+	return assets, nil
+}
+
+// refreshURL replaces the asset's stored URL with a fresh signed URL when possible
+func (s *AssetService) refreshURL(ctx context.Context, asset *bezzmodels.BrandAsset) {
+	if url, err := s.aiService.GenerateSignedURL(ctx, asset.ObjectName+".png"); err == nil {
+		asset.URL = url
+	}
+}
+
+// decodeAssetImage decodes PNG or JPEG data and rejects images too small to be useful or too large to decode safely
+func decodeAssetImage(data []byte) (image.Image, string, error) {
+	// Check the dimensions from the header before allocating the decoded image
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("file must be a PNG or JPEG image")
+	}
+	if format != "png" && format != "jpeg" {
+		return nil, "", fmt.Errorf("file must be a PNG or JPEG image, got %s", format)
+	}
+	if config.Width < minAssetDimension || config.Height < minAssetDimension {
+		return nil, "", fmt.Errorf("image must be at least %dx%d pixels", minAssetDimension, minAssetDimension)
+	}
+	if config.Width > maxAssetDimension || config.Height > maxAssetDimension {
+		return nil, "", fmt.Errorf("image must be at most %dx%d pixels", maxAssetDimension, maxAssetDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("file must be a PNG or JPEG image")
+	}
+	return img, format, nil
+}
+
+// NormalizePalette validates a user-supplied palette, upper-cases hex values and fills missing usage roles
+func NormalizePalette(palette []bezzmodels.Color) ([]bezzmodels.Color, error) {
+	if len(palette) > maxPaletteColors {
+		return nil, fmt.Errorf("a palette can have at most %d colors", maxPaletteColors)
+	}
+
+	roles := []string{"primary", "secondary", "accent"}
+	normalized := make([]bezzmodels.Color, len(palette))
+	for i, c := range palette {
+		parsed, err := parseHexColor(c.Hex)
+		if err != nil {
+			return nil, err
+		}
+		c.Hex = hexString(parsed)
+		c.Usage = strings.ToLower(strings.TrimSpace(c.Usage))
+		if c.Usage == "" {
+			c.Usage = "accent"
+			if i < len(roles) {
+				c.Usage = roles[i]
+			}
+		}
+		normalized[i] = c
+	}
+	return normalized, nil
+}
+
+// applyFixedPalette keeps the user's colors, borrowing names and psychology from the generated palette by role
+func applyFixedPalette(fixed, generated []bezzmodels.Color) []bezzmodels.Color {
+	byHex := make(map[string]bezzmodels.Color, len(generated))
+	byUsage := make(map[string]bezzmodels.Color, len(generated))
+	for _, c := range generated {
+		if parsed, err := parseHexColor(c.Hex); err == nil {
+			byHex[hexString(parsed)] = c
+		}
+		if _, ok := byUsage[c.Usage]; !ok {
+			byUsage[c.Usage] = c
+		}
+	}
+
+	palette := make([]bezzmodels.Color, len(fixed))
+	for i, c := range fixed {
+		described, ok := byHex[c.Hex]
+		if !ok {
+			described = byUsage[c.Usage]
+		}
+		if c.Name == "" {
+			c.Name = described.Name
+		}
+		if c.Name == "" {
+			c.Name = c.Hex
+		}
+		if c.Psychology == "" {
+			c.Psychology = described.Psychology
+		}
+		palette[i] = c
+	}
+	return palette
+}
+
+// brandAssetConstraints describes the supplied assets for Logo-Designer-GPT
+func brandAssetConstraints(assets *bezzmodels.BrandAssets) string {
+	if assets == nil || (assets.Logo == nil && len(assets.Palette) == 0) {
+		return "None. Create a new logo concept and color palette."
+	}
+
+	var lines []string
+	if len(assets.Palette) > 0 {
+		colors := make([]string, len(assets.Palette))
+		for i, c := range assets.Palette {
+			colors[i] = fmt.Sprintf("%s (%s)", c.Hex, c.Usage)
+			if c.Name != "" {
+				colors[i] = fmt.Sprintf("%s %s (%s)", c.Name, c.Hex, c.Usage)
+			}
+		}
+		source := "supplied by the company"
+		if assets.PaletteSource == "logo" {
+			source = "extracted from the company's existing logo"
+		}
+		lines = append(lines, fmt.Sprintf("- The brand palette is FIXED (%s). Return exactly these colors in color_palette, in this order, with the same hex and usage, "+
+			"and write a psychology description for each that explains why it suits this brand: %s", source, strings.Join(colors, ", ")))
+	}
+	if assets.Logo != nil {
+		lines = append(lines, "- The company already has a logo, which will be used as-is. Describe a logo concept and usage guidance that fit an established mark; do not propose a redesign.")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestNormalizePalette(t *testing.T) {
+	palette, err := NormalizePalette([]models.Color{{Hex: "#0a2540"}, {Hex: "f5a623"}, {Hex: "#fff"}, {Hex: "#123456", Usage: "Neutral"}})
+	require.NoError(t, err)
+	assert.Equal(t, "#0A2540", palette[0].Hex)
+	assert.Equal(t, "primary", palette[0].Usage)
+	assert.Equal(t, "#F5A623", palette[1].Hex)
+	assert.Equal(t, "secondary", palette[1].Usage)
+	assert.Equal(t, "#FFFFFF", palette[2].Hex)
+	assert.Equal(t, "accent", palette[2].Usage)
+	assert.Equal(t, "neutral", palette[3].Usage)
+
+	_, err = NormalizePalette([]models.Color{{Hex: "teal"}})
+	assert.Error(t, err)
+
+	_, err = NormalizePalette(make([]models.Color, maxPaletteColors+1))
+	assert.Error(t, err)
+}
+
+func TestApplyFixedPalette_KeepsHexesAndBorrowsDescriptions(t *testing.T) {
+	fixed := []models.Color{
+		{Hex: "#0A2540", Usage: "primary"},
+		{Hex: "#F5A623", Usage: "accent", Name: "Harvest Gold"},
+	}
+	generated := []models.Color{
+		{Name: "Midnight", Hex: "#0a2540", Usage: "secondary", Psychology: "calm"},
+		{Name: "Coral", Hex: "#FF7F50", Usage: "accent", Psychology: "energy"},
+	}
+
+	palette := applyFixedPalette(fixed, generated)
+	require.Len(t, palette, 2)
+	assert.Equal(t, models.Color{Name: "Midnight", Hex: "#0A2540", Usage: "primary", Psychology: "calm"}, palette[0])
+	assert.Equal(t, models.Color{Name: "Harvest Gold", Hex: "#F5A623", Usage: "accent", Psychology: "energy"}, palette[1])
+}
+
+func TestBrandAssetConstraints(t *testing.T) {
+	assert.Contains(t, brandAssetConstraints(nil), "Create a new logo")
+
+	constraints := brandAssetConstraints(&models.BrandAssets{
+		Logo:    &models.BrandAsset{ID: "logo1"},
+		Palette: []models.Color{{Hex: "#0A2540", Usage: "primary"}},
+	})
+	assert.Contains(t, constraints, "#0A2540 (primary)")
+	assert.Contains(t, constraints, "already has a logo")
+}
+
+func TestDecodeAssetImage(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 128, 96)), nil))
+
+	img, format, err := decodeAssetImage(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 128, img.Bounds().Dx())
+
+	small, err := encodePNG(image.NewRGBA(image.Rect(0, 0, 16, 16)))
+	require.NoError(t, err)
+	_, _, err = decodeAssetImage(small)
+	assert.Error(t, err)
+
+	_, _, err = decodeAssetImage([]byte("not an image"))
+	assert.Error(t, err)
+
+	// Oversized images are rejected from their header, before decoding
+	huge, err := encodePNG(image.NewGray(image.Rect(0, 0, maxAssetDimension+1, 64)))
+	require.NoError(t, err)
+	_, _, err = decodeAssetImage(huge)
+	assert.EqualError(t, err, "image must be at most 8000x8000 pixels")
+}
+
+func TestUploadAsset_ValidationErrorsAreInvalidAsset(t *testing.T) {
+	s := &AssetService{}
+
+	_, err := s.UploadAsset(context.Background(), "user", "banner", "a.png", strings.NewReader("x"))
+	assert.ErrorIs(t, err, ErrInvalidAsset)
+
+	_, err = s.UploadAsset(context.Background(), "user", assetKindLogo, "a.png", bytes.NewReader(make([]byte, maxAssetBytes+1)))
+	assert.ErrorIs(t, err, ErrInvalidAsset)
+
+	_, err = s.UploadAsset(context.Background(), "user", assetKindLogo, "a.png", strings.NewReader("not an image"))
+	assert.ErrorIs(t, err, ErrInvalidAsset)
+	assert.Contains(t, err.Error(), "PNG or JPEG")
+}
+
+func TestResolveBriefAssets_ValidationErrorsAreInvalidAsset(t *testing.T) {
+	s := &AssetService{}
+
+	_, err := s.ResolveBriefAssets(context.Background(), "user", &models.BrandBriefRequest{ProductAssetIDs: make([]string, maxProductShots+1)})
+	assert.ErrorIs(t, err, ErrInvalidAsset)
+
+	_, err = s.ResolveBriefAssets(context.Background(), "user", &models.BrandBriefRequest{Palette: []models.Color{{Hex: "not-a-color"}}})
+	assert.ErrorIs(t, err, ErrInvalidAsset)
+}
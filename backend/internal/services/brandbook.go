@@ -0,0 +1,593 @@
+package services
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// BrandBookTemplate controls the layout of the PDF brand book
+type BrandBookTemplate struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	cover          string  // full_bleed, split, minimal
+	headerBand     bool    // section titles on a filled primary band rather than over an accent rule
+	galleryColumns int     // ads per row in the gallery
+	margin         float64 // page margin in points
+}
+
+// DefaultBrandBookTemplate is used when no template is requested
+const DefaultBrandBookTemplate = "classic"
+
+var brandBookTemplates = []BrandBookTemplate{
+	{ID: "classic", Name: "Classic", Description: "Full-bleed cover in the primary color, banded section headers, two-up ad gallery", cover: "full_bleed", headerBand: true, galleryColumns: 2, margin: 50},
+	{ID: "modern", Name: "Modern", Description: "Split cover, ruled section headers, dense three-up ad gallery", cover: "split", headerBand: false, galleryColumns: 3, margin: 40},
+	{ID: "minimal", Name: "Minimal", Description: "White cover with an accent rule, generous margins, one ad per row", cover: "minimal", headerBand: false, galleryColumns: 1, margin: 72},
+}
+
+// BrandBookTemplates lists the available brand book templates
+func BrandBookTemplates() []BrandBookTemplate {
+	return brandBookTemplates
+}
+
+// ValidateBrandBookTemplate checks that a requested template exists
+func ValidateBrandBookTemplate(id string) error {
+	if _, ok := brandBookTemplate(id); !ok {
+		ids := make([]string, len(brandBookTemplates))
+		for i, t := range brandBookTemplates {
+			ids[i] = t.ID
+		}
+		return fmt.Errorf("unknown brand book template %q (supported: %s)", id, strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+func brandBookTemplate(id string) (BrandBookTemplate, bool) {
+	for _, t := range brandBookTemplates {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return BrandBookTemplate{}, false
+}
+
+// brandBookColors are the brief's palette roles, with neutral fallbacks
+type brandBookColors struct {
+	primary, secondary, accent color.RGBA
+	text, muted, surface       color.RGBA
+}
+
+func brandBookPalette(identity *bezzmodels.BrandIdentity) brandBookColors {
+	var palette []bezzmodels.Color
+	if identity != nil {
+		palette = identity.ColorPalette
+	}
+	primary := paletteColor(palette, "primary", color.RGBA{R: 0x1F, G: 0x29, B: 0x37, A: 0xff})
+	secondary := paletteColor(palette, "secondary", mixColors(primary, paletteWhite, 0.35))
+	accent := paletteColor(palette, "accent", secondary)
+	return brandBookColors{
+		primary:   primary,
+		secondary: secondary,
+		accent:    accent,
+		text:      color.RGBA{R: 0x1F, G: 0x23, B: 0x28, A: 0xff},
+		muted:     color.RGBA{R: 0x6B, G: 0x72, B: 0x80, A: 0xff},
+		surface:   mixColors(primary, paletteWhite, 0.93),
+	}
+}
+
+// brandBookWriter lays content out top to bottom, starting new pages as they fill
+type brandBookWriter struct {
+	doc     *pdfDocument
+	page    *pdfPage
+	tpl     BrandBookTemplate
+	colors  brandBookColors
+	company string
+	y       float64
+	pages   int
+}
+
+func (w *brandBookWriter) left() float64   { return w.tpl.margin }
+func (w *brandBookWriter) width() float64  { return pdfPageWidth - 2*w.tpl.margin }
+func (w *brandBookWriter) bottom() float64 { return pdfPageHeight - w.tpl.margin - 20 }
+
+// newPage starts a content page with the running footer
+func (w *brandBookWriter) newPage() {
+	w.page = w.doc.AddPage()
+	w.pages++
+	w.y = w.tpl.margin
+	footerY := pdfPageHeight - w.tpl.margin/2
+	w.page.Line(w.left(), footerY-12, w.left()+w.width(), footerY-12, 0.5, w.colors.surface)
+	w.page.Text(w.left(), footerY, 8, false, w.colors.muted, w.company+" Brand Book")
+	number := fmt.Sprintf("%d", w.pages)
+	w.page.Text(w.left()+w.width()-pdfTextWidth(number, 8, false), footerY, 8, false, w.colors.muted, number)
+}
+
+// ensure starts a new page unless h points remain
+func (w *brandBookWriter) ensure(h float64) {
+	if w.y+h > w.bottom() {
+		w.newPage()
+	}
+}
+
+// section starts a chapter on a fresh page
+func (w *brandBookWriter) section(title string) {
+	w.newPage()
+	if w.tpl.headerBand {
+		w.page.Rect(0, w.y-10, pdfPageWidth, 48, w.colors.primary)
+		w.page.Text(w.left(), w.y+22, 20, true, readableTextColor(w.colors.primary), title)
+		w.y += 62
+		return
+	}
+	w.page.Text(w.left(), w.y+20, 22, true, w.colors.primary, title)
+	w.page.Rect(w.left(), w.y+30, 48, 3, w.colors.accent)
+	w.y += 52
+}
+
+// heading writes a sub-heading, keeping it with at least a few lines of what follows
+func (w *brandBookWriter) heading(text string) {
+	w.ensure(60)
+	w.y += 6
+	w.page.Text(w.left(), w.y+11, 11, true, w.colors.primary, strings.ToUpper(text))
+	w.y += 20
+}
+
+// paragraph writes wrapped text at x, indented by indent points
+func (w *brandBookWriter) paragraph(text string, size float64, bold bool, col color.RGBA, indent float64) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	leading := size * 1.4
+	for _, line := range pdfWrapText(text, size, bold, w.width()-indent) {
+		w.ensure(leading)
+		w.page.Text(w.left()+indent, w.y+size, size, bold, col, line)
+		w.y += leading
+	}
+	w.y += size * 0.6
+}
+
+// bullets writes a bulleted list
+func (w *brandBookWriter) bullets(items []string) {
+	for _, item := range items {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		w.ensure(14)
+		w.page.Rect(w.left()+2, w.y+5, 4, 4, w.colors.accent)
+		w.paragraph(item, 10, false, w.colors.text, 14)
+		w.y -= 4
+	}
+	w.y += 6
+}
+
+// renderBrandBook lays out the brief's results as a multi-page PDF brand book
+func renderBrandBook(brief *bezzmodels.BrandBrief, templateID string, assets exportImages) ([]byte, error) {
+	if brief.Results == nil {
+		return nil, fmt.Errorf("brief has no results to export")
+	}
+	tpl, ok := brandBookTemplate(templateID)
+	if !ok {
+		return nil, ValidateBrandBookTemplate(templateID)
+	}
+
+	w := &brandBookWriter{
+		doc:     newPDFDocument(),
+		tpl:     tpl,
+		colors:  brandBookPalette(brief.Results.BrandIdentity),
+		company: brief.CompanyName,
+	}
+
+	logo := -1
+	if assets.logo != nil {
+		index, err := w.doc.AddImage(assets.logo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed logo: %w", err)
+		}
+		logo = index
+	}
+
+	w.cover(brief, logo, assets.logo)
+	w.strategy(brief.Results.Strategy)
+	w.personas(brief.Results.Strategy.TargetSegments)
+	if identity := brief.Results.BrandIdentity; identity != nil {
+		w.identity(identity, logo, assets.logo)
+		if identity.Typography != nil {
+			w.typography(identity.Typography)
+		}
+	}
+	if len(brief.Results.BrandNames) > 0 {
+		w.brandNames(brief.Results.BrandNames)
+	}
+	if len(brief.Results.Ads) > 0 {
+		if err := w.gallery(brief.Results.Ads, assets.ads); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.doc.Bytes(), nil
+}
+
+// cover draws the title page in the template's style
+func (w *brandBookWriter) cover(brief *bezzmodels.BrandBrief, logo int, logoImg image.Image) {
+	page := w.doc.AddPage()
+	w.pages++
+	c := w.colors
+	tagline := brief.Results.Strategy.Tagline
+	date := time.Now().Format("January 2, 2006")
+	m := w.tpl.margin
+
+	switch w.tpl.cover {
+	case "split":
+		panel := pdfPageWidth * 0.45
+		page.Rect(0, 0, panel, pdfPageHeight, c.primary)
+		page.Rect(panel, pdfPageHeight-12, pdfPageWidth-panel, 12, c.accent)
+		ink := readableTextColor(c.primary)
+		y := pdfPageHeight * 0.55
+		for _, line := range pdfWrapText(brief.CompanyName, 30, true, panel-2*m) {
+			page.Text(m, y, 30, true, ink, line)
+			y += 36
+		}
+		page.Text(m, y+6, 12, false, ink, "Brand Book")
+		y += 40
+		for _, line := range pdfWrapText(tagline, 11, false, panel-2*m) {
+			page.Text(m, y, 11, false, ink, line)
+			y += 16
+		}
+		page.Text(m, pdfPageHeight-m, 9, false, ink, date)
+		if logo >= 0 {
+			page.ImageFit(logo, logoImg, panel+m, pdfPageHeight*0.3, pdfPageWidth-panel-2*m, 220)
+		}
+
+	case "minimal":
+		if logo >= 0 {
+			page.ImageFit(logo, logoImg, m, m, 120, 120)
+		}
+		y := pdfPageHeight * 0.5
+		page.Rect(m, y-50, 60, 4, c.accent)
+		for _, line := range pdfWrapText(brief.CompanyName, 36, true, pdfPageWidth-2*m) {
+			page.Text(m, y, 36, true, c.primary, line)
+			y += 42
+		}
+		page.Text(m, y, 13, false, c.muted, "Brand Book")
+		y += 30
+		for _, line := range pdfWrapText(tagline, 12, false, pdfPageWidth-2*m) {
+			page.Text(m, y, 12, false, c.text, line)
+			y += 17
+		}
+		page.Text(m, pdfPageHeight-m, 9, false, c.muted, date)
+
+	default: // full_bleed
+		page.Rect(0, 0, pdfPageWidth, pdfPageHeight, c.primary)
+		page.Rect(0, pdfPageHeight-18, pdfPageWidth, 18, c.accent)
+		ink := readableTextColor(c.primary)
+		if logo >= 0 {
+			card := 200.0
+			x := (pdfPageWidth - card) / 2
+			page.Rect(x, 150, card, card, paletteWhite)
+			page.ImageFit(logo, logoImg, x+16, 166, card-32, card-32)
+		}
+		y := 430.0
+		for _, line := range pdfWrapText(brief.CompanyName, 34, true, pdfPageWidth-2*m) {
+			page.Text((pdfPageWidth-pdfTextWidth(line, 34, true))/2, y, 34, true, ink, line)
+			y += 40
+		}
+		label := "BRAND BOOK"
+		page.Text((pdfPageWidth-pdfTextWidth(label, 12, false))/2, y, 12, false, ink, label)
+		y += 36
+		for _, line := range pdfWrapText(tagline, 13, false, pdfPageWidth-2*m-40) {
+			page.Text((pdfPageWidth-pdfTextWidth(line, 13, false))/2, y, 13, false, ink, line)
+			y += 18
+		}
+		page.Text((pdfPageWidth-pdfTextWidth(date, 9, false))/2, pdfPageHeight-m, 9, false, ink, date)
+	}
+}
+
+// strategy writes positioning, value proposition, pillars, messaging and tone of voice
+func (w *brandBookWriter) strategy(strategy bezzmodels.BrandStrategy) {
+	w.section("Brand Strategy")
+
+	w.heading("Positioning")
+	w.paragraph(strategy.Positioning, 11, false, w.colors.text, 0)
+	w.heading("Value Proposition")
+	w.paragraph(strategy.ValueProposition, 11, false, w.colors.text, 0)
+
+	if strategy.Tagline != "" {
+		w.heading("Tagline")
+		lines := pdfWrapText("“"+strategy.Tagline+"”", 16, true, w.width()-24)
+		h := float64(len(lines))*22 + 20
+		w.ensure(h)
+		w.page.Rect(w.left(), w.y, w.width(), h, w.colors.surface)
+		w.page.Rect(w.left(), w.y, 4, h, w.colors.accent)
+		y := w.y + 10
+		for _, line := range lines {
+			w.page.Text(w.left()+16, y+16, 16, true, w.colors.primary, line)
+			y += 22
+		}
+		w.y += h + 12
+	}
+
+	if len(strategy.BrandPillars) > 0 {
+		w.heading("Brand Pillars")
+		w.bullets(strategy.BrandPillars)
+	}
+
+	w.heading("Messaging Framework")
+	w.paragraph(strategy.MessagingFramework.PrimaryMessage, 11, true, w.colors.text, 0)
+	w.bullets(strategy.MessagingFramework.SupportingMessages)
+
+	tone := strategy.TonalGuidelines
+	w.heading("Tone of Voice")
+	w.paragraph(tone.Voice, 11, false, w.colors.text, 0)
+	if len(tone.Personality) > 0 {
+		w.paragraph("Personality: "+strings.Join(tone.Personality, ", "), 10, false, w.colors.muted, 0)
+	}
+	if len(tone.DoAndDonts.Do) > 0 {
+		w.paragraph("Do", 10, true, w.colors.primary, 0)
+		w.bullets(tone.DoAndDonts.Do)
+	}
+	if len(tone.DoAndDonts.Dont) > 0 {
+		w.paragraph("Don't", 10, true, w.colors.primary, 0)
+		w.bullets(tone.DoAndDonts.Dont)
+	}
+}
+
+// personas writes one card per target segment
+func (w *brandBookWriter) personas(segments []bezzmodels.TargetSegment) {
+	if len(segments) == 0 {
+		return
+	}
+	w.section("Personas")
+	for _, segment := range segments {
+		w.ensure(120)
+		top, page := w.y, w.page
+		w.y += 12
+		title := segment.Name
+		if segment.Role != "" {
+			title += " · " + segment.Role
+		}
+		w.paragraph(title, 13, true, w.colors.primary, 16)
+		w.labelled("Demographics", segment.Demographics)
+		w.labelled("Psychographics", segment.Psychographics)
+		w.labelled("Pain points", strings.Join(segment.PainPoints, "; "))
+		w.labelled("Motivations", strings.Join(segment.Motivations, "; "))
+		w.labelled("Channels", strings.Join(segment.PreferredChannels, ", "))
+		if w.page == page { // the accent bar is only drawn when the card stayed on one page
+			w.page.Rect(w.left(), top, 4, w.y-top, w.colors.accent)
+		}
+		w.y += 14
+	}
+}
+
+// labelled writes a bold label above its value, skipping empty values
+func (w *brandBookWriter) labelled(label, value string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	w.ensure(30)
+	w.page.Text(w.left()+16, w.y+8, 8, true, w.colors.muted, strings.ToUpper(label))
+	w.y += 12
+	w.paragraph(value, 10, false, w.colors.text, 16)
+}
+
+// identity writes the logo concept and the palette page with swatches and color codes
+func (w *brandBookWriter) identity(identity *bezzmodels.BrandIdentity, logo int, logoImg image.Image) {
+	w.section("Logo")
+	if logo >= 0 {
+		box := 220.0
+		w.page.Rect(w.left(), w.y, w.width(), box+40, w.colors.surface)
+		w.page.ImageFit(logo, logoImg, w.left()+20, w.y+20, w.width()-40, box)
+		w.y += box + 56
+	}
+	w.heading("Concept")
+	w.paragraph(identity.LogoConcept, 11, false, w.colors.text, 0)
+
+	analysis := paletteAnalysisFor(identity)
+	if analysis == nil || len(analysis.Colors) == 0 {
+		return
+	}
+	psychology := map[string]string{}
+	for _, c := range identity.ColorPalette {
+		if rgb, err := parseHexColor(c.Hex); err == nil {
+			psychology[hexString(rgb)] = c.Psychology
+		}
+	}
+
+	w.section("Color Palette")
+	swatch := 110.0
+	for _, spec := range analysis.Colors {
+		rgb, err := parseHexColor(spec.Hex)
+		if err != nil {
+			continue
+		}
+		w.ensure(swatch + 20)
+		top := w.y
+		w.page.Rect(w.left(), top, swatch, swatch, rgb)
+		if contrastRatio(rgb, paletteWhite) < 1.2 {
+			w.page.StrokeRect(w.left(), top, swatch, swatch, 0.5, w.colors.muted)
+		}
+		w.page.Text(w.left()+8, top+swatch-10, 9, true, readableTextColor(rgb), spec.Hex)
+
+		// Tint and shade strip under the main swatch
+		steps := append(append([]string{}, spec.Tints...), spec.Shades...)
+		for i, hex := range steps {
+			if step, err := parseHexColor(hex); err == nil {
+				stepW := swatch / float64(len(steps))
+				w.page.Rect(w.left()+float64(i)*stepW, top+swatch+2, stepW, 8, step)
+			}
+		}
+
+		x := w.left() + swatch + 18
+		w.page.Text(x, top+14, 13, true, w.colors.text, spec.Name)
+		codes := []string{
+			"HEX  " + spec.Hex,
+			fmt.Sprintf("RGB  %d, %d, %d", spec.RGB.R, spec.RGB.G, spec.RGB.B),
+			fmt.Sprintf("CMYK  %.0f, %.0f, %.0f, %.0f", spec.CMYK.C, spec.CMYK.M, spec.CMYK.Y, spec.CMYK.K),
+			fmt.Sprintf("HSL  %.0f°, %.0f%%, %.0f%%", spec.HSL.H, spec.HSL.S, spec.HSL.L),
+		}
+		if spec.Usage != "" {
+			codes = append(codes, "Usage: "+spec.Usage)
+		}
+		y := top + 30
+		for _, code := range codes {
+			w.page.Text(x, y, 9, false, w.colors.muted, code)
+			y += 13
+		}
+		for _, line := range pdfWrapText(psychology[spec.Hex], 9, false, w.width()-swatch-18) {
+			if y > top+swatch+8 {
+				break
+			}
+			w.page.Text(x, y+2, 9, false, w.colors.text, line)
+			y += 12
+		}
+		w.y = top + swatch + 24
+	}
+}
+
+// typography writes the font pairing, usage rules and type scale
+func (w *brandBookWriter) typography(typography *bezzmodels.Typography) {
+	w.section("Typography")
+	for _, role := range []struct {
+		label string
+		font  bezzmodels.FontSelection
+	}{{"Headings", typography.Heading}, {"Body", typography.Body}} {
+		if role.font.Family == "" {
+			continue
+		}
+		w.heading(role.label)
+		w.paragraph(role.font.Family, 22, role.label == "Headings", w.colors.primary, 0)
+		weights := make([]string, len(role.font.Weights))
+		for i, weight := range role.font.Weights {
+			weights[i] = fmt.Sprintf("%d", weight)
+		}
+		details := []string{role.font.Category}
+		if len(weights) > 0 {
+			details = append(details, "weights "+strings.Join(weights, ", "))
+		}
+		if role.font.License != "" {
+			details = append(details, role.font.License)
+		}
+		w.paragraph(strings.Join(details, " · "), 9, false, w.colors.muted, 0)
+		if role.font.SourceURL != "" {
+			w.paragraph(role.font.SourceURL, 9, false, w.colors.muted, 0)
+		}
+	}
+	w.paragraph(typography.Rationale, 10, false, w.colors.text, 0)
+
+	if len(typography.Scale) > 0 {
+		w.heading(fmt.Sprintf("Type Scale · %s (%.3g)", typography.ScaleName, typography.ScaleRatio))
+		w.paragraph("Samples are set in Helvetica; use the brand fonts above in production.", 8, false, w.colors.muted, 0)
+		steps := append([]bezzmodels.TypeScaleStep{}, typography.Scale...)
+		sort.SliceStable(steps, func(i, j int) bool { return steps[i].SizePx > steps[j].SizePx })
+		for _, step := range steps {
+			sample := minFloat(step.SizePx*0.75, 36) // px to pt, capped to keep the table on the page
+			rowH := sample + 16
+			w.ensure(rowH)
+			w.page.Text(w.left(), w.y+sample, sample, step.Weight >= 600, w.colors.text, titleCase(step.Name))
+			spec := fmt.Sprintf("%s %d · %.0fpx / %.3grem · %.2g", step.Family, step.Weight, step.SizePx, step.SizeRem, step.LineHeight)
+			w.page.Text(w.left()+w.width()-pdfTextWidth(spec, 8, false), w.y+sample, 8, false, w.colors.muted, spec)
+			w.page.Line(w.left(), w.y+rowH-4, w.left()+w.width(), w.y+rowH-4, 0.5, w.colors.surface)
+			w.y += rowH
+		}
+		w.y += 10
+	}
+
+	if len(typography.UsageRules) > 0 {
+		w.heading("Usage")
+		w.bullets(typography.UsageRules)
+	}
+}
+
+// brandNames writes the name options with their rationale
+func (w *brandBookWriter) brandNames(names []bezzmodels.BrandNameSuggestion) {
+	w.section("Brand Name Options")
+	for i, name := range names {
+		w.ensure(50)
+		w.paragraph(fmt.Sprintf("%d. %s", i+1, name.Name), 15, true, w.colors.primary, 0)
+		w.paragraph(name.Rationale, 10, false, w.colors.text, 0)
+		w.y += 6
+	}
+}
+
+// gallery writes the ads in a grid with their copy under each image
+func (w *brandBookWriter) gallery(ads []bezzmodels.AdCampaign, images []image.Image) error {
+	w.section("Ad Gallery")
+	cols := w.tpl.galleryColumns
+	gap := 16.0
+	cellW := (w.width() - gap*float64(cols-1)) / float64(cols)
+	imageH := minFloat(cellW, 300)
+
+	rowTop, rowBottom := w.y, w.y
+	for i, ad := range ads {
+		col := i % cols
+		if col == 0 {
+			w.y = rowBottom
+			if i > 0 {
+				w.y += gap
+			}
+			w.ensure(imageH + 150) // image plus the tallest copy block
+			rowTop, rowBottom = w.y, w.y
+		}
+		x := w.left() + float64(col)*(cellW+gap)
+
+		w.page.Rect(x, rowTop, cellW, imageH, w.colors.surface)
+		if i < len(images) && images[i] != nil {
+			index, err := w.doc.AddImage(images[i])
+			if err != nil {
+				return fmt.Errorf("failed to embed ad %s: %w", ad.ID, err)
+			}
+			w.page.ImageFit(index, images[i], x, rowTop, cellW, imageH)
+		} else {
+			label := "Image unavailable"
+			w.page.Text(x+(cellW-pdfTextWidth(label, 9, false))/2, rowTop+imageH/2, 9, false, w.colors.muted, label)
+		}
+
+		y := rowTop + imageH + 14
+		meta := strings.TrimSpace(strings.Join([]string{ad.Platform, ad.Placement, ad.AspectRatio}, " · "))
+		w.page.Text(x, y, 7, true, w.colors.muted, strings.ToUpper(strings.Trim(meta, " ·")))
+		y += 14
+		for _, line := range limitLines(pdfWrapText(ad.Copy.Headline, 11, true, cellW), 3) {
+			w.page.Text(x, y, 11, true, w.colors.text, line)
+			y += 14
+		}
+		for _, line := range limitLines(pdfWrapText(ad.Copy.Body, 9, false, cellW), 5) {
+			w.page.Text(x, y, 9, false, w.colors.text, line)
+			y += 12
+		}
+		if ad.Copy.CTA != "" {
+			bw := pdfTextWidth(ad.Copy.CTA, 9, true) + 16
+			w.page.Rect(x, y-4, bw, 18, w.colors.accent)
+			w.page.Text(x+8, y+8, 9, true, readableTextColor(w.colors.accent), ad.Copy.CTA)
+			y += 20
+		}
+		if y > rowBottom {
+			rowBottom = y
+		}
+	}
+	w.y = rowBottom
+	return nil
+}
+
+// limitLines keeps at most n lines, ending the last kept line with an ellipsis
+func limitLines(lines []string, n int) []string {
+	if len(lines) <= n {
+		return lines
+	}
+	lines = lines[:n]
+	lines[n-1] += "…"
+	return lines
+}
+
+// titleCase upper-cases the first letter of s
+func titleCase(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+utf8.RuneLen(r):]
+	}
+	return s
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func sampleBrandBookBrief() *models.BrandBrief {
+	return &models.BrandBrief{
+		CompanyName: "Acme Coffee",
+		Results: &models.BrandResults{
+			Strategy: models.BrandStrategy{
+				Positioning:      "The friendliest specialty roaster in Lagos (and proud of it).",
+				ValueProposition: "Fresh beans, delivered weekly.",
+				Tagline:          "Mornings, sorted",
+				BrandPillars:     []string{"Freshness", "Craft", "Community"},
+				TargetSegments: []models.TargetSegment{
+					{Name: "Busy Bola", Role: "Office manager", Demographics: "28-40, urban", PainPoints: []string{"Stale office coffee"}},
+				},
+			},
+			BrandNames: []models.BrandNameSuggestion{{Name: "Roastwell", Rationale: "Warm and crafted"}},
+			BrandIdentity: &models.BrandIdentity{
+				LogoConcept: "A steaming cup inside a sunrise",
+				ColorPalette: []models.Color{
+					{Name: "Espresso", Hex: "#3B2416", Usage: "primary", Psychology: "Rich and grounded"},
+					{Name: "Crema", Hex: "#F2E1C9", Usage: "secondary"},
+					{Name: "Sunrise", Hex: "#F28C28", Usage: "accent"},
+				},
+				Typography: &models.Typography{
+					Heading:   models.FontSelection{Family: "Playfair Display", Category: "serif", Weights: []int{700}},
+					Body:      models.FontSelection{Family: "Inter", Category: "sans-serif", Weights: []int{400, 600}},
+					ScaleName: "major third", ScaleRatio: 1.25,
+					Scale: []models.TypeScaleStep{{Name: "h1", Family: "Playfair Display", Weight: 700, SizePx: 39, SizeRem: 2.44, LineHeight: 1.2}},
+				},
+			},
+			Ads: []models.AdCampaign{
+				{ID: "ad_1", Platform: "instagram", Copy: models.AdCopy{Headline: "Brew better", Body: "Fresh coffee", CTA: "Shop Now"}},
+				{ID: "ad_2", Platform: "facebook", Copy: models.AdCopy{Headline: "Wake up happy", CTA: "Learn More"}},
+			},
+		},
+	}
+}
+
+func solidImage(w, h int, c color.NRGBA) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	return img
+}
+
+// pdfContentText inflates every Flate stream in a PDF and concatenates them
+func pdfContentText(t *testing.T, pdf []byte) string {
+	var text strings.Builder
+	streams := regexp.MustCompile(`(?s)/Filter /FlateDecode /Length (\d+) >>\nstream\n`)
+	for _, m := range streams.FindAllSubmatchIndex(pdf, -1) {
+		length, _ := strconv.Atoi(string(pdf[m[2]:m[3]]))
+		r, err := zlib.NewReader(bytes.NewReader(pdf[m[1] : m[1]+length]))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		text.Write(data)
+	}
+	return text.String()
+}
+
+func TestRenderBrandBook_WritesValidMultiPagePDF(t *testing.T) {
+	logo := solidImage(200, 100, color.NRGBA{R: 0x3B, G: 0x24, B: 0x16, A: 0x80}) // translucent, so it gets a soft mask
+	ad := solidImage(300, 300, color.NRGBA{R: 0xF2, G: 0x8C, B: 0x28, A: 0xff})
+
+	for _, tpl := range BrandBookTemplates() {
+		t.Run(tpl.ID, func(t *testing.T) {
+			pdf, err := renderBrandBook(sampleBrandBookBrief(), tpl.ID, exportImages{logo: logo, ads: []image.Image{ad, nil}})
+			require.NoError(t, err)
+
+			assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+			assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+
+			// Every xref entry points at its object
+			xref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(pdf)
+			require.NotNil(t, xref)
+			offset, _ := strconv.Atoi(string(xref[1]))
+			entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[offset:], -1)
+			require.NotEmpty(t, entries)
+			for i, entry := range entries {
+				at, _ := strconv.Atoi(string(entry[1]))
+				assert.True(t, bytes.HasPrefix(pdf[at:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+			}
+
+			// Cover, strategy, personas, logo, palette, typography, names, gallery
+			pages := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(pdf)
+			count, _ := strconv.Atoi(string(pages[1]))
+			assert.GreaterOrEqual(t, count, 8)
+			assert.Equal(t, count, bytes.Count(pdf, []byte("/Type /Page ")))
+
+			assert.Equal(t, 2, bytes.Count(pdf, []byte("/Subtype /Image /Width 200")), "logo and its soft mask")
+			assert.Contains(t, string(pdf), "/SMask")
+			assert.Contains(t, string(pdf), "/Filter /DCTDecode", "opaque ad images are embedded as JPEG")
+
+			content := pdfContentText(t, pdf)
+			for _, want := range []string{"Acme Coffee", "Brand Strategy", "Busy Bola", "#3B2416", "CMYK  0, 39, 63, 77", "Playfair Display", "Roastwell", "Brew better", "Image unavailable", `\(and proud of it\)`} {
+				assert.Contains(t, content, want)
+			}
+			assert.Contains(t, content, "0.231 0.141 0.086 rg", "the primary palette color is used")
+		})
+	}
+}
+
+func TestRenderBrandBook_WithoutImagesOrIdentity(t *testing.T) {
+	brief := sampleBrandBookBrief()
+	brief.Results.BrandIdentity = nil
+	pdf, err := renderBrandBook(brief, DefaultBrandBookTemplate, exportImages{})
+	require.NoError(t, err)
+	assert.NotContains(t, string(pdf), "/Subtype /Image")
+}
+
+func TestValidateBrandBookTemplate(t *testing.T) {
+	assert.NoError(t, ValidateBrandBookTemplate("modern"))
+	err := ValidateBrandBookTemplate("baroque")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "classic, modern, minimal")
+}
+
+func TestPDFEscape_WinAnsi(t *testing.T) {
+	assert.Equal(t, `Caf\351 \(50\\50\) \223hi\224 \200 ?`, pdfEscape("Café (50\\50) “hi” € 日"))
+}
+
+func TestPDFWrapText(t *testing.T) {
+	lines := pdfWrapText("one two three four five six", 10, false, 60)
+	for _, line := range lines {
+		assert.LessOrEqual(t, pdfTextWidth(line, 10, false), 60.0)
+	}
+	assert.Equal(t, "one two three four five six", strings.Join(lines, " "))
+	assert.Equal(t, []string{"a", "", "b"}, pdfWrapText("a\n\nb", 10, false, 100))
+}
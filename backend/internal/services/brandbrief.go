@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,49 +19,49 @@ import (
 	"google.golang.org/api/iterator"
 )
 
+// ErrBriefNotFound is returned when a brief doesn't exist
+var ErrBriefNotFound = errors.New("brief not found")
+
+// ErrNoModerationReport is returned when reviewing a brief that was never moderated
+var ErrNoModerationReport = errors.New("brief has no moderation report")
+
+// ErrInsufficientCredits is returned when retrying an unpaid brief without a credit to charge
+var ErrInsufficientCredits = errors.New("insufficient credits")
+
 // BrandBriefService handles brand brief operations
 type BrandBriefService struct {
 	db         *firestore.Client
 	aiService  *AIService
+	sectors    *SectorService
 	storage    *storage.Client
 	bucketName string
 }
 
 // NewBrandBriefService creates a new brand brief service
-func NewBrandBriefService(db *firestore.Client, aiService *AIService, storage *storage.Client, bucketName string) *BrandBriefService {
+func NewBrandBriefService(db *firestore.Client, aiService *AIService, sectors *SectorService, storage *storage.Client, bucketName string) *BrandBriefService {
 	return &BrandBriefService{
 		db:         db,
 		aiService:  aiService,
+		sectors:    sectors,
 		storage:    storage,
 		bucketName: bucketName,
 	}
 }
 
 // CreateBrief creates a new brand brief and starts processing
-func (s *BrandBriefService) CreateBrief(ctx context.Context, userID string, req *models.BrandBriefRequest) (*models.BrandBrief, error) {
+func (s *BrandBriefService) CreateBrief(ctx context.Context, userID string, req *models.BrandBriefRequest, assets *models.BrandAssets, moderation *models.ModerationReport) (*models.BrandBrief, error) {
 	log.Printf("🏗️ BRIEF SERVICE: Creating brief for user %s", userID)
 
-	// Create brief document
-	briefID := generateID()
-	brief := &models.BrandBrief{
-		ID:             briefID,
-		UserID:         userID,
-		CompanyName:    req.CompanyName,
-		Sector:         req.Sector,
-		Tone:           req.Tone,
-		TargetAudience: req.TargetAudience,
-		Language:       req.Language,
-		AdditionalInfo: req.AdditionalInfo,
-		Status:         "processing",
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+	brief, err := s.newBrief(ctx, userID, req, assets, moderation, "processing")
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("📝 BRIEF SERVICE: Brief data - ID:%s, Company:%s, Sector:%s", briefID, req.CompanyName, req.Sector)
+	log.Printf("📝 BRIEF SERVICE: Brief data - ID:%s, Company:%s, Sector:%s", brief.ID, req.CompanyName, brief.SectorID)
 
 	// Save to Firestore
 	log.Printf("💾 BRIEF SERVICE: Saving to Firestore...")
-	_, err := s.db.Collection("briefs").Doc(brief.ID).Set(ctx, brief)
+	_, err = s.db.Collection("briefs").Doc(brief.ID).Set(ctx, brief)
 	if err != nil {
 		log.Printf("❌ BRIEF SERVICE: Failed to save to Firestore: %v", err)
 		return nil, err
@@ -74,6 +76,55 @@ func (s *BrandBriefService) CreateBrief(ctx context.Context, userID string, req
 	return brief, nil
 }
 
+// ModerateRequest checks a new brief's inputs against the content policy
+func (s *BrandBriefService) ModerateRequest(ctx context.Context, req *models.BrandBriefRequest) *models.ModerationReport {
+	return s.aiService.ModerateBriefRequest(ctx, req)
+}
+
+// RecordBlockedBrief saves a brief whose inputs failed moderation so admins can review it; it is never processed
+func (s *BrandBriefService) RecordBlockedBrief(ctx context.Context, userID string, req *models.BrandBriefRequest, assets *models.BrandAssets, moderation *models.ModerationReport) (*models.BrandBrief, error) {
+	brief, err := s.newBrief(ctx, userID, req, assets, moderation, "blocked")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Collection("briefs").Doc(brief.ID).Set(ctx, brief); err != nil {
+		return nil, err
+	}
+	log.Printf("🛡️ BRIEF SERVICE: Recorded blocked brief %s for review", brief.ID)
+	return brief, nil
+}
+
+// newBrief builds the brief document for a request
+func (s *BrandBriefService) newBrief(ctx context.Context, userID string, req *models.BrandBriefRequest, assets *models.BrandAssets, moderation *models.ModerationReport, status string) (*models.BrandBrief, error) {
+	// Store the sector's canonical label and ID rather than whatever the client sent
+	sector, err := s.sectors.ResolveSector(ctx, req.Sector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BrandBrief{
+		ID:             generateID(),
+		UserID:         userID,
+		CompanyName:    req.CompanyName,
+		Sector:         sector.Labels["en"],
+		SectorID:       sector.ID,
+		Tone:           req.Tone,
+		TargetAudience: req.TargetAudience,
+		Language:       req.Language,
+		AdditionalInfo: req.AdditionalInfo,
+		Placements:     req.Placements,
+		Style:          req.Style,
+		AdStyles:       req.AdStyles,
+		Assets:         assets,
+		VideoDurations: req.VideoDurations,
+		Moderation:     moderation,
+		Status:         status,
+		Unpaid:         status == "blocked", // briefs blocked at submission are never charged
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
 // GetBrief retrieves a brand brief by ID
 func (s *BrandBriefService) GetBrief(ctx context.Context, briefID string) (*models.BrandBrief, error) {
 	log.Printf("📖 BRIEF SERVICE: Getting brief %s from Firestore", briefID)
@@ -82,7 +133,7 @@ func (s *BrandBriefService) GetBrief(ctx context.Context, briefID string) (*mode
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			log.Printf("❌ BRIEF SERVICE: Brief %s not found in Firestore", briefID)
-			return nil, fmt.Errorf("brief not found")
+			return nil, ErrBriefNotFound
 		}
 		log.Printf("❌ BRIEF SERVICE: Firestore error getting brief %s: %v", briefID, err)
 		return nil, err
@@ -170,6 +221,14 @@ func (s *BrandBriefService) RetryProcessing(ctx context.Context, briefID string)
 
 	log.Printf("✅ RETRY SERVICE: Brief %s is retryable (status: %s)", briefID, brief.Status)
 
+	// Briefs approved after being blocked had their credit returned, so retrying charges it again
+	if brief.Unpaid {
+		if err := s.chargeUnpaidBrief(ctx, brief); err != nil {
+			log.Printf("❌ RETRY SERVICE: Failed to charge brief %s: %v", briefID, err)
+			return err
+		}
+	}
+
 	// Start processing in a goroutine with a fresh background context
 	// We can't use the HTTP request context because it gets cancelled when the request completes
 	go func() {
@@ -191,17 +250,49 @@ func (s *BrandBriefService) processBrief(ctx context.Context, brief *models.Bran
 	log.Printf("📊 AI PIPELINE: Updating status to processing...")
 	s.updateBriefStatus(ctx, brief.ID, "processing")
 
+	// Content-safety flags from every stage are merged into the stored report, which an admin may review while this runs
+	saveModeration := func(flags []models.ModerationFlag) {
+		if len(flags) == 0 {
+			return
+		}
+		if err := s.addModerationFlags(ctx, brief.ID, flags); err != nil {
+			log.Printf("⚠️ AI PIPELINE: Failed to save moderation report for brief %s: %v", brief.ID, err)
+		}
+	}
+	// Blocked briefs match briefs blocked at submission: they aren't retryable, wait for an admin's review
+	// and don't cost the user a credit
+	blockBrief := func(flags []models.ModerationFlag) {
+		saveModeration(flags)
+		if err := s.blockAndRefundBrief(ctx, brief.ID); err != nil {
+			log.Printf("⚠️ AI PIPELINE: Failed to block brief %s: %v", brief.ID, err)
+			s.updateBriefStatus(ctx, brief.ID, "blocked")
+		}
+	}
+
 	// Execute the Brief-GPT -> Strategist-GPT pipeline
 	log.Printf("🤖 AI PIPELINE: Executing Brief-GPT -> Strategist-GPT pipeline...")
 	strategy, err := s.aiService.ProcessBriefPipeline(ctx, brief)
 	if err != nil {
 		log.Printf("❌ AI PIPELINE: Strategy generation failed for brief %s: %v", brief.ID, err)
+		if leakFlags := promptLeakFlags("strategy", moderationActionBlocked, err); leakFlags != nil {
+			blockBrief(leakFlags)
+			return
+		}
 		s.updateBriefStatus(ctx, brief.ID, "failed")
 		return
 	}
 
 	log.Printf("✅ AI PIPELINE: Strategy generated successfully for brief %s", brief.ID)
 
+	// Check the strategy copy before it is saved or fed into the later stages
+	safe, strategyFlags := s.aiService.ModerateStrategy(ctx, strategy)
+	if !safe {
+		log.Printf("❌ AI PIPELINE: Strategy for brief %s failed moderation", brief.ID)
+		blockBrief(strategyFlags)
+		return
+	}
+	saveModeration(strategyFlags)
+
 	// Generate brand name alternatives
 	log.Printf("🏷️ AI PIPELINE: Starting brand name generation...")
 	brandNames, err := s.aiService.GenerateBrandNames(ctx, brief, strategy)
@@ -209,38 +300,68 @@ func (s *BrandBriefService) processBrief(ctx context.Context, brief *models.Bran
 		log.Printf("⚠️ AI PIPELINE: Brand name generation failed, continuing without alternatives: %v", err)
 		brandNames = []models.BrandNameSuggestion{} // Graceful degradation
 	}
+	brandNames, nameFlags := s.aiService.ModerateBrandNames(ctx, brandNames)
+	saveModeration(nameFlags)
 
 	log.Printf("✅ AI PIPELINE: Generated %d brand name suggestions", len(brandNames))
 
 	// Generate brand identity (logo + colors) - REQUIRED for completion
 	log.Printf("🎨 AI PIPELINE: Starting brand identity generation...")
-	brandIdentity, err := s.aiService.GenerateBrandIdentity(ctx, strategy, brief.CompanyName, brief.Sector, brief.TargetAudience)
+	brandIdentity, identityFlags, err := s.aiService.GenerateBrandIdentity(ctx, strategy, brief.CompanyName, brief.Sector, brief.TargetAudience, brief.Assets)
+	saveModeration(identityFlags)
 	if err != nil {
 		log.Printf("❌ AI PIPELINE: Brand identity generation failed for brief %s: %v", brief.ID, err)
+		if leakFlags := promptLeakFlags("identity", moderationActionBlocked, err); leakFlags != nil || errors.Is(err, ErrContentBlocked) {
+			blockBrief(leakFlags)
+			return
+		}
 		s.updateBriefStatus(ctx, brief.ID, "failed")
 		return
 	}
 
 	log.Printf("✅ AI PIPELINE: Generated brand identity with %d colors", len(brandIdentity.ColorPalette))
 
+	// Derive the logo suite (transparent, icon, wordmark, mono, favicon, app icons, avatars) and the SVG logo
+	s.aiService.GenerateLogoVariants(ctx, brandIdentity, brief.CompanyName)
+	s.aiService.GenerateVectorLogo(ctx, brandIdentity, brief.CompanyName)
+
 	// Update status to strategy completed
 	log.Printf("💾 AI PIPELINE: Saving strategy, brand names, and identity to Firestore...")
 	s.updateBriefStatusWithStrategyNamesAndIdentity(ctx, brief.ID, "strategy_completed", strategy, brandNames, brandIdentity)
 
 	// Generate ad campaigns
 	log.Printf("🎨 AI PIPELINE: Starting ad campaign generation...")
-	adSpecs, err := s.aiService.GenerateAds(ctx, strategy, brandIdentity)
+	adSpecs, err := s.aiService.GenerateAds(ctx, strategy, brandIdentity, brief.Placements, StyleSelection{Default: brief.Style, PerPlacement: brief.AdStyles})
 	if err != nil {
 		log.Printf("❌ AI PIPELINE: Ad generation failed for brief %s: %v", brief.ID, err)
+		if leakFlags := promptLeakFlags("ad", moderationActionBlocked, err); leakFlags != nil {
+			blockBrief(leakFlags)
+			return
+		}
 		s.updateBriefStatus(ctx, brief.ID, "ads_failed")
 		return
 	}
 
 	log.Printf("✅ AI PIPELINE: Generated %d ad specifications", len(adSpecs.Ads))
 
+	// Check every copy block and image prompt before anything is rendered
+	safeAds, adFlags := s.aiService.ModerateAdSpecs(ctx, adSpecs.Ads)
+	if len(adSpecs.Ads) > 0 && len(safeAds) == 0 {
+		// Like flagged strategy or identity output, this blocks the brief so the flagged ads can't be regenerated by retrying
+		log.Printf("❌ AI PIPELINE: Every ad for brief %s failed moderation", brief.ID)
+		blockBrief(adFlags)
+		return
+	}
+	saveModeration(adFlags)
+	adSpecs.Ads = safeAds
+
 	// Generate images for ads
 	log.Printf("🖼️ AI PIPELINE: Starting image generation...")
-	ads, err := s.aiService.RenderImages(ctx, adSpecs.Ads, brief.CompanyName, brief.Sector)
+	var productShots []models.BrandAsset
+	if brief.Assets != nil {
+		productShots = brief.Assets.ProductShots
+	}
+	ads, err := s.aiService.RenderImages(ctx, adSpecs.Ads, brief.CompanyName, s.briefSector(ctx, brief), productShots)
 	if err != nil {
 		log.Printf("❌ AI PIPELINE: Image generation failed for brief %s: %v", brief.ID, err)
 		s.updateBriefStatus(ctx, brief.ID, "images_failed")
@@ -249,10 +370,27 @@ func (s *BrandBriefService) processBrief(ctx context.Context, brief *models.Bran
 
 	log.Printf("✅ AI PIPELINE: Generated %d complete ads with images", len(ads))
 
+	// Composite headline, CTA and logo into ready-to-post creatives (best effort)
+	log.Printf("🧩 AI PIPELINE: Compositing finished creatives...")
+	s.aiService.ComposeCreatives(ctx, ads, brandIdentity)
+
 	// Update status with ads
 	log.Printf("💾 AI PIPELINE: Saving ads to Firestore...")
 	s.updateBriefStatusWithAds(ctx, brief.ID, "ads_completed", ads)
 
+	// Storyboard the video ads the plan allowed at creation (best effort)
+	if len(brief.VideoDurations) > 0 {
+		log.Printf("🎬 AI PIPELINE: Starting video storyboard generation...")
+		videoAds, videoFlags, err := s.aiService.GenerateVideoStoryboards(ctx, strategy, brandIdentity, brief.CompanyName, s.briefSector(ctx, brief), brief.Style, brief.VideoDurations)
+		// The storyboards are dropped rather than the brief, which already has its ads
+		saveModeration(append(videoFlags, promptLeakFlags("video", moderationActionDropped, err)...))
+		if err != nil {
+			log.Printf("⚠️ AI PIPELINE: Video storyboards failed, completing without them: %v", err)
+		} else {
+			s.updateBriefVideoAds(ctx, brief.ID, videoAds)
+		}
+	}
+
 	// Mark as completed
 	log.Printf("🎉 AI PIPELINE: Marking brief %s as completed", brief.ID)
 	s.updateBriefStatus(ctx, brief.ID, "completed")
@@ -321,6 +459,195 @@ func (s *BrandBriefService) updateBriefStatusWithAds(ctx context.Context, briefI
 	}
 }
 
+// updateBriefVideoAds saves the video storyboards without changing the brief's status
+func (s *BrandBriefService) updateBriefVideoAds(ctx context.Context, briefID string, videoAds []models.VideoAd) {
+	updates := []firestore.Update{
+		{Path: "results.videoAds", Value: videoAds},
+		{Path: "updatedAt", Value: time.Now()},
+	}
+
+	_, err := s.db.Collection("briefs").Doc(briefID).Update(ctx, updates)
+	if err != nil {
+		log.Printf("Failed to update brief %s with video ads: %v", briefID, err)
+	}
+}
+
+// addModerationFlags merges flags into the brief's stored moderation report. It runs in a transaction so a
+// review saved while the pipeline is running isn't overwritten.
+func (s *BrandBriefService) addModerationFlags(ctx context.Context, briefID string, flags []models.ModerationFlag) error {
+	ref := s.db.Collection("briefs").Doc(briefID)
+	err := s.db.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		brief, err := briefInTransaction(tx, ref)
+		if err != nil {
+			return err
+		}
+		report := brief.Moderation
+		if report == nil {
+			report = &models.ModerationReport{Status: moderationClear}
+		}
+		recordModeration(report, flags)
+
+		return tx.Update(ref, []firestore.Update{
+			{Path: "moderation", Value: report},
+			{Path: "updatedAt", Value: time.Now()},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save moderation report: %w", err)
+	}
+	return nil
+}
+
+// blockAndRefundBrief marks a brief blocked and returns the credit it was charged, once
+func (s *BrandBriefService) blockAndRefundBrief(ctx context.Context, briefID string) error {
+	ref := s.db.Collection("briefs").Doc(briefID)
+	return s.db.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		brief, err := briefInTransaction(tx, ref)
+		if err != nil {
+			return err
+		}
+
+		updates := []firestore.Update{
+			{Path: "status", Value: "blocked"},
+			{Path: "updatedAt", Value: time.Now()},
+		}
+		if !brief.Unpaid {
+			updates = append(updates, firestore.Update{Path: "unpaid", Value: true})
+			userRef := s.db.Collection("users").Doc(brief.UserID)
+			if err := tx.Update(userRef, []firestore.Update{{Path: "credits", Value: firestore.Increment(1)}}); err != nil {
+				return fmt.Errorf("failed to refund credit: %w", err)
+			}
+			log.Printf("💸 AI PIPELINE: Refunding the credit for blocked brief %s", briefID)
+		}
+		return tx.Update(ref, updates)
+	})
+}
+
+// chargeUnpaidBrief takes a credit for a brief that holds none, before it is processed again
+func (s *BrandBriefService) chargeUnpaidBrief(ctx context.Context, brief *models.BrandBrief) error {
+	ref := s.db.Collection("briefs").Doc(brief.ID)
+	userRef := s.db.Collection("users").Doc(brief.UserID)
+	return s.db.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		current, err := briefInTransaction(tx, ref)
+		if err != nil {
+			return err
+		}
+		if !current.Unpaid {
+			return nil
+		}
+
+		userDoc, err := tx.Get(userRef)
+		if err != nil {
+			return err
+		}
+		var user models.User
+		if err := userDoc.DataTo(&user); err != nil {
+			return err
+		}
+		if user.Credits < 1 {
+			return ErrInsufficientCredits
+		}
+
+		if err := tx.Update(userRef, []firestore.Update{
+			{Path: "credits", Value: firestore.Increment(-1)},
+			{Path: "updatedAt", Value: time.Now()},
+		}); err != nil {
+			return err
+		}
+		return tx.Update(ref, []firestore.Update{{Path: "unpaid", Value: firestore.Delete}})
+	})
+}
+
+// briefInTransaction reads a brief inside a Firestore transaction
+func briefInTransaction(tx *firestore.Transaction, ref *firestore.DocumentRef) (*models.BrandBrief, error) {
+	doc, err := tx.Get(ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrBriefNotFound
+		}
+		return nil, err
+	}
+	var brief models.BrandBrief
+	if err := doc.DataTo(&brief); err != nil {
+		return nil, err
+	}
+	return &brief, nil
+}
+
+// ListModerationQueue returns briefs with moderation flags awaiting admin review, most recent first
+func (s *BrandBriefService) ListModerationQueue(ctx context.Context, limit int) ([]*models.BrandBrief, error) {
+	// Filtering on a single field avoids a composite index; the queue is small enough to sort in memory
+	docs, err := s.db.Collection("briefs").Where("moderation.needsReview", "==", true).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	briefs := make([]*models.BrandBrief, 0, len(docs))
+	for _, doc := range docs {
+		var brief models.BrandBrief
+		if err := doc.DataTo(&brief); err != nil {
+			log.Printf("⚠️ MODERATION QUEUE: Failed to parse brief %s: %v", doc.Ref.ID, err)
+			continue
+		}
+		briefs = append(briefs, &brief)
+	}
+
+	sort.Slice(briefs, func(i, j int) bool { return briefs[i].UpdatedAt.After(briefs[j].UpdatedAt) })
+	if len(briefs) > limit {
+		briefs = briefs[:limit]
+	}
+	return briefs, nil
+}
+
+// ReviewModeration records an admin's decision on a flagged brief and removes it from the queue.
+// It runs in a transaction so flags the pipeline adds at the same time aren't lost.
+func (s *BrandBriefService) ReviewModeration(ctx context.Context, briefID, reviewerID string, review *models.ModerationReviewRequest) (*models.BrandBrief, error) {
+	ref := s.db.Collection("briefs").Doc(briefID)
+	var brief *models.BrandBrief
+	var revokedToken string
+	err := s.db.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var err error
+		brief, err = briefInTransaction(tx, ref)
+		if err != nil {
+			return err
+		}
+		if brief.Moderation == nil {
+			return ErrNoModerationReport
+		}
+
+		now := time.Now()
+		applyModerationReview(brief.Moderation, reviewerID, review, now)
+		updates := []firestore.Update{
+			{Path: "moderation", Value: brief.Moderation},
+			{Path: "updatedAt", Value: now},
+		}
+		// Approved briefs that were blocked can be retried by their owner
+		if status := reviewedBriefStatus(brief.Status, review.Decision); status != brief.Status {
+			updates = append(updates, firestore.Update{Path: "status", Value: status})
+			brief.Status = status
+		}
+		if review.Decision == moderationDecisionRejected {
+			// Take down any public link to the rejected content
+			updates = append(updates, firestore.Update{Path: "shareToken", Value: firestore.Delete})
+			revokedToken, brief.ShareToken = brief.ShareToken, ""
+		}
+		return tx.Update(ref, updates)
+	})
+	if err != nil {
+		if errors.Is(err, ErrBriefNotFound) || errors.Is(err, ErrNoModerationReport) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	if revokedToken != "" {
+		deleteSharedMicrosite(ctx, s.storage, s.bucketName, revokedToken)
+	}
+
+	log.Printf("🛡️ MODERATION: Brief %s %s by %s", briefID, review.Decision, reviewerID)
+	return brief, nil
+}
+
 // updateBriefStatus updates the status of a brief
 func (s *BrandBriefService) updateBriefStatus(ctx context.Context, briefID, status string) {
 	updates := []firestore.Update{
@@ -351,9 +678,28 @@ func (s *BrandBriefService) RefreshImageURLs(ctx context.Context, briefID, userI
 		return nil, fmt.Errorf("access denied")
 	}
 
+	if brief.Results == nil {
+		return brief, nil // Nothing generated yet
+	}
+
+	// Refresh the logo variants
+	if identity := brief.Results.BrandIdentity; identity != nil {
+		for i, variant := range identity.LogoVariants {
+			variantURL, err := s.aiService.GenerateSignedURL(ctx, logoVariantObject(variant))
+			if err != nil {
+				log.Printf("⚠️ REFRESH IMAGE URLS: Failed to refresh logo variant %s: %v", variant.Name, err)
+				continue
+			}
+			identity.LogoVariants[i].ImageURL = variantURL
+		}
+	}
+
+	// Refresh storyboard keyframes, subtitles and contact sheets
+	s.refreshVideoURLs(ctx, brief.Results.VideoAds)
+
 	// Check if brief has ads with images
-	if brief.Results == nil || brief.Results.Ads == nil {
-		return brief, nil // No ads to refresh
+	if brief.Results.Ads == nil && brief.Results.BrandIdentity == nil && brief.Results.VideoAds == nil {
+		return brief, nil // Nothing to refresh
 	}
 
 	log.Printf("🔄 REFRESH IMAGE URLS: Found %d campaigns to refresh", len(brief.Results.Ads))
@@ -387,13 +733,34 @@ func (s *BrandBriefService) RefreshImageURLs(ctx context.Context, briefID, userI
 		// Update the campaign with new signed URL
 		brief.Results.Ads[i].ImageURL = newSignedURL
 		log.Printf("✅ REFRESH IMAGE URLS: Refreshed URL for campaign %d using object: %s", i, objectName)
+
+		// Refresh finished creatives stored alongside the raw image
+		for j, creative := range campaign.Creatives {
+			if creative.ObjectName == "" {
+				continue
+			}
+			creativeURL, err := s.aiService.GenerateSignedURL(ctx, creative.ObjectName+".png")
+			if err != nil {
+				log.Printf("⚠️ REFRESH IMAGE URLS: Failed to refresh %s creative for campaign %d: %v", creative.Size, i, err)
+				continue
+			}
+			brief.Results.Ads[i].Creatives[j].ImageURL = creativeURL
+		}
 	}
 
 	// Update the brief in Firestore
 	updates := []firestore.Update{
-		{Path: "results.ads", Value: brief.Results.Ads},
 		{Path: "updatedAt", Value: firestore.ServerTimestamp},
 	}
+	if brief.Results.Ads != nil {
+		updates = append(updates, firestore.Update{Path: "results.ads", Value: brief.Results.Ads})
+	}
+	if brief.Results.BrandIdentity != nil {
+		updates = append(updates, firestore.Update{Path: "results.brandIdentity.logoVariants", Value: brief.Results.BrandIdentity.LogoVariants})
+	}
+	if brief.Results.VideoAds != nil {
+		updates = append(updates, firestore.Update{Path: "results.videoAds", Value: brief.Results.VideoAds})
+	}
 
 	_, err = s.db.Collection("briefs").Doc(briefID).Update(ctx, updates)
 	if err != nil {
@@ -404,6 +771,43 @@ func (s *BrandBriefService) RefreshImageURLs(ctx context.Context, briefID, userI
 	return brief, nil
 }
 
+// refreshVideoURLs re-signs the stored storyboard and voiceover files, keeping the old URL when signing fails
+func (s *BrandBriefService) refreshVideoURLs(ctx context.Context, videos []models.VideoAd) {
+	refresh := func(url *string, fullName string) {
+		signed, err := s.aiService.GenerateSignedURL(ctx, fullName)
+		if err != nil {
+			log.Printf("⚠️ REFRESH IMAGE URLS: Failed to refresh %s: %v", fullName, err)
+			return
+		}
+		*url = signed
+	}
+
+	for i := range videos {
+		video := &videos[i]
+		for j := range video.Scenes {
+			scene := &video.Scenes[j]
+			if scene.KeyframeObjectName != "" {
+				refresh(&scene.KeyframeURL, scene.KeyframeObjectName+".png")
+			}
+			if scene.VoiceoverObjectName != "" {
+				refresh(&scene.VoiceoverURL, scene.VoiceoverObjectName+".wav")
+			}
+		}
+		if video.SubtitlesObjectName != "" {
+			refresh(&video.SubtitlesURL, video.SubtitlesObjectName+".srt")
+		}
+		if video.StoryboardObjectName != "" {
+			refresh(&video.StoryboardURL, video.StoryboardObjectName+".png")
+		}
+		if video.VideoObjectName != "" {
+			refresh(&video.VideoURL, video.VideoObjectName+".mp4")
+		}
+		if video.ThumbnailObjectName != "" {
+			refresh(&video.ThumbnailURL, video.ThumbnailObjectName+".png")
+		}
+	}
+}
+
 // discoverObjectName attempts to discover the GCS object name for backward compatibility
 func (s *BrandBriefService) discoverObjectName(ctx context.Context, imageURL, companyName string, campaignIndex int) (string, error) {
 	// Try to list objects in the bucket that match the company name pattern
@@ -442,3 +846,18 @@ func (s *BrandBriefService) discoverObjectName(ctx context.Context, imageURL, co
 
 	return "", fmt.Errorf("no matching object found for campaign %d", campaignIndex)
 }
+
+// briefSector looks up the brief's sector, tolerating older briefs whose free-text sector no longer resolves
+func (s *BrandBriefService) briefSector(ctx context.Context, brief *models.BrandBrief) *models.Sector {
+	key := brief.SectorID
+	if key == "" {
+		key = brief.Sector
+	}
+
+	sector, err := s.sectors.ResolveSector(ctx, key)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Sector %q not in the registry, using generic image styling: %v", key, err)
+		return nil
+	}
+	return sector
+}
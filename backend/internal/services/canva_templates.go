@@ -0,0 +1,430 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"log"
+	"strings"
+	"time"
+
+	"bezz-backend/internal/models"
+)
+
+// Canva template bundle layout
+const (
+	canvaTemplatesFolder = "templates"
+	canvaAssetsFolder    = "assets"
+	canvaTemplateFormat  = "canva-template"
+	canvaFallbackFont    = "Arial, Helvetica, sans-serif"
+	canvaPhotoQuality    = 88
+)
+
+// canvaLayerIDs names the SVG layers designers see in Canva's and Figma's layer panels
+var canvaLayerIDs = map[string]string{
+	"photo":    "photo",
+	"overlay":  "overlay",
+	"logo":     "logo",
+	"headline": "headline",
+	"button":   "cta-button",
+	"cta":      "cta-label",
+}
+
+// CanvaExportData describes one editable template in the bundle; paths are relative to the bundle root
+type CanvaExportData struct {
+	Name       string         `json:"name"`
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	DesignURL  string         `json:"designUrl"`  // layered SVG
+	PreviewURL string         `json:"previewUrl"` // flattened PNG
+	Elements   []CanvaElement `json:"elements"`   // bottom to top, matching the SVG layers
+	Metadata   ExportMetadata `json:"metadata"`
+}
+
+// CanvaElement is one layer of a template
+type CanvaElement struct {
+	ID      string      `json:"id"`   // the SVG element id
+	Type    string      `json:"type"` // "text", "image", "shape"
+	Content interface{} `json:"content"`
+	Style   interface{} `json:"style"`
+}
+
+// ExportMetadata records where an exported template came from
+type ExportMetadata struct {
+	CompanyName string    `json:"companyName"`
+	ExportDate  time.Time `json:"exportDate"`
+	Format      string    `json:"format"`
+}
+
+// canvaTextContent is a text layer's copy; lines are the line breaks used in the template
+type canvaTextContent struct {
+	Role  string   `json:"role"` // headline, cta
+	Text  string   `json:"text"`
+	Lines []string `json:"lines"`
+}
+
+// canvaImageContent points an image layer at its file in the bundle's assets folder
+type canvaImageContent struct {
+	Role string `json:"role"` // photo, logo
+	Src  string `json:"src"`
+	Fit  string `json:"fit"` // cover, contain
+}
+
+// canvaShapeContent is a filled rectangle such as a text band or button
+type canvaShapeContent struct {
+	Role  string `json:"role"` // overlay, button
+	Shape string `json:"shape"`
+}
+
+// canvaStyle places an element in template pixels
+type canvaStyle struct {
+	X          int     `json:"x"`
+	Y          int     `json:"y"`
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+	Fill       string  `json:"fill,omitempty"`
+	Opacity    float64 `json:"opacity,omitempty"`
+	FontFamily string  `json:"fontFamily,omitempty"`
+	FontSize   float64 `json:"fontSize,omitempty"`
+	FontWeight int     `json:"fontWeight,omitempty"`
+	LineHeight int     `json:"lineHeight,omitempty"`
+}
+
+// canvaBrand is what every template of a brief shares: palette, logo and fonts
+type canvaBrand struct {
+	company     string
+	palette     []models.Color
+	logo        image.Image
+	logoURI     string // PNG data URI embedded in each SVG
+	headingFont string // CSS font stacks
+	bodyFont    string
+	exported    time.Time
+}
+
+// canvaPhoto is an ad's base image, encoded once and shared by its template sizes
+type canvaPhoto struct {
+	img  image.Image
+	src  string // bundle path
+	data []byte // JPEG
+	uri  string
+}
+
+// canvaTemplate is one rendered template: the layered SVG, a flattened preview and its element description
+type canvaTemplate struct {
+	svg     []byte
+	preview []byte
+	data    CanvaExportData
+}
+
+// newCanvaBrand collects the brand's palette and fonts and encodes the logo for embedding
+func newCanvaBrand(brief *models.BrandBrief, logo image.Image) (*canvaBrand, []byte, error) {
+	brand := &canvaBrand{
+		company:     brief.CompanyName,
+		logo:        logo,
+		headingFont: canvaFallbackFont,
+		bodyFont:    canvaFallbackFont,
+		exported:    time.Now(),
+	}
+	if identity := brief.Results.BrandIdentity; identity != nil {
+		brand.palette = identity.ColorPalette
+		for _, f := range buildDesignTokens(brief.CompanyName, identity).fonts {
+			stack := cssFontStack(f.stack)
+			if len(f.stack) == 1 {
+				stack += ", " + canvaFallbackFont
+			}
+			if f.key == "heading" {
+				brand.headingFont = stack
+			} else {
+				brand.bodyFont = stack
+			}
+		}
+	}
+
+	var logoPNG []byte
+	if logo != nil {
+		data, err := encodePNG(logo)
+		if err != nil {
+			return nil, nil, err
+		}
+		logoPNG = data
+		brand.logoURI = "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
+	}
+	return brand, logoPNG, nil
+}
+
+// newCanvaPhoto encodes an ad image as JPEG for the bundle's assets folder and the SVGs that embed it
+func newCanvaPhoto(img image.Image, number int) (*canvaPhoto, error) {
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, rgba, &jpeg.Options{Quality: canvaPhotoQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return &canvaPhoto{
+		img:  img,
+		src:  fmt.Sprintf("%s/ad-%d.jpg", canvaAssetsFolder, number),
+		data: buf.Bytes(),
+		uri:  "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// renderCanvaTemplate composes one ad at one size and rebuilds the drawing steps as named, editable layers
+func renderCanvaTemplate(brand *canvaBrand, photo *canvaPhoto, adCopy models.AdCopy, name, layout string, size CreativeSize) (*canvaTemplate, error) {
+	img, layers, err := composeCreativeLayers(CreativeInput{
+		Base:    photo.img,
+		Logo:    brand.logo,
+		Copy:    adCopy,
+		Palette: brand.palette,
+		Layout:  layout,
+		Size:    size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	preview, err := encodePNG(img)
+	if err != nil {
+		return nil, err
+	}
+
+	folder := canvaTemplatesFolder + "/" + size.String()
+	data := CanvaExportData{
+		Name:       name,
+		Width:      size.Width,
+		Height:     size.Height,
+		DesignURL:  folder + "/" + name + ".svg",
+		PreviewURL: folder + "/" + name + ".png",
+		Metadata:   ExportMetadata{CompanyName: brand.company, ExportDate: brand.exported, Format: canvaTemplateFormat},
+	}
+
+	var svg bytes.Buffer
+	fmt.Fprintf(&svg, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d">
+<title>%s</title>
+`, size.Width, size.Height, size.Width, size.Height, svgEscape(brand.company+" - "+name))
+
+	used := map[string]int{}
+	for _, layer := range layers {
+		id := canvaLayerIDs[layer.role]
+		if used[id]++; used[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, used[id])
+		}
+		r := layer.rect
+		style := canvaStyle{X: r.Min.X, Y: r.Min.Y, Width: r.Dx(), Height: r.Dy()}
+
+		switch layer.kind {
+		case "photo", "logo":
+			src, uri, fit, aspect := photo.src, photo.uri, "cover", "xMidYMid slice"
+			if layer.kind == "logo" {
+				src, uri, fit, aspect = canvaAssetsFolder+"/logo.png", brand.logoURI, "contain", "xMidYMid meet"
+			}
+			fmt.Fprintf(&svg, `<image id="%s" x="%d" y="%d" width="%d" height="%d" preserveAspectRatio="%s" xlink:href="%s"/>
+`, id, r.Min.X, r.Min.Y, r.Dx(), r.Dy(), aspect, uri)
+			data.Elements = append(data.Elements, CanvaElement{ID: id, Type: "image", Content: canvaImageContent{Role: layer.role, Src: src, Fit: fit}, Style: style})
+
+		case "shape":
+			style.Fill, style.Opacity = canvaHex(layer.fill), canvaOpacity(layer.fill)
+			fmt.Fprintf(&svg, `<rect id="%s" x="%d" y="%d" width="%d" height="%d" fill="%s"`, id, r.Min.X, r.Min.Y, r.Dx(), r.Dy(), style.Fill)
+			if style.Opacity < 1 {
+				fmt.Fprintf(&svg, ` fill-opacity="%s"`, trimFloat(style.Opacity))
+			}
+			svg.WriteString("/>\n")
+			data.Elements = append(data.Elements, CanvaElement{ID: id, Type: "shape", Content: canvaShapeContent{Role: layer.role, Shape: "rectangle"}, Style: style})
+
+		case "text":
+			text, family := adCopy.Headline, brand.headingFont
+			if layer.role == "cta" {
+				text, family = adCopy.CTA, brand.bodyFont
+			}
+			style.Fill, style.FontFamily, style.FontSize, style.LineHeight = canvaHex(layer.fill), family, layer.fontSize, layer.lineHeight
+			style.FontWeight = 400
+			if layer.bold {
+				style.FontWeight = 700
+			}
+			fmt.Fprintf(&svg, `<text id="%s" font-family="%s" font-size="%s" font-weight="%d" fill="%s">`,
+				id, svgEscape(family), trimFloat(layer.fontSize), style.FontWeight, style.Fill)
+			for i, line := range layer.lines {
+				fmt.Fprintf(&svg, `<tspan x="%d" y="%d">%s</tspan>`, r.Min.X, layer.baseline+i*layer.lineHeight, svgEscape(line))
+			}
+			svg.WriteString("</text>\n")
+			data.Elements = append(data.Elements, CanvaElement{ID: id, Type: "text", Content: canvaTextContent{Role: layer.role, Text: text, Lines: layer.lines}, Style: style})
+		}
+	}
+	svg.WriteString("</svg>\n")
+
+	return &canvaTemplate{svg: svg.Bytes(), preview: preview, data: data}, nil
+}
+
+// canvaHex formats a layer colour as #RRGGBB, dropping its opacity
+func canvaHex(c color.NRGBA) string {
+	return hexString(color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff})
+}
+
+// canvaOpacity is a layer colour's opacity between 0 and 1, to two decimals
+func canvaOpacity(c color.NRGBA) float64 {
+	return float64(int(float64(c.A)/0xff*100+0.5)) / 100
+}
+
+// svgEscape escapes text for SVG element content and attribute values
+func svgEscape(s string) string {
+	var buf strings.Builder
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// GenerateCanvaExport builds a ZIP of editable templates for Canva and Figma: a layered SVG, PNG preview and
+// element JSON for every social size of each ad, plus the source images, logo and brand tokens
+func (s *ExportService) GenerateCanvaExport(ctx context.Context, briefID string, userID string) ([]byte, string, string, error) {
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch brief: %w", err)
+	}
+	if brief.Results == nil {
+		return nil, "", "", fmt.Errorf("brief has no results to export")
+	}
+
+	images := s.loadExportImages(ctx, brief)
+	data, err := buildCanvaBundle(brief, images)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	filename := fmt.Sprintf("%s-canva-templates-%s.zip", strings.ReplaceAll(brief.CompanyName, " ", "-"), time.Now().Format("2006-01-02"))
+	log.Printf("✅ EXPORT: Canva template bundle created for %s", brief.CompanyName)
+	return data, "application/zip", filename, nil
+}
+
+// buildCanvaBundle writes the template bundle ZIP from the brief and its downloaded images
+func buildCanvaBundle(brief *models.BrandBrief, images exportImages) ([]byte, error) {
+	brand, logoPNG, err := newCanvaBrand(brief, images.logo)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	write := func(name string, data []byte) error {
+		file, err := zipWriter.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(data)
+		return err
+	}
+
+	var templates []CanvaExportData
+	for i, ad := range brief.Results.Ads {
+		if images.ads[i] == nil {
+			continue
+		}
+		placement, ok := adPlacements[ad.Placement]
+		if !ok {
+			placement = adPlacements[defaultPlacementIDs[0]]
+		}
+		if placement.Format == "print" {
+			continue
+		}
+
+		photo, err := newCanvaPhoto(images.ads[i], i+1)
+		if err != nil {
+			log.Printf("⚠️ EXPORT: Skipping Canva templates for ad %s: %v", ad.ID, err)
+			continue
+		}
+		for _, size := range placement.creativeSizes {
+			name := fmt.Sprintf("Ad-%d-%s", i+1, size)
+			template, err := renderCanvaTemplate(brand, photo, ad.Copy, name, creativeLayoutFor(placement, size), size)
+			if err != nil {
+				log.Printf("⚠️ EXPORT: Failed to render %s template for ad %s: %v", size, ad.ID, err)
+				continue
+			}
+			description, err := json.MarshalIndent(template.data, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			base := strings.TrimSuffix(template.data.DesignURL, ".svg")
+			for _, f := range []struct {
+				name string
+				data []byte
+			}{{template.data.DesignURL, template.svg}, {template.data.PreviewURL, template.preview}, {base + ".json", description}} {
+				if err := write(f.name, f.data); err != nil {
+					return nil, err
+				}
+			}
+			templates = append(templates, template.data)
+		}
+		if err := write(photo.src, photo.data); err != nil {
+			return nil, err
+		}
+	}
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("brief has no ad images to build templates from")
+	}
+
+	if logoPNG != nil {
+		if err := write(canvaAssetsFolder+"/logo.png", logoPNG); err != nil {
+			return nil, err
+		}
+	}
+	if identity := brief.Results.BrandIdentity; identity != nil {
+		tokens, _, filename, err := RenderDesignTokens(brief.CompanyName, identity, "json")
+		if err != nil {
+			return nil, err
+		}
+		if err := write(filename, tokens); err != nil {
+			return nil, err
+		}
+	}
+	if err := write("README.txt", []byte(canvaReadme(brand, templates))); err != nil {
+		return nil, err
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize ZIP: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// canvaReadme lists the templates and explains how to open them in Canva and Figma
+func canvaReadme(brand *canvaBrand, templates []CanvaExportData) string {
+	readme := fmt.Sprintf(`%s - EDITABLE AD TEMPLATES
+Generated: %s
+
+TEMPLATES:
+`, strings.ToUpper(brand.company), brand.exported.Format("January 2, 2006"))
+	for _, template := range templates {
+		readme += fmt.Sprintf("• %s (%dx%d)\n", template.DesignURL, template.Width, template.Height)
+	}
+
+	readme += fmt.Sprintf(`
+Each template comes as:
+• .svg - layered design: photo, overlay, logo, headline, cta-button and cta-label
+• .png - flattened preview of the finished creative
+• .json - the same layers with positions, colours and fonts, for scripts and plugins
+
+%s/ holds the original ad photos and the logo; tokens.json holds the brand colours and fonts
+as W3C design tokens (Tokens Studio for Figma imports it directly).
+
+OPEN IN CANVA:
+1. Create a design with custom dimensions matching the template size
+2. Uploads > Upload files, choose the .svg and the files in %s/
+3. Canva keeps the SVG's colours editable; for live text, add text boxes where the .json places
+   the headline and CTA, or open the template in Figma first and import it into Canva from there
+
+OPEN IN FIGMA:
+1. Drag the .svg onto the canvas, or use File > Place image
+2. Each layer keeps its name; text stays editable and images can be swapped with Fill > Image
+
+NOTE: Line breaks were set with a reference font. Install the brand fonts (heading: %s;
+body: %s) and re-check the text wrapping after editing.
+`, canvaAssetsFolder, canvaAssetsFolder, brand.headingFont, brand.bodyFont)
+	return readme
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+// svgLayerIDs parses an SVG, failing on malformed XML, and returns its element ids in document order
+func svgLayerIDs(t *testing.T, svg []byte) []string {
+	var ids []string
+	decoder := xml.NewDecoder(bytes.NewReader(svg))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return ids
+		}
+		require.NoError(t, err)
+		if start, ok := token.(xml.StartElement); ok {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "id" {
+					ids = append(ids, attr.Value)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderCanvaTemplate_BuildsEditableLayers(t *testing.T) {
+	brief := sampleBrandBookBrief()
+	brand, logoPNG, err := newCanvaBrand(brief, solidImage(40, 20, color.NRGBA{R: 0x3b, A: 0xff}))
+	require.NoError(t, err)
+	require.NotEmpty(t, logoPNG)
+	photo, err := newCanvaPhoto(solidImage(64, 48, color.NRGBA{G: 0x80, A: 0xff}), 1)
+	require.NoError(t, err)
+
+	adCopy := models.AdCopy{Headline: "Brew better <together> & faster", CTA: "Shop Now"}
+	template, err := renderCanvaTemplate(brand, photo, adCopy, "Ad-1-1080x1080", "bottom_band", CreativeSize{Width: 1080, Height: 1080})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"photo", "logo", "overlay", "headline", "cta-button", "cta-label"}, svgLayerIDs(t, template.svg))
+	svg := string(template.svg)
+	assert.Contains(t, svg, `viewBox="0 0 1080 1080"`)
+	assert.Contains(t, svg, "Brew better &lt;together&gt; &amp;</tspan>")
+	assert.Contains(t, svg, `font-family="&#34;Playfair Display&#34;, Arial, Helvetica, sans-serif"`)
+	assert.Contains(t, svg, `fill="#3B2416" fill-opacity="0.88"`, "the band uses the brand's primary colour")
+	assert.Contains(t, svg, "data:image/jpeg;base64,")
+
+	preview, _, err := image.Decode(bytes.NewReader(template.preview))
+	require.NoError(t, err)
+	assert.Equal(t, 1080, preview.Bounds().Dx())
+
+	data := template.data
+	assert.Equal(t, "templates/1080x1080/Ad-1-1080x1080.svg", data.DesignURL)
+	assert.Equal(t, canvaTemplateFormat, data.Metadata.Format)
+	require.Len(t, data.Elements, 6)
+	assert.Equal(t, "image", data.Elements[0].Type)
+	assert.Equal(t, canvaImageContent{Role: "photo", Src: "assets/ad-1.jpg", Fit: "cover"}, data.Elements[0].Content)
+	assert.Equal(t, "shape", data.Elements[2].Type)
+
+	headline := data.Elements[3]
+	assert.Equal(t, "text", headline.Type)
+	assert.Equal(t, adCopy.Headline, headline.Content.(canvaTextContent).Text)
+	style := headline.Style.(canvaStyle)
+	assert.Equal(t, `"Playfair Display", Arial, Helvetica, sans-serif`, style.FontFamily)
+	assert.Equal(t, 700, style.FontWeight)
+	assert.True(t, strings.HasPrefix(data.Elements[5].Style.(canvaStyle).FontFamily, `"Inter"`), "the CTA uses the body font")
+}
+
+func TestBuildCanvaBundle_WritesTemplatesPerSocialSize(t *testing.T) {
+	brief := sampleBrandBookBrief()
+	brief.Results.Ads = []models.AdCampaign{
+		{ID: "ad_1", Placement: "instagram_feed", Copy: models.AdCopy{Headline: "Brew better", CTA: "Shop Now"}},
+		{ID: "ad_2", Placement: "print", Copy: models.AdCopy{Headline: "Not social"}},
+		{ID: "ad_3", Placement: "linkedin_sponsored", Copy: models.AdCopy{Headline: "No image"}},
+	}
+	photo := solidImage(64, 64, color.NRGBA{B: 0x80, A: 0xff})
+	data, err := buildCanvaBundle(brief, exportImages{
+		logo: solidImage(40, 20, color.NRGBA{R: 0x3b, A: 0xff}),
+		ads:  []image.Image{photo, photo, nil},
+	})
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := map[string]*zip.File{}
+	for _, f := range reader.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{
+		"templates/1080x1080/Ad-1-1080x1080.svg", "templates/1080x1080/Ad-1-1080x1080.png", "templates/1080x1080/Ad-1-1080x1080.json",
+		"templates/1080x1350/Ad-1-1080x1350.svg", "assets/ad-1.jpg", "assets/logo.png", "tokens.json", "README.txt",
+	} {
+		assert.Contains(t, files, name)
+	}
+	assert.Len(t, files, 10, "print ads and ads without images get no templates")
+
+	rc, err := files["templates/1080x1350/Ad-1-1080x1350.json"].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+	var template struct {
+		Width    int `json:"width"`
+		Elements []struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"elements"`
+	}
+	require.NoError(t, json.NewDecoder(rc).Decode(&template))
+	assert.Equal(t, 1080, template.Width)
+	assert.Equal(t, "photo", template.Elements[0].ID)
+
+	readme, err := files["README.txt"].Open()
+	require.NoError(t, err)
+	defer readme.Close()
+	text, _ := io.ReadAll(readme)
+	assert.Contains(t, string(text), "ACME COFFEE - EDITABLE AD TEMPLATES")
+}
+
+func TestBuildCanvaBundle_NoAdImages(t *testing.T) {
+	brief := sampleBrandBookBrief()
+	_, err := buildCanvaBundle(brief, exportImages{ads: make([]image.Image, len(brief.Results.Ads))})
+	assert.Error(t, err)
+}
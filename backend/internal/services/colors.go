@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// parseHexColor parses #RGB or #RRGGBB (the leading # is optional)
+func parseHexColor(hex string) (color.RGBA, error) {
+	h := strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if len(h) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid hex color %q", hex)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid hex color %q", hex)
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
+// hexString formats a color as #RRGGBB
+func hexString(c color.RGBA) string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
+
+// relativeLuminance computes the WCAG relative luminance of an sRGB color
+func relativeLuminance(c color.RGBA) float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// readableTextColor picks near-white or near-black text, whichever reads better on bg
+func readableTextColor(bg color.RGBA) color.RGBA {
+	if relativeLuminance(bg) > 0.179 {
+		return color.RGBA{R: 0x11, G: 0x11, B: 0x11, A: 0xff}
+	}
+	return color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+}
+
+// paletteColor returns the first valid palette color with the given usage, or fallback
+func paletteColor(palette []bezzmodels.Color, usage string, fallback color.RGBA) color.RGBA {
+	for _, c := range palette {
+		if !strings.EqualFold(c.Usage, usage) {
+			continue
+		}
+		if parsed, err := parseHexColor(c.Hex); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+// contrastRatio computes the WCAG contrast ratio between two colors, from 1 to 21
+func contrastRatio(a, b color.RGBA) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// rgbToHSL converts a color to hue in degrees [0, 360) and saturation and lightness in [0, 1]
+func rgbToHSL(c color.RGBA) (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	maxC, minC := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (maxC + minC) / 2
+	if maxC == minC {
+		return 0, 0, l
+	}
+
+	d := maxC - minC
+	if l > 0.5 {
+		s = d / (2 - maxC - minC)
+	} else {
+		s = d / (maxC + minC)
+	}
+
+	switch maxC {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+// hslToRGB converts hue in degrees and saturation and lightness in [0, 1] to a color
+func hslToRGB(h, s, l float64) color.RGBA {
+	h = math.Mod(math.Mod(h, 360)+360, 360)
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.RGBA{R: clampByte((r + m) * 255), G: clampByte((g + m) * 255), B: clampByte((b + m) * 255), A: 0xff}
+}
+
+// rgbToCMYK converts a color to naive CMYK fractions in [0, 1]
+func rgbToCMYK(c color.RGBA) (cy, m, y, k float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	k = 1 - math.Max(r, math.Max(g, b))
+	if k == 1 {
+		return 0, 0, 0, 1
+	}
+	return (1 - r - k) / (1 - k), (1 - g - k) / (1 - k), (1 - b - k) / (1 - k), k
+}
+
+// mixColors blends a toward b by t in [0, 1]
+func mixColors(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return clampByte(float64(x) + (float64(y)-float64(x))*t)
+	}
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 0xff}
+}
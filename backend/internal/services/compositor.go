@@ -0,0 +1,572 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // register JPEG decoding for uploaded and generated images
+	"image/png"
+	"log"
+	"strings"
+	"sync"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// CreativeSize is a finished creative's pixel size
+type CreativeSize struct {
+	Width  int
+	Height int
+}
+
+// String formats the size as WIDTHxHEIGHT
+func (s CreativeSize) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// cleanCreativeLayout marks creatives that are the cropped image alone, without copy or logo
+const cleanCreativeLayout = "clean"
+
+// creativeLayouts maps layout template IDs to their renderers
+var creativeLayouts = map[string]func(c *creativeCanvas){
+	"bottom_band":  layoutBottomBand,
+	"top_headline": layoutTopHeadline,
+	"side_panel":   layoutSidePanel,
+}
+
+// CreativeInput holds everything the compositor needs to render one creative
+type CreativeInput struct {
+	Base    image.Image
+	Logo    image.Image // optional
+	Copy    bezzmodels.AdCopy
+	Palette []bezzmodels.Color
+	Layout  string
+	Size    CreativeSize
+}
+
+// ComposeCreative renders the ad copy, CTA button and logo over the base image
+func ComposeCreative(in CreativeInput) (*image.RGBA, error) {
+	c, err := composeCreative(in, false)
+	if err != nil {
+		return nil, err
+	}
+	return c.img, nil
+}
+
+// composeCreativeLayers renders a creative and also returns the layers it drew, for editable templates
+func composeCreativeLayers(in CreativeInput) (*image.RGBA, []creativeLayer, error) {
+	c, err := composeCreative(in, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.img, c.layers, nil
+}
+
+func composeCreative(in CreativeInput, record bool) (*creativeCanvas, error) {
+	if in.Base == nil {
+		return nil, fmt.Errorf("base image is required")
+	}
+	if in.Size.Width <= 0 || in.Size.Height <= 0 {
+		return nil, fmt.Errorf("invalid creative size %s", in.Size)
+	}
+
+	layout, ok := creativeLayouts[in.Layout]
+	if !ok {
+		return nil, fmt.Errorf("unknown creative layout: %s", in.Layout)
+	}
+
+	fonts, err := loadCreativeFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	primary := paletteColor(in.Palette, "primary", color.RGBA{R: 0x1f, G: 0x29, B: 0x37, A: 0xff})
+	accent := paletteColor(in.Palette, "accent", paletteColor(in.Palette, "secondary", color.RGBA{R: 0xf5, G: 0x9e, B: 0x0b, A: 0xff}))
+
+	c := &creativeCanvas{
+		img:     image.NewRGBA(image.Rect(0, 0, in.Size.Width, in.Size.Height)),
+		input:   in,
+		fonts:   fonts,
+		primary: primary,
+		accent:  accent,
+		unit:    float64(min(in.Size.Width, in.Size.Height)),
+		record:  record,
+	}
+	if record {
+		c.faceSizes = map[font.Face]float64{}
+	}
+	defer c.closeFaces()
+	layout(c)
+	return c, nil
+}
+
+// encodePNG encodes img as PNG bytes
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ComposeCreatives renders finished creatives for every placement size of each ad and stores them next to the raw image
+func (s *AIService) ComposeCreatives(ctx context.Context, ads []bezzmodels.AdCampaign, identity *bezzmodels.BrandIdentity) int {
+	var palette []bezzmodels.Color
+	var logo image.Image
+	if identity != nil {
+		palette = identity.ColorPalette
+		// Prefer the background-free variant so the logo sits cleanly on the image
+		logoObject := identity.LogoObjectName + ".png"
+		if variant, ok := logoVariant(identity, "transparent"); ok {
+			logoObject = logoVariantObject(variant)
+		}
+		if identity.LogoObjectName != "" {
+			if img, err := s.readImageFromGCS(ctx, logoObject); err != nil {
+				log.Printf("⚠️ AI PIPELINE: Could not load logo for creatives, continuing without it: %v", err)
+			} else {
+				logo = img
+			}
+		}
+	}
+
+	composed := 0
+	for i := range ads {
+		ad := &ads[i]
+		if ad.ObjectName == "" {
+			log.Printf("⚠️ AI PIPELINE: Ad %s has no stored image, skipping creatives", ad.ID)
+			continue
+		}
+
+		base, err := s.readImageFromGCS(ctx, ad.ObjectName+".png")
+		if err != nil {
+			log.Printf("⚠️ AI PIPELINE: Could not load image for ad %s: %v", ad.ID, err)
+			continue
+		}
+
+		placement := adPlacements[ad.Placement]
+		if placement.ID == "" {
+			placement = adPlacements[defaultPlacementIDs[0]]
+		}
+
+		store := func(img image.Image, size CreativeSize, layout, objectName string) {
+			data, err := encodePNG(img)
+			if err != nil {
+				log.Printf("⚠️ AI PIPELINE: %v", err)
+				return
+			}
+
+			signedURL, err := s.uploadImageBytesToGCS(ctx, data, objectName)
+			if err != nil {
+				log.Printf("⚠️ AI PIPELINE: Uploading %s creative for ad %s failed: %v", size, ad.ID, err)
+				return
+			}
+
+			ad.Creatives = append(ad.Creatives, bezzmodels.AdCreative{
+				Size:       size.String(),
+				Width:      size.Width,
+				Height:     size.Height,
+				Layout:     layout,
+				ImageURL:   signedURL,
+				ObjectName: objectName,
+			})
+			composed++
+		}
+
+		for _, size := range placement.creativeSizes {
+			layout := creativeLayoutFor(placement, size)
+			img, err := ComposeCreative(CreativeInput{
+				Base:    base,
+				Logo:    logo,
+				Copy:    ad.Copy,
+				Palette: palette,
+				Layout:  layout,
+				Size:    size,
+			})
+			if err != nil {
+				log.Printf("⚠️ AI PIPELINE: Compositing %s creative for ad %s failed: %v", size, ad.ID, err)
+				continue
+			}
+			store(img, size, layout, fmt.Sprintf("%s_creative_%s", ad.ObjectName, size))
+		}
+
+		// Networks such as Google responsive display ads add their own text, so they get the image alone
+		for _, size := range placement.cleanSizes {
+			img := image.NewRGBA(image.Rect(0, 0, size.Width, size.Height))
+			drawImageCover(img, img.Bounds(), base)
+			store(img, size, cleanCreativeLayout, fmt.Sprintf("%s_clean_%s", ad.ObjectName, size))
+		}
+	}
+
+	log.Printf("🧩 AI PIPELINE: Composed %d finished creatives for %d ads", composed, len(ads))
+	return composed
+}
+
+// creativeLayoutFor picks the placement's layout unless the size's shape does not suit it
+func creativeLayoutFor(placement AdPlacement, size CreativeSize) string {
+	ratio := float64(size.Width) / float64(size.Height)
+	switch {
+	case placement.layout == "side_panel" && ratio < 1.4:
+		return "bottom_band"
+	case placement.layout == "top_headline" && ratio > 1.4:
+		return "side_panel"
+	case placement.layout == "":
+		return "bottom_band"
+	}
+	return placement.layout
+}
+
+// creativeFonts holds the parsed typefaces used for headlines, buttons and captions
+type creativeFonts struct {
+	bold    *opentype.Font
+	regular *opentype.Font
+}
+
+var (
+	creativeFontsOnce   sync.Once
+	creativeFontsLoaded *creativeFonts
+	creativeFontsErr    error
+)
+
+// loadCreativeFonts parses the embedded Go fonts once
+func loadCreativeFonts() (*creativeFonts, error) {
+	creativeFontsOnce.Do(func() {
+		bold, err := opentype.Parse(gobold.TTF)
+		if err != nil {
+			creativeFontsErr = fmt.Errorf("failed to parse bold font: %w", err)
+			return
+		}
+		regular, err := opentype.Parse(goregular.TTF)
+		if err != nil {
+			creativeFontsErr = fmt.Errorf("failed to parse regular font: %w", err)
+			return
+		}
+		creativeFontsLoaded = &creativeFonts{bold: bold, regular: regular}
+	})
+	return creativeFontsLoaded, creativeFontsErr
+}
+
+// creativeCanvas is the drawing surface shared by the layout templates
+type creativeCanvas struct {
+	img     *image.RGBA
+	input   CreativeInput
+	fonts   *creativeFonts
+	primary color.RGBA
+	accent  color.RGBA
+	unit    float64     // shortest side, used to scale type and spacing
+	faces   []font.Face // faces handed out by face, closed once the render is done
+
+	record    bool                  // capture each drawing step as a layer
+	layers    []creativeLayer       // captured layers, bottom to top
+	faceSizes map[font.Face]float64 // pixel size of each face handed out, for captured text layers
+}
+
+// creativeLayer is one drawing step of a layout, kept so templates can rebuild the creative as editable layers
+type creativeLayer struct {
+	kind       string // photo, shape, logo, text
+	role       string // photo, overlay, button, logo, headline, cta
+	rect       image.Rectangle
+	fill       color.NRGBA
+	lines      []string // wrapped text, one entry per line
+	fontSize   float64
+	bold       bool
+	baseline   int // y of the first line's baseline
+	lineHeight int
+}
+
+// face returns a font face at a size relative to the canvas
+func (c *creativeCanvas) face(f *opentype.Font, scale float64) font.Face {
+	size := c.unit * scale
+	if size < 9 {
+		size = 9
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		// Parsed fonts always produce a face; fall back to a fixed size if the options were rejected
+		size = 12
+		face, _ = opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72})
+	}
+	if c.record {
+		c.faceSizes[face] = size
+	}
+	c.faces = append(c.faces, face)
+	return face
+}
+
+// closeFaces releases the faces created for this render; faces aren't safe for concurrent use, so each
+// render gets its own rather than sharing a cache
+func (c *creativeCanvas) closeFaces() {
+	for _, face := range c.faces {
+		face.Close()
+	}
+	c.faces = nil
+}
+
+// drawCover scales the base image to cover r, cropping from the centre
+func (c *creativeCanvas) drawCover(r image.Rectangle) {
+	drawImageCover(c.img, r, c.input.Base)
+	if c.record {
+		c.layers = append(c.layers, creativeLayer{kind: "photo", role: "photo", rect: r})
+	}
+}
+
+// drawImageCover scales src to cover r in dst, cropping from the centre
+func drawImageCover(dst draw.Image, r image.Rectangle, src image.Image) {
+	crop := centreCrop(src.Bounds(), float64(r.Dx())/float64(r.Dy()))
+	xdraw.CatmullRom.Scale(dst, r, src, crop, draw.Src, nil)
+}
+
+// centreCrop returns the largest rectangle of the given width/height ratio centred in b
+func centreCrop(b image.Rectangle, ratio float64) image.Rectangle {
+	if float64(b.Dx())/float64(b.Dy()) > ratio {
+		w := int(float64(b.Dy()) * ratio)
+		x0 := b.Min.X + (b.Dx()-w)/2
+		return image.Rect(x0, b.Min.Y, x0+w, b.Max.Y)
+	}
+	h := int(float64(b.Dx()) / ratio)
+	y0 := b.Min.Y + (b.Dy()-h)/2
+	return image.Rect(b.Min.X, y0, b.Max.X, y0+h)
+}
+
+// cropToAspectRatio centre-crops an encoded image to a "W:H" ratio such as "1.91:1", returning it as PNG.
+// The image models only generate square, 2:3 and 3:2 images, so most placements need a crop.
+func cropToAspectRatio(data []byte, aspectRatio string) ([]byte, error) {
+	ratio, err := parseAspectRatio(aspectRatio)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	crop := centreCrop(img.Bounds(), ratio)
+	cropped := image.NewNRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, crop.Min, draw.Src)
+	return encodePNG(cropped)
+}
+
+// imageAspectRatio reports an encoded image's real "W:H" ratio, or "" if it cannot be decoded
+func imageAspectRatio(data []byte) string {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		return ""
+	}
+	a, b := cfg.Width, cfg.Height
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return fmt.Sprintf("%d:%d", cfg.Width/a, cfg.Height/a)
+}
+
+// parseAspectRatio turns "W:H" into width divided by height
+func parseAspectRatio(aspectRatio string) (float64, error) {
+	var w, h float64
+	if _, err := fmt.Sscanf(aspectRatio, "%g:%g", &w, &h); err != nil || w <= 0 || h <= 0 {
+		return 0, fmt.Errorf("invalid aspect ratio %q", aspectRatio)
+	}
+	return w / h, nil
+}
+
+// fill paints r with col, blending when col is translucent
+func (c *creativeCanvas) fill(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+	if c.record {
+		c.layers = append(c.layers, creativeLayer{kind: "shape", role: "overlay", rect: r, fill: color.NRGBAModel.Convert(col).(color.NRGBA)})
+	}
+}
+
+// drawLogo fits the logo inside box, preserving its aspect ratio
+func (c *creativeCanvas) drawLogo(box image.Rectangle) {
+	logo := c.input.Logo
+	if logo == nil {
+		return
+	}
+	lb := logo.Bounds()
+	scale := minFloat(float64(box.Dx())/float64(lb.Dx()), float64(box.Dy())/float64(lb.Dy()))
+	w, h := int(float64(lb.Dx())*scale), int(float64(lb.Dy())*scale)
+	dst := image.Rect(box.Min.X, box.Min.Y, box.Min.X+w, box.Min.Y+h)
+	xdraw.CatmullRom.Scale(c.img, dst, logo, lb, draw.Over, nil)
+	if c.record {
+		c.layers = append(c.layers, creativeLayer{kind: "logo", role: "logo", rect: dst})
+	}
+}
+
+// drawText wraps text into r and returns the y coordinate below the last line drawn
+func (c *creativeCanvas) drawText(text string, r image.Rectangle, face font.Face, col color.Color, maxLines int) int {
+	metrics := face.Metrics()
+	lineHeight := (metrics.Ascent + metrics.Descent).Ceil() * 115 / 100
+	lines := wrapText(text, face, r.Dx(), maxLines)
+
+	y := r.Min.Y + metrics.Ascent.Ceil()
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: face}
+	drawn := 0
+	for _, line := range lines {
+		if y > r.Max.Y {
+			break
+		}
+		d.Dot = fixed.P(r.Min.X, y)
+		d.DrawString(line)
+		y += lineHeight
+		drawn++
+	}
+	if c.record && drawn > 0 {
+		c.layers = append(c.layers, creativeLayer{
+			kind: "text", role: "headline", rect: r, fill: color.NRGBAModel.Convert(col).(color.NRGBA),
+			lines: lines[:drawn], fontSize: c.faceSizes[face], bold: true,
+			baseline: r.Min.Y + metrics.Ascent.Ceil(), lineHeight: lineHeight,
+		})
+	}
+	return y - metrics.Ascent.Ceil()
+}
+
+// buttonSize measures a CTA button without drawing it
+func (c *creativeCanvas) buttonSize(label string, scale float64) image.Point {
+	face := c.face(c.fonts.bold, scale)
+	metrics := face.Metrics()
+	padX := int(c.unit * scale * 0.9)
+	padY := int(c.unit * scale * 0.5)
+	return image.Point{
+		X: font.MeasureString(face, label).Ceil() + 2*padX,
+		Y: (metrics.Ascent + metrics.Descent).Ceil() + 2*padY,
+	}
+}
+
+// drawButton draws a filled CTA button with its top-left corner at pt
+func (c *creativeCanvas) drawButton(label string, pt image.Point, scale float64) {
+	if label == "" {
+		return
+	}
+	face := c.face(c.fonts.bold, scale)
+	size := c.buttonSize(label, scale)
+
+	r := image.Rectangle{Min: pt, Max: pt.Add(size)}
+	c.fill(r, c.accent)
+
+	textColor := readableTextColor(c.accent)
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(textColor), Face: face}
+	padX := int(c.unit * scale * 0.9)
+	d.Dot = fixed.P(r.Min.X+padX, r.Min.Y+int(c.unit*scale*0.5)+face.Metrics().Ascent.Ceil())
+	d.DrawString(label)
+
+	if c.record {
+		c.layers[len(c.layers)-1].role = "button" // the fill above
+		c.layers = append(c.layers, creativeLayer{
+			kind: "text", role: "cta", rect: image.Rect(r.Min.X+padX, r.Min.Y, r.Max.X-padX, r.Max.Y), fill: color.NRGBAModel.Convert(textColor).(color.NRGBA),
+			lines: []string{label}, fontSize: c.faceSizes[face], bold: true,
+			baseline: d.Dot.Y.Ceil(), lineHeight: size.Y,
+		})
+	}
+}
+
+// wrapText breaks text into lines no wider than width, ending with an ellipsis when it runs past maxLines
+func wrapText(text string, face font.Face, width int, maxLines int) []string {
+	words := strings.Fields(text)
+	var lines []string
+	current := ""
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if font.MeasureString(face, candidate).Ceil() <= width || current == "" {
+			current = candidate
+			continue
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := lines[maxLines-1]
+		for font.MeasureString(face, last+"…").Ceil() > width {
+			cut := strings.LastIndex(last, " ")
+			if cut <= 0 {
+				break
+			}
+			last = last[:cut]
+		}
+		lines[maxLines-1] = last + "…"
+	}
+	return lines
+}
+
+// withAlpha returns col with the given opacity
+func withAlpha(col color.RGBA, alpha uint8) color.NRGBA {
+	return color.NRGBA{R: col.R, G: col.G, B: col.B, A: alpha}
+}
+
+// minFloat returns the smaller of a and b
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// layoutBottomBand places the image full-bleed with a translucent brand band holding headline and CTA
+func layoutBottomBand(c *creativeCanvas) {
+	bounds := c.img.Bounds()
+	c.drawCover(bounds)
+
+	pad := int(c.unit * 0.05)
+	c.drawLogo(image.Rect(pad, pad, pad+int(c.unit*0.28), pad+int(c.unit*0.12)))
+
+	band := image.Rect(0, bounds.Dy()-int(float64(bounds.Dy())*0.32), bounds.Dx(), bounds.Dy())
+	c.fill(band, withAlpha(c.primary, 0xe0))
+
+	textColor := readableTextColor(c.primary)
+	inner := image.Rect(pad, band.Min.Y+pad, bounds.Dx()-pad, bounds.Dy()-pad)
+	y := c.drawText(c.input.Copy.Headline, inner, c.face(c.fonts.bold, 0.065), textColor, 2)
+	c.drawButton(c.input.Copy.CTA, image.Point{X: pad, Y: y + pad/2}, 0.038)
+}
+
+// layoutTopHeadline suits tall formats: headline at the top, CTA anchored near the bottom
+func layoutTopHeadline(c *creativeCanvas) {
+	bounds := c.img.Bounds()
+	c.drawCover(bounds)
+
+	pad := int(c.unit * 0.06)
+	header := image.Rect(0, 0, bounds.Dx(), int(float64(bounds.Dy())*0.26))
+	c.fill(header, withAlpha(c.primary, 0xe0))
+
+	c.drawLogo(image.Rect(pad, pad, pad+int(c.unit*0.3), pad+int(c.unit*0.1)))
+
+	textColor := readableTextColor(c.primary)
+	inner := image.Rect(pad, pad+int(c.unit*0.14), bounds.Dx()-pad, header.Max.Y-pad/2)
+	c.drawText(c.input.Copy.Headline, inner, c.face(c.fonts.bold, 0.075), textColor, 3)
+
+	button := c.buttonSize(c.input.Copy.CTA, 0.045)
+	c.drawButton(c.input.Copy.CTA, image.Point{X: (bounds.Dx() - button.X) / 2, Y: bounds.Dy() - int(float64(bounds.Dy())*0.12)}, 0.045)
+}
+
+// layoutSidePanel suits wide banners: image on the left, a solid brand panel with copy on the right
+func layoutSidePanel(c *creativeCanvas) {
+	bounds := c.img.Bounds()
+	split := int(float64(bounds.Dx()) * 0.58)
+	c.drawCover(image.Rect(0, 0, split, bounds.Dy()))
+
+	panel := image.Rect(split, 0, bounds.Dx(), bounds.Dy())
+	c.fill(panel, c.primary)
+
+	pad := int(c.unit * 0.07)
+	c.drawLogo(image.Rect(panel.Min.X+pad, pad, panel.Max.X-pad, pad+int(c.unit*0.16)))
+
+	textColor := readableTextColor(c.primary)
+	inner := image.Rect(panel.Min.X+pad, pad+int(c.unit*0.22), panel.Max.X-pad, bounds.Dy()-pad)
+	y := c.drawText(c.input.Copy.Headline, inner, c.face(c.fonts.bold, 0.09), textColor, 3)
+	c.drawButton(c.input.Copy.CTA, image.Point{X: panel.Min.X + pad, Y: y + pad/2}, 0.055)
+}
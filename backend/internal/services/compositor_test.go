@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/basicfont"
+
+	"bezz-backend/internal/models"
+)
+
+func TestComposeCreative_RendersEachLayoutAtRequestedSize(t *testing.T) {
+	base := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	logo := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	palette := []models.Color{{Hex: "#0A2540", Usage: "primary"}, {Hex: "#F5A623", Usage: "accent"}}
+	adCopy := models.AdCopy{Headline: "Fresh meals delivered daily", Body: "Chef-made dinners at your door.", CTA: "Order Now"}
+
+	sizes := []CreativeSize{{Width: 1080, Height: 1080}, {Width: 1080, Height: 1920}, {Width: 1200, Height: 627}}
+	for layout := range creativeLayouts {
+		for _, size := range sizes {
+			img, err := ComposeCreative(CreativeInput{Base: base, Logo: logo, Copy: adCopy, Palette: palette, Layout: layout, Size: size})
+			require.NoError(t, err, "%s %s", layout, size)
+			assert.Equal(t, size.Width, img.Bounds().Dx())
+			assert.Equal(t, size.Height, img.Bounds().Dy())
+		}
+	}
+}
+
+func TestComposeCreative_RejectsBadInput(t *testing.T) {
+	base := image.NewRGBA(image.Rect(0, 0, 8, 8))
+
+	_, err := ComposeCreative(CreativeInput{Base: base, Layout: "diagonal", Size: CreativeSize{Width: 100, Height: 100}})
+	assert.Error(t, err)
+
+	_, err = ComposeCreative(CreativeInput{Base: base, Layout: "bottom_band"})
+	assert.Error(t, err)
+
+	_, err = ComposeCreative(CreativeInput{Layout: "bottom_band", Size: CreativeSize{Width: 100, Height: 100}})
+	assert.Error(t, err)
+}
+
+func TestCreativeLayoutFor(t *testing.T) {
+	linkedin := adPlacements["linkedin_sponsored"]
+	assert.Equal(t, "side_panel", creativeLayoutFor(linkedin, CreativeSize{Width: 1200, Height: 627}))
+	assert.Equal(t, "bottom_band", creativeLayoutFor(linkedin, CreativeSize{Width: 1080, Height: 1080}))
+
+	story := adPlacements["instagram_story"]
+	assert.Equal(t, "top_headline", creativeLayoutFor(story, CreativeSize{Width: 1080, Height: 1920}))
+}
+
+func TestWrapText_TruncatesWithEllipsis(t *testing.T) {
+	face := basicfont.Face7x13
+	lines := wrapText("one two three four five six seven eight", face, 7*10, 2)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "one two", lines[0])
+	assert.Contains(t, lines[1], "…")
+}
+
+func TestParseHexColorAndContrast(t *testing.T) {
+	c, err := parseHexColor("#fff")
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, c)
+	assert.Equal(t, "#0A2540", hexString(color.RGBA{R: 0x0a, G: 0x25, B: 0x40, A: 0xff}))
+
+	_, err = parseHexColor("blue")
+	assert.Error(t, err)
+
+	dark := readableTextColor(color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff})
+	assert.Less(t, relativeLuminance(dark), 0.1)
+	light := readableTextColor(color.RGBA{R: 0x0a, G: 0x25, B: 0x40, A: 0xff})
+	assert.Equal(t, uint8(0xff), light.R)
+}
+
+func TestComposeCreativeLayers_RecordsEachDrawingStep(t *testing.T) {
+	in := CreativeInput{
+		Base: image.NewRGBA(image.Rect(0, 0, 64, 48)), Logo: image.NewRGBA(image.Rect(0, 0, 32, 16)),
+		Copy:    models.AdCopy{Headline: "Fresh meals delivered daily", CTA: "Order Now"},
+		Palette: []models.Color{{Hex: "#0A2540", Usage: "primary"}, {Hex: "#F5A623", Usage: "accent"}},
+		Layout:  "bottom_band", Size: CreativeSize{Width: 1080, Height: 1080},
+	}
+	img, layers, err := composeCreativeLayers(in)
+	require.NoError(t, err)
+	assert.Equal(t, 1080, img.Bounds().Dx())
+
+	var roles []string
+	for _, layer := range layers {
+		roles = append(roles, layer.role)
+	}
+	assert.Equal(t, []string{"photo", "logo", "overlay", "headline", "button", "cta"}, roles)
+
+	headline, cta := layers[3], layers[5]
+	assert.Equal(t, []string{"Fresh meals delivered daily"}, headline.lines)
+	assert.InDelta(t, 1080*0.065, headline.fontSize, 0.01)
+	assert.Greater(t, headline.baseline, layers[2].rect.Min.Y)
+	assert.True(t, cta.rect.In(layers[4].rect), "the CTA label sits inside its button")
+	assert.Equal(t, uint8(0xe0), layers[2].fill.A)
+}
+
+func TestComposeCreative_ReleasesFacesAfterRender(t *testing.T) {
+	in := CreativeInput{
+		Base:   image.NewRGBA(image.Rect(0, 0, 64, 48)),
+		Copy:   models.AdCopy{Headline: "Fresh meals delivered daily", CTA: "Order Now"},
+		Layout: "top_headline", Size: CreativeSize{Width: 1080, Height: 1350},
+	}
+	c, err := composeCreative(in, false)
+	require.NoError(t, err)
+	assert.Empty(t, c.faces, "every face created for the render is closed")
+}
+
+func TestCropToAspectRatio_MatchesEveryPlacement(t *testing.T) {
+	for id, placement := range adPlacements {
+		for _, size := range []string{placement.imageSize.gptImage1, placement.imageSize.dalle3} {
+			var w, h int
+			_, err := fmt.Sscanf(size, "%dx%d", &w, &h)
+			require.NoError(t, err)
+			raw, err := encodePNG(image.NewRGBA(image.Rect(0, 0, w, h)))
+			require.NoError(t, err)
+
+			cropped, err := cropToAspectRatio(raw, placement.AspectRatio)
+			require.NoError(t, err, "%s %s", id, size)
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(cropped))
+			require.NoError(t, err)
+
+			want, err := parseAspectRatio(placement.AspectRatio)
+			require.NoError(t, err)
+			assert.InDelta(t, want, float64(cfg.Width)/float64(cfg.Height), 0.01, "%s %s", id, size)
+			assert.True(t, cfg.Width == w || cfg.Height == h, "%s %s should only be cropped along one side", id, size)
+		}
+	}
+}
+
+func TestCropToAspectRatio_RejectsBadRatio(t *testing.T) {
+	raw, err := encodePNG(image.NewRGBA(image.Rect(0, 0, 8, 8)))
+	require.NoError(t, err)
+	_, err = cropToAspectRatio(raw, "wide")
+	assert.Error(t, err)
+	_, err = cropToAspectRatio(raw, "1:0")
+	assert.Error(t, err)
+}
+
+func TestImageAspectRatio(t *testing.T) {
+	raw, err := encodePNG(image.NewRGBA(image.Rect(0, 0, 1536, 1024)))
+	require.NoError(t, err)
+	assert.Equal(t, "3:2", imageAspectRatio(raw))
+	assert.Equal(t, "", imageAspectRatio([]byte("not an image")))
+}
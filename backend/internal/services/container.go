@@ -24,6 +24,8 @@ type Container struct {
 	AuthService       *AuthService
 	AIService         *AIService
 	UserService       *UserService
+	SectorService     *SectorService
+	AssetService      *AssetService
 	BrandBriefService *BrandBriefService
 	PaymentService    *PaymentService
 	ExportService     *ExportService
@@ -70,11 +72,13 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	// Create service instances
 	authService := NewAuthService(authClient, cfg.FirebaseAPIKey)
 	// Initialize AI Service
-	aiService := NewAIService(&openaiClient, storageClient, cfg.GCSBucketName)
+	aiService := NewAIService(&openaiClient, storageClient, cfg.GCSBucketName, cfg.FFmpegPath)
 	userService := NewUserService(firestoreClient)
-	brandBriefService := NewBrandBriefService(firestoreClient, aiService, storageClient, cfg.GCSBucketName)
+	sectorService := NewSectorService(firestoreClient)
+	assetService := NewAssetService(firestoreClient, aiService, storageClient, cfg.GCSBucketName)
+	brandBriefService := NewBrandBriefService(firestoreClient, aiService, sectorService, storageClient, cfg.GCSBucketName)
 	paymentService := NewPaymentService(cfg.StripeSecretKey, cfg.StripeWebhookSecret)
-	exportService := NewExportService(brandBriefService, firestoreClient)
+	exportService := NewExportService(brandBriefService, firestoreClient, storageClient, cfg.GCSBucketName)
 
 	container := &Container{
 		Config:            cfg,
@@ -85,6 +89,8 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		AuthService:       authService,
 		AIService:         aiService,
 		UserService:       userService,
+		SectorService:     sectorService,
+		AssetService:      assetService,
 		BrandBriefService: brandBriefService,
 		PaymentService:    paymentService,
 		ExportService:     exportService,
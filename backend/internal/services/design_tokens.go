@@ -0,0 +1,557 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+	"unicode/utf16"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// designTokenFormat is one machine-readable rendering of the brand's design tokens
+type designTokenFormat struct {
+	ID          string
+	Filename    string
+	ContentType string
+	render      func(*designTokens) ([]byte, error)
+}
+
+var designTokenFormats = []designTokenFormat{
+	{ID: "css", Filename: "tokens.css", ContentType: "text/css; charset=utf-8", render: renderTokensCSS},
+	{ID: "scss", Filename: "_tokens.scss", ContentType: "text/x-scss; charset=utf-8", render: renderTokensSCSS},
+	{ID: "tailwind", Filename: "tailwind.config.js", ContentType: "application/javascript; charset=utf-8", render: renderTokensTailwind},
+	{ID: "json", Filename: "tokens.json", ContentType: "application/json", render: renderTokensW3C},
+	{ID: "tokens-studio", Filename: "tokens-studio.json", ContentType: "application/json", render: renderTokensStudio},
+	{ID: "ase", Filename: "palette.ase", ContentType: "application/octet-stream", render: renderTokensASE},
+	{ID: "gpl", Filename: "palette.gpl", ContentType: "text/plain; charset=utf-8", render: renderTokensGPL},
+}
+
+// DesignTokenFormats lists the supported design token format ids
+func DesignTokenFormats() []string {
+	ids := make([]string, len(designTokenFormats))
+	for i, f := range designTokenFormats {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
+// ValidateDesignTokenFormat checks that a requested token format exists
+func ValidateDesignTokenFormat(format string) error {
+	if _, ok := designTokenFormatByID(format); !ok {
+		return fmt.Errorf("unknown design token format %q (supported: %s)", format, strings.Join(DesignTokenFormats(), ", "))
+	}
+	return nil
+}
+
+func designTokenFormatByID(id string) (designTokenFormat, bool) {
+	for _, f := range designTokenFormats {
+		if f.ID == id {
+			return f, true
+		}
+	}
+	return designTokenFormat{}, false
+}
+
+// designTokens is the format-neutral token set built from a brand identity
+type designTokens struct {
+	name      string
+	colors    []colorToken
+	fonts     []fontToken // heading, body
+	typeScale []bezzmodels.TypeScaleStep
+	spacing   []spacingToken
+}
+
+// colorToken is a palette color with its 100-900 ramp; 500 is the color itself
+type colorToken struct {
+	key, label, hex, usage, description string
+	rgb                                 color.RGBA
+	ramp                                []colorStep
+}
+
+type colorStep struct {
+	step string
+	hex  string
+	rgb  color.RGBA
+}
+
+type fontToken struct {
+	key    string // heading, body
+	family string
+	stack  []string // family then fallbacks
+	weight int      // default weight: the boldest for headings, the lightest for body text; 0 when unknown
+}
+
+type spacingToken struct {
+	key string
+	px  float64
+}
+
+// designTokenSpacing is a 4px-based spacing scale, keyed like Tailwind's
+var designTokenSpacing = []spacingToken{
+	{"0", 0}, {"1", 4}, {"2", 8}, {"3", 12}, {"4", 16}, {"5", 20}, {"6", 24},
+	{"8", 32}, {"10", 40}, {"12", 48}, {"16", 64}, {"20", 80}, {"24", 96},
+}
+
+// buildDesignTokens derives the token set from a brand identity
+func buildDesignTokens(companyName string, identity *bezzmodels.BrandIdentity) *designTokens {
+	tokens := &designTokens{name: companyName, spacing: designTokenSpacing}
+
+	palette, _ := AnalyzePalette(identity.ColorPalette)
+	used := map[string]bool{}
+	usageCount := map[string]int{}
+	for _, c := range palette {
+		usageCount[tokenSlug(c.Usage)]++
+	}
+	for i, c := range palette {
+		rgb, _ := parseHexColor(c.Hex)
+		key := tokenSlug(c.Usage)
+		if key == "" || usageCount[key] > 1 {
+			key = tokenSlug(c.Name)
+		}
+		if key == "" || used[key] {
+			key = fmt.Sprintf("color-%d", i+1)
+		}
+		used[key] = true
+
+		label := c.Name
+		if label == "" {
+			label = titleCase(key)
+		}
+		token := colorToken{key: key, label: label, hex: c.Hex, usage: c.Usage, description: c.Psychology, rgb: rgb}
+		// Tints run 400 (20% white) to 100 (80% white); shades run 600 to 900
+		for j := len(tintSteps) - 1; j >= 0; j-- {
+			token.ramp = append(token.ramp, rampStep(fmt.Sprintf("%d", 400-100*j), mixColors(rgb, paletteWhite, tintSteps[j])))
+		}
+		token.ramp = append(token.ramp, rampStep("500", rgb))
+		for j, t := range tintSteps {
+			token.ramp = append(token.ramp, rampStep(fmt.Sprintf("%d", 600+100*j), mixColors(rgb, paletteBlack, t)))
+		}
+		tokens.colors = append(tokens.colors, token)
+	}
+
+	if typography := identity.Typography; typography != nil {
+		for _, f := range []struct {
+			key  string
+			font bezzmodels.FontSelection
+		}{{"heading", typography.Heading}, {"body", typography.Body}} {
+			if f.font.Family == "" {
+				continue
+			}
+			stack := []string{f.font.Family}
+			for _, fallback := range strings.Split(f.font.Fallback, ",") {
+				if fallback = strings.Trim(strings.TrimSpace(fallback), `"'`); fallback != "" && fallback != f.font.Family {
+					stack = append(stack, fallback)
+				}
+			}
+			token := fontToken{key: f.key, family: f.font.Family, stack: stack}
+			if weights := f.font.Weights; len(weights) > 0 {
+				token.weight = weights[0]
+				if f.key == "heading" {
+					token.weight = weights[len(weights)-1]
+				}
+			}
+			tokens.fonts = append(tokens.fonts, token)
+		}
+		tokens.typeScale = typography.Scale
+	}
+
+	return tokens
+}
+
+// describe labels a color with its usage and psychology
+func (c colorToken) describe() string {
+	description := c.label
+	if c.usage != "" {
+		description += " (" + c.usage + ")"
+	}
+	if c.description != "" {
+		description += ": " + c.description
+	}
+	return description
+}
+
+func rampStep(step string, c color.RGBA) colorStep {
+	return colorStep{step: step, hex: hexString(c), rgb: c}
+}
+
+// tokenSlug lower-cases a name into a token key: letters and digits joined by hyphens
+func tokenSlug(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+	return b.String()
+}
+
+// fontForStep returns the font token key a type scale step uses
+func (t *designTokens) fontForStep(step bezzmodels.TypeScaleStep) string {
+	for _, f := range t.fonts {
+		if f.family == step.Family {
+			return f.key
+		}
+	}
+	return "body"
+}
+
+// cssFontStack quotes family names that need it
+func cssFontStack(stack []string) string {
+	quoted := make([]string, len(stack))
+	for i, family := range stack {
+		switch family {
+		case "serif", "sans-serif", "monospace", "cursive", "fantasy", "system-ui", "ui-sans-serif", "ui-serif", "ui-monospace":
+			quoted[i] = family
+		default:
+			quoted[i] = fmt.Sprintf("%q", family)
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func remString(px float64) string {
+	return trimFloat(px/typeScaleBasePx) + "rem"
+}
+
+// trimFloat formats v with up to three decimals and no trailing zeros
+func trimFloat(v float64) string {
+	s := fmt.Sprintf("%.3f", math.Round(v*1000)/1000)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func tokenHeader(t *designTokens, open, close string) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s design tokens. Generated by Bezz AI; regenerate rather than editing by hand. %s", open, strings.ReplaceAll(t.name, "*/", ""), close)) + "\n"
+}
+
+// cssVariables lists the tokens as name/value pairs shared by the CSS and SCSS renderings
+func (t *designTokens) cssVariables() [][2]string {
+	var vars [][2]string
+	for _, c := range t.colors {
+		vars = append(vars, [2]string{"color-" + c.key, c.hex})
+		for _, step := range c.ramp {
+			if step.step != "500" {
+				vars = append(vars, [2]string{fmt.Sprintf("color-%s-%s", c.key, step.step), step.hex})
+			}
+		}
+	}
+	for _, f := range t.fonts {
+		vars = append(vars, [2]string{"font-" + f.key, cssFontStack(f.stack)})
+		if f.weight > 0 {
+			vars = append(vars, [2]string{"font-weight-" + f.key, fmt.Sprintf("%d", f.weight)})
+		}
+	}
+	for _, step := range t.typeScale {
+		vars = append(vars,
+			[2]string{"font-size-" + step.Name, trimFloat(step.SizeRem) + "rem"},
+			[2]string{"line-height-" + step.Name, trimFloat(step.LineHeight)},
+			[2]string{"font-weight-" + step.Name, fmt.Sprintf("%d", step.Weight)})
+	}
+	for _, s := range t.spacing {
+		vars = append(vars, [2]string{"space-" + s.key, remString(s.px)})
+	}
+	return vars
+}
+
+func renderTokensCSS(t *designTokens) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString(tokenHeader(t, "/*", "*/"))
+	b.WriteString(":root {\n")
+	for _, v := range t.cssVariables() {
+		fmt.Fprintf(&b, "  --%s: %s;\n", v[0], v[1])
+	}
+	b.WriteString("}\n")
+	return []byte(b.String()), nil
+}
+
+func renderTokensSCSS(t *designTokens) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString(tokenHeader(t, "//", ""))
+	for _, v := range t.cssVariables() {
+		fmt.Fprintf(&b, "$%s: %s;\n", v[0], v[1])
+	}
+
+	b.WriteString("\n$colors: (\n")
+	for _, c := range t.colors {
+		fmt.Fprintf(&b, "  %q: $color-%s,\n", c.key, c.key)
+	}
+	b.WriteString(");\n\n$spacing: (\n")
+	for _, s := range t.spacing {
+		fmt.Fprintf(&b, "  %q: $space-%s,\n", s.key, s.key)
+	}
+	b.WriteString(");\n")
+	return []byte(b.String()), nil
+}
+
+// orderedObject marshals to a JSON object with keys in insertion order
+type orderedObject []orderedField
+
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func marshalTokens(v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func renderTokensTailwind(t *designTokens) ([]byte, error) {
+	var colors, families, sizes, spacing orderedObject
+	for _, c := range t.colors {
+		shades := orderedObject{{"DEFAULT", c.hex}}
+		for _, step := range c.ramp {
+			shades = append(shades, orderedField{step.step, step.hex})
+		}
+		colors = append(colors, orderedField{c.key, shades})
+	}
+	for _, f := range t.fonts {
+		families = append(families, orderedField{f.key, f.stack})
+	}
+	for _, step := range t.typeScale {
+		sizes = append(sizes, orderedField{step.Name, []interface{}{
+			trimFloat(step.SizeRem) + "rem",
+			orderedObject{{"lineHeight", trimFloat(step.LineHeight)}, {"fontWeight", fmt.Sprintf("%d", step.Weight)}},
+		}})
+	}
+	for _, s := range t.spacing {
+		spacing = append(spacing, orderedField{s.key, remString(s.px)})
+	}
+
+	extend := orderedObject{{"colors", colors}}
+	if len(families) > 0 {
+		extend = append(extend, orderedField{"fontFamily", families})
+	}
+	if len(sizes) > 0 {
+		extend = append(extend, orderedField{"fontSize", sizes})
+	}
+	extend = append(extend, orderedField{"spacing", spacing})
+
+	theme, err := json.MarshalIndent(extend, "    ", "  ")
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	b.WriteString(tokenHeader(t, "/*", "*/"))
+	b.WriteString("/** @type {import('tailwindcss').Config} */\nmodule.exports = {\n  theme: {\n    extend: ")
+	b.Write(theme)
+	b.WriteString(",\n  },\n};\n")
+	return []byte(b.String()), nil
+}
+
+// renderTokensW3C writes the W3C Design Tokens Community Group format, which Tokens Studio also imports
+func renderTokensW3C(t *designTokens) ([]byte, error) {
+	var colors orderedObject
+	for _, c := range t.colors {
+		group := orderedObject{{"$type", "color"}, {"$description", c.describe()}}
+		group = append(group, orderedField{"base", orderedObject{{"$value", fmt.Sprintf("{color.%s.500}", c.key)}}})
+		for _, step := range c.ramp {
+			group = append(group, orderedField{step.step, orderedObject{{"$value", step.hex}}})
+		}
+		colors = append(colors, orderedField{c.key, group})
+	}
+
+	root := orderedObject{{"color", colors}}
+	if len(t.fonts) > 0 {
+		var families, weights orderedObject
+		for _, f := range t.fonts {
+			families = append(families, orderedField{f.key, orderedObject{{"$value", f.stack}}})
+			if f.weight > 0 {
+				weights = append(weights, orderedField{f.key, orderedObject{{"$value", f.weight}}})
+			}
+		}
+		root = append(root, orderedField{"font", orderedObject{
+			{"family", append(orderedObject{{"$type", "fontFamily"}}, families...)},
+			{"weight", append(orderedObject{{"$type", "fontWeight"}}, weights...)},
+		}})
+	}
+	if len(t.typeScale) > 0 {
+		typography := orderedObject{{"$type", "typography"}}
+		for _, step := range t.typeScale {
+			typography = append(typography, orderedField{step.Name, orderedObject{{"$value", orderedObject{
+				{"fontFamily", fmt.Sprintf("{font.family.%s}", t.fontForStep(step))},
+				{"fontSize", trimFloat(step.SizeRem) + "rem"},
+				{"fontWeight", step.Weight},
+				{"lineHeight", step.LineHeight},
+			}}}})
+		}
+		root = append(root, orderedField{"typography", typography})
+	}
+	spacing := orderedObject{{"$type", "dimension"}}
+	for _, s := range t.spacing {
+		spacing = append(spacing, orderedField{s.key, orderedObject{{"$value", remString(s.px)}}})
+	}
+	root = append(root, orderedField{"spacing", spacing})
+
+	return marshalTokens(root)
+}
+
+// renderTokensStudio writes Tokens Studio for Figma's single-set format
+func renderTokensStudio(t *designTokens) ([]byte, error) {
+	token := func(value interface{}, kind string) orderedObject {
+		return orderedObject{{"value", value}, {"type", kind}}
+	}
+
+	var colors orderedObject
+	for _, c := range t.colors {
+		group := orderedObject{{"base", token(fmt.Sprintf("{color.%s.500}", c.key), "color")}}
+		for _, step := range c.ramp {
+			group = append(group, orderedField{step.step, token(step.hex, "color")})
+		}
+		colors = append(colors, orderedField{c.key, group})
+	}
+	set := orderedObject{{"color", colors}}
+
+	if len(t.fonts) > 0 {
+		var families orderedObject
+		for _, f := range t.fonts {
+			families = append(families, orderedField{f.key, token(f.family, "fontFamilies")})
+		}
+		set = append(set, orderedField{"fontFamilies", families})
+	}
+	if len(t.typeScale) > 0 {
+		var sizes, lineHeights, weights, typography orderedObject
+		for _, step := range t.typeScale {
+			sizes = append(sizes, orderedField{step.Name, token(trimFloat(step.SizePx), "fontSizes")})
+			lineHeights = append(lineHeights, orderedField{step.Name, token(trimFloat(step.LineHeight*100)+"%", "lineHeights")})
+			weights = append(weights, orderedField{step.Name, token(fmt.Sprintf("%d", step.Weight), "fontWeights")})
+			typography = append(typography, orderedField{step.Name, token(orderedObject{
+				{"fontFamily", fmt.Sprintf("{fontFamilies.%s}", t.fontForStep(step))},
+				{"fontWeight", fmt.Sprintf("{fontWeights.%s}", step.Name)},
+				{"fontSize", fmt.Sprintf("{fontSizes.%s}", step.Name)},
+				{"lineHeight", fmt.Sprintf("{lineHeights.%s}", step.Name)},
+			}, "typography")})
+		}
+		set = append(set,
+			orderedField{"fontSizes", sizes},
+			orderedField{"lineHeights", lineHeights},
+			orderedField{"fontWeights", weights},
+			orderedField{"typography", typography})
+	}
+	var spacing orderedObject
+	for _, s := range t.spacing {
+		spacing = append(spacing, orderedField{s.key, token(trimFloat(s.px), "spacing")})
+	}
+	set = append(set, orderedField{"spacing", spacing})
+
+	return marshalTokens(orderedObject{
+		{"global", set},
+		{"$themes", []interface{}{}},
+		{"$metadata", orderedObject{{"tokenSetOrder", []string{"global"}}}},
+	})
+}
+
+// renderTokensASE writes an Adobe Swatch Exchange file with one group per palette color and its ramp
+func renderTokensASE(t *designTokens) ([]byte, error) {
+	var blocks bytes.Buffer
+	count := 0
+	writeBlock := func(kind uint16, body []byte) {
+		binary.Write(&blocks, binary.BigEndian, kind)
+		binary.Write(&blocks, binary.BigEndian, uint32(len(body)))
+		blocks.Write(body)
+		count++
+	}
+	name := func(s string) []byte {
+		var b bytes.Buffer
+		units := utf16.Encode([]rune(s))
+		binary.Write(&b, binary.BigEndian, uint16(len(units)+1)) // includes the terminator
+		binary.Write(&b, binary.BigEndian, units)
+		binary.Write(&b, binary.BigEndian, uint16(0))
+		return b.Bytes()
+	}
+	swatch := func(label string, c color.RGBA) []byte {
+		b := bytes.NewBuffer(name(label))
+		b.WriteString("RGB ")
+		binary.Write(b, binary.BigEndian, []float32{float32(c.R) / 255, float32(c.G) / 255, float32(c.B) / 255})
+		binary.Write(b, binary.BigEndian, uint16(2)) // normal (process) color
+		return b.Bytes()
+	}
+
+	for _, c := range t.colors {
+		writeBlock(0xC001, name(c.label))
+		writeBlock(0x0001, swatch(c.label, c.rgb))
+		for _, step := range c.ramp {
+			if step.step != "500" {
+				writeBlock(0x0001, swatch(fmt.Sprintf("%s %s", c.label, step.step), step.rgb))
+			}
+		}
+		writeBlock(0xC002, nil)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("ASEF")
+	binary.Write(&out, binary.BigEndian, []uint16{1, 0})
+	binary.Write(&out, binary.BigEndian, uint32(count))
+	out.Write(blocks.Bytes())
+	return out.Bytes(), nil
+}
+
+// renderTokensGPL writes a GIMP/Inkscape/Krita palette with each color's ramp on one row
+func renderTokensGPL(t *designTokens) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "GIMP Palette\nName: %s\nColumns: 9\n#\n", strings.ReplaceAll(t.name, "\n", " "))
+	for _, c := range t.colors {
+		for _, step := range c.ramp {
+			label := c.label
+			if step.step != "500" {
+				label = fmt.Sprintf("%s %s", c.label, step.step)
+			}
+			fmt.Fprintf(&b, "%3d %3d %3d\t%s\n", step.rgb.R, step.rgb.G, step.rgb.B, label)
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// RenderDesignTokens renders the identity's tokens in one format, returning the file's content type and name
+func RenderDesignTokens(companyName string, identity *bezzmodels.BrandIdentity, format string) ([]byte, string, string, error) {
+	f, ok := designTokenFormatByID(format)
+	if !ok {
+		return nil, "", "", ValidateDesignTokenFormat(format)
+	}
+	data, err := f.render(buildDesignTokens(companyName, identity))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to render %s tokens: %w", format, err)
+	}
+	return data, f.ContentType, f.Filename, nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func sampleTokenIdentity() *models.BrandIdentity {
+	identity := sampleBrandBookBrief().Results.BrandIdentity
+	identity.Typography.Heading.Fallback = `Georgia, "Times New Roman", serif`
+	identity.Typography.Body.Fallback = "system-ui, sans-serif"
+	return identity
+}
+
+func renderTokens(t *testing.T, format string) string {
+	data, _, _, err := RenderDesignTokens("Acme Coffee", sampleTokenIdentity(), format)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRenderDesignTokens_CSSAndSCSS(t *testing.T) {
+	css := renderTokens(t, "css")
+	for _, want := range []string{
+		"--color-primary: #3B2416;",
+		"--color-accent-100: #FCE8D4;",
+		`--font-heading: "Playfair Display", "Georgia", "Times New Roman", serif;`,
+		"--font-weight-heading: 700;",
+		"--font-weight-body: 400;",
+		"--font-size-h1: 2.44rem;",
+		"--space-4: 1rem;",
+	} {
+		assert.Contains(t, css, want)
+	}
+	assert.NotContains(t, css, "--color-primary-500", "the 500 step is the base color")
+
+	scss := renderTokens(t, "scss")
+	assert.Contains(t, scss, "$color-secondary: #F2E1C9;")
+	assert.Contains(t, scss, `"accent": $color-accent,`)
+}
+
+// jsonPath walks nested JSON objects
+func jsonPath(v map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		v, _ = v[key].(map[string]interface{})
+	}
+	return v
+}
+
+func TestRenderDesignTokens_JSONFormats(t *testing.T) {
+	var w3c map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(renderTokens(t, "json")), &w3c))
+	primary := jsonPath(w3c, "color", "primary")
+	assert.Equal(t, "color", primary["$type"])
+	assert.Equal(t, "Espresso (primary): Rich and grounded", primary["$description"])
+	assert.Equal(t, "#3B2416", jsonPath(primary, "500")["$value"])
+	assert.Equal(t, "{color.primary.500}", jsonPath(primary, "base")["$value"])
+	assert.Equal(t, "{font.family.heading}", jsonPath(w3c, "typography", "h1", "$value")["fontFamily"])
+	assert.Equal(t, "fontWeight", jsonPath(w3c, "font", "weight")["$type"])
+
+	var studio struct {
+		Global   map[string]map[string]interface{} `json:"global"`
+		Metadata struct {
+			TokenSetOrder []string `json:"tokenSetOrder"`
+		} `json:"$metadata"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(renderTokens(t, "tokens-studio")), &studio))
+	assert.Equal(t, []string{"global"}, studio.Metadata.TokenSetOrder)
+	assert.Equal(t, map[string]interface{}{"value": "120%", "type": "lineHeights"}, studio.Global["lineHeights"]["h1"])
+	assert.Equal(t, map[string]interface{}{"value": "16", "type": "spacing"}, studio.Global["spacing"]["4"])
+
+	tailwind := renderTokens(t, "tailwind")
+	assert.True(t, strings.HasSuffix(tailwind, "  },\n};\n"))
+	assert.Contains(t, tailwind, `"DEFAULT": "#3B2416"`)
+}
+
+func TestRenderDesignTokens_ASE(t *testing.T) {
+	data := []byte(renderTokens(t, "ase"))
+	require.Equal(t, "ASEF", string(data[:4]))
+	assert.Equal(t, []byte{0, 1, 0, 0}, data[4:8])
+	count := binary.BigEndian.Uint32(data[8:12])
+	assert.Equal(t, uint32(3*(1+9+1)), count, "a group start, nine swatches and a group end per color")
+
+	// Walk the blocks, decoding the first swatch
+	r := bytes.NewReader(data[12:])
+	var names []string
+	var first []float32
+	for i := uint32(0); i < count; i++ {
+		var kind uint16
+		var length uint32
+		require.NoError(t, binary.Read(r, binary.BigEndian, &kind))
+		require.NoError(t, binary.Read(r, binary.BigEndian, &length))
+		body := make([]byte, length)
+		_, err := r.Read(body)
+		if length > 0 {
+			require.NoError(t, err)
+		}
+		if kind != 0x0001 {
+			continue
+		}
+		units := int(binary.BigEndian.Uint16(body[:2]))
+		name := make([]uint16, units-1)
+		require.NoError(t, binary.Read(bytes.NewReader(body[2:]), binary.BigEndian, name))
+		names = append(names, string(utf16.Decode(name)))
+		if first == nil {
+			rest := body[2+units*2:]
+			assert.Equal(t, "RGB ", string(rest[:4]))
+			first = make([]float32, 3)
+			require.NoError(t, binary.Read(bytes.NewReader(rest[4:16]), binary.BigEndian, first))
+		}
+	}
+	assert.Zero(t, r.Len())
+	assert.Equal(t, "Espresso", names[0])
+	assert.Contains(t, names, "Sunrise 900")
+	assert.InDelta(t, 59.0/255, first[0], 1e-6)
+	assert.InDelta(t, 22.0/255, first[2], 1e-6)
+}
+
+func TestRenderDesignTokens_GPL(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(renderTokens(t, "gpl")), "\n")
+	assert.Equal(t, "GIMP Palette", lines[0])
+	assert.Len(t, lines, 4+3*9)
+	assert.Equal(t, " 59  36  22\tEspresso", lines[8])
+}
+
+func TestBuildDesignTokens_Keys(t *testing.T) {
+	tokens := buildDesignTokens("Acme", &models.BrandIdentity{ColorPalette: []models.Color{
+		{Name: "Deep Sea", Hex: "#003366", Usage: "accent"},
+		{Name: "Coral Reef", Hex: "#FF7F50", Usage: "accent"},
+		{Name: "", Hex: "#FFFFFF"},
+		{Name: "Broken", Hex: "nope"},
+	}})
+	require.Len(t, tokens.colors, 3, "invalid hex colors are dropped")
+	assert.Equal(t, "deep-sea", tokens.colors[0].key, "shared usages fall back to the name")
+	assert.Equal(t, "coral-reef", tokens.colors[1].key)
+	assert.Equal(t, "color-3", tokens.colors[2].key)
+	assert.Empty(t, tokens.fonts)
+
+	ramp := tokens.colors[0].ramp
+	require.Len(t, ramp, 9)
+	assert.Equal(t, "100", ramp[0].step)
+	assert.Equal(t, "#003366", ramp[4].hex)
+	assert.Greater(t, relativeLuminance(ramp[0].rgb), relativeLuminance(ramp[8].rgb))
+}
+
+func TestValidateDesignTokenFormat(t *testing.T) {
+	assert.NoError(t, ValidateDesignTokenFormat("tailwind"))
+	err := ValidateDesignTokenFormat("xml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "css, scss, tailwind, json, tokens-studio, ase, gpl")
+	assert.Equal(t, "0", trimFloat(math.Copysign(0, -1)))
+	assert.Equal(t, "1.25", trimFloat(1.2500001))
+}
@@ -4,7 +4,10 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
 	"io"
 	"log"
 	"net/http"
@@ -14,54 +17,74 @@ import (
 	"bezz-backend/internal/models"
 
 	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ExportService handles exporting brand assets
 type ExportService struct {
 	briefService *BrandBriefService
 	db           *firestore.Client
+	storage      *storage.Client
+	bucketName   string
+	adNetworks   []AdNetworkExporter
 }
 
 // NewExportService creates a new export service
-func NewExportService(briefService *BrandBriefService, db *firestore.Client) *ExportService {
+func NewExportService(briefService *BrandBriefService, db *firestore.Client, storage *storage.Client, bucketName string) *ExportService {
 	return &ExportService{
 		briefService: briefService,
 		db:           db,
+		storage:      storage,
+		bucketName:   bucketName,
+		adNetworks:   []AdNetworkExporter{metaAdsExporter{}, googleAdsExporter{}, linkedInAdsExporter{}},
 	}
 }
 
-// GenerateBatchExport creates a ZIP or PDF export of all brand assets
-func (s *ExportService) GenerateBatchExport(ctx context.Context, briefID string, userID string, format string) ([]byte, string, string, error) {
-	log.Printf("📦 EXPORT: Starting batch export for brief %s in %s format", briefID, format)
+// ErrBriefAccessDenied is returned when exporting a brief that belongs to another user
+var ErrBriefAccessDenied = errors.New("access denied: brief belongs to different user")
 
-	// Fetch the brief with results
-	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to fetch brief: %w", err)
-	}
+// ErrNothingToExport is returned when exporting a brief that has no results yet
+var ErrNothingToExport = errors.New("brief has no results to export")
 
-	// Validate that the brief has results
-	if brief.Results == nil {
-		return nil, "", "", fmt.Errorf("brief has no results to export")
-	}
+// ErrUnsupportedExportFormat is returned for batch export formats other than zip, pdf and pptx
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+// exportContentTypes maps each batch export format to the MIME type it is served with
+var exportContentTypes = map[string]string{
+	"zip":  "application/zip",
+	"pdf":  "application/pdf",
+	"pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+// batchExportFilename names a batch export download, e.g. Acme-Coffee-brand-kit-2026-10-18.zip
+func batchExportFilename(brief *models.BrandBrief, format string) string {
+	kind := map[string]string{"zip": "brand-kit", "pdf": "brand-book", "pptx": "pitch-deck"}[format]
+	return fmt.Sprintf("%s-%s-%s.%s",
+		strings.ReplaceAll(brief.CompanyName, " ", "-"), kind,
+		time.Now().Format("2006-01-02"), format)
+}
 
+// writeBatchExport writes a brief's ZIP, PDF or PPTX export to w
+func (s *ExportService) writeBatchExport(ctx context.Context, w io.Writer, brief *models.BrandBrief, format string, template string) error {
 	switch format {
 	case "zip":
-		return s.generateZipExport(ctx, brief)
+		return s.writeZipExport(ctx, w, brief)
 	case "pdf":
-		return s.generatePDFExport(ctx, brief)
+		return s.writePDFExport(ctx, w, brief, template)
+	case "pptx":
+		return s.writePPTXExport(ctx, w, brief)
 	default:
-		return nil, "", "", fmt.Errorf("unsupported format: %s", format)
+		return fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
-// generateZipExport creates a ZIP file with all brand assets
-func (s *ExportService) generateZipExport(ctx context.Context, brief *models.BrandBrief) ([]byte, string, string, error) {
+// writeZipExport streams a ZIP of all brand assets to w, so large kits never sit in memory whole
+func (s *ExportService) writeZipExport(ctx context.Context, w io.Writer, brief *models.BrandBrief) error {
 	log.Printf("📦 EXPORT: Creating ZIP export for %s", brief.CompanyName)
 
-	// Create a buffer to write the ZIP to
-	buf := new(bytes.Buffer)
-	zipWriter := zip.NewWriter(buf)
+	zipWriter := zip.NewWriter(w)
 
 	// Add brand strategy document
 	if err := s.addStrategyToZip(zipWriter, brief); err != nil {
@@ -87,42 +110,74 @@ func (s *ExportService) generateZipExport(ctx context.Context, brief *models.Bra
 		log.Printf("⚠️ EXPORT: Failed to add ads to ZIP: %v", err)
 	}
 
-	// Add asset list/manifest
-	if err := s.addManifestToZip(zipWriter, brief); err != nil {
+	// Add video storyboards
+	if err := s.addVideosToZip(zipWriter, brief); err != nil {
+		log.Printf("⚠️ EXPORT: Failed to add video storyboards to ZIP: %v", err)
+	}
+
+	// Add the stored images, clips and subtitles; a failed write means the destination is gone, so give up
+	assets := zipExportAssets(brief)
+	missing, err := s.addAssetsToZip(ctx, zipWriter, assets)
+	if err != nil {
+		return fmt.Errorf("failed to write assets to ZIP: %w", err)
+	}
+
+	// Add asset list/manifest from the same asset list, noting anything that could not be included
+	if err := s.addManifestToZip(zipWriter, brief, assets, missing); err != nil {
 		log.Printf("⚠️ EXPORT: Failed to add manifest to ZIP: %v", err)
 	}
 
 	// Close the ZIP writer
 	if err := zipWriter.Close(); err != nil {
-		return nil, "", "", fmt.Errorf("failed to close ZIP writer: %w", err)
+		return fmt.Errorf("failed to close ZIP writer: %w", err)
 	}
 
-	filename := fmt.Sprintf("%s-brand-kit-%s.zip",
-		strings.ReplaceAll(brief.CompanyName, " ", "-"),
-		time.Now().Format("2006-01-02"))
-
 	log.Printf("✅ EXPORT: ZIP export created successfully for %s", brief.CompanyName)
-	return buf.Bytes(), "application/zip", filename, nil
+	return nil
 }
 
-// generatePDFExport creates a PDF report of the brand strategy
-func (s *ExportService) generatePDFExport(ctx context.Context, brief *models.BrandBrief) ([]byte, string, string, error) {
-	// For now, return text content as PDF would require additional dependencies
-	// This can be enhanced later with proper PDF generation
-	content := s.generateStrategyText(brief)
+// writePDFExport renders the brand book PDF, embedding the logo and ad images in the chosen template's layout
+func (s *ExportService) writePDFExport(ctx context.Context, w io.Writer, brief *models.BrandBrief, template string) error {
+	log.Printf("📦 EXPORT: Creating PDF brand book for %s with the %s template", brief.CompanyName, template)
 
-	filename := fmt.Sprintf("%s-brand-strategy-%s.txt",
-		strings.ReplaceAll(brief.CompanyName, " ", "-"),
-		time.Now().Format("2006-01-02"))
+	assets := s.loadExportImages(ctx, brief)
 
-	return []byte(content), "text/plain", filename, nil
+	pdf, err := renderBrandBook(brief, template, assets)
+	if err != nil {
+		return fmt.Errorf("failed to render brand book: %w", err)
+	}
+	if _, err := w.Write(pdf); err != nil {
+		return fmt.Errorf("failed to write brand book: %w", err)
+	}
+
+	log.Printf("✅ EXPORT: PDF brand book created successfully for %s", brief.CompanyName)
+	return nil
+}
+
+// writePPTXExport renders the pitch deck account managers present to clients
+func (s *ExportService) writePPTXExport(ctx context.Context, w io.Writer, brief *models.BrandBrief) error {
+	log.Printf("📦 EXPORT: Creating PPTX pitch deck for %s", brief.CompanyName)
+
+	deck, err := renderPitchDeck(brief, s.loadExportImages(ctx, brief))
+	if err != nil {
+		return fmt.Errorf("failed to render pitch deck: %w", err)
+	}
+	if _, err := w.Write(deck); err != nil {
+		return fmt.Errorf("failed to write pitch deck: %w", err)
+	}
+
+	log.Printf("✅ EXPORT: PPTX pitch deck created successfully for %s", brief.CompanyName)
+	return nil
 }
 
 // getBriefWithValidation fetches and validates brief ownership
 func (s *ExportService) getBriefWithValidation(ctx context.Context, briefID string, userID string) (*models.BrandBrief, error) {
 	doc, err := s.db.Collection("briefs").Doc(briefID).Get(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("brief not found: %w", err)
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrBriefNotFound
+		}
+		return nil, err
 	}
 
 	var brief models.BrandBrief
@@ -132,7 +187,7 @@ func (s *ExportService) getBriefWithValidation(ctx context.Context, briefID stri
 
 	// Validate ownership
 	if brief.UserID != userID {
-		return nil, fmt.Errorf("access denied: brief belongs to different user")
+		return nil, ErrBriefAccessDenied
 	}
 
 	return &brief, nil
@@ -187,15 +242,29 @@ CONCEPT DESCRIPTION:
 COLOR PALETTE:
 `, brief.CompanyName, time.Now().Format("January 2, 2006"), identity.LogoConcept)
 
-	for _, color := range identity.ColorPalette {
+	analysis := paletteAnalysisFor(identity)
+	for i, color := range identity.ColorPalette {
 		logoContent += fmt.Sprintf(`
 • %s (%s)
   Hex: %s
   Usage: %s
   Psychology: %s
 `, color.Name, color.Usage, color.Hex, strings.Title(color.Usage), color.Psychology)
+		if i < len(analysis.Colors) {
+			spec := analysis.Colors[i]
+			logoContent += fmt.Sprintf(`  RGB: %d, %d, %d
+  HSL: %.0f°, %.0f%%, %.0f%%
+  CMYK: %.0f, %.0f, %.0f, %.0f
+  Tints: %s
+  Shades: %s
+`, spec.RGB.R, spec.RGB.G, spec.RGB.B, spec.HSL.H, spec.HSL.S, spec.HSL.L,
+				spec.CMYK.C, spec.CMYK.M, spec.CMYK.Y, spec.CMYK.K,
+				strings.Join(spec.Tints, ", "), strings.Join(spec.Shades, ", "))
+		}
 	}
 
+	logoContent += s.generatePaletteAnalysisText(analysis)
+
 	file, err := zipWriter.Create("03-Logo-Concept-and-Colors.txt")
 	if err != nil {
 		return err
@@ -205,17 +274,195 @@ COLOR PALETTE:
 		return err
 	}
 
-	// Add logo image if available
-	if identity.LogoImageURL != "" {
-		if err := s.addImageToZip(zipWriter, identity.LogoImageURL, "04-Logo-Concept.jpg"); err != nil {
-			log.Printf("⚠️ EXPORT: Failed to add logo image to ZIP: %v", err)
+	// Machine-readable palette for design tools
+	paletteJSON, err := json.MarshalIndent(struct {
+		Palette  []models.Color          `json:"palette"`
+		Analysis *models.PaletteAnalysis `json:"analysis"`
+	}{identity.ColorPalette, analysis}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if file, err = zipWriter.Create("03-Color-Palette.json"); err != nil {
+		return err
+	}
+	if _, err = file.Write(paletteJSON); err != nil {
+		return err
+	}
+
+	// Add typography system
+	if identity.Typography != nil {
+		if file, err = zipWriter.Create("03-Typography.txt"); err != nil {
+			return err
 		}
+		if _, err = file.Write([]byte(s.generateTypographyText(brief.CompanyName, identity.Typography))); err != nil {
+			return err
+		}
+	}
+
+	// Add design tokens for developers and design tools
+	if err := s.addDesignTokensToZip(zipWriter, brief); err != nil {
+		log.Printf("⚠️ EXPORT: Failed to add design tokens to ZIP: %v", err)
 	}
 
 	return nil
 }
 
-// addAdsToZip adds advertisement assets to the ZIP
+// designTokensFolder holds the token files in the ZIP
+const designTokensFolder = "03-Design-Tokens"
+
+// addDesignTokensToZip writes the identity's design tokens in every supported format
+func (s *ExportService) addDesignTokensToZip(zipWriter *zip.Writer, brief *models.BrandBrief) error {
+	for _, format := range designTokenFormats {
+		data, _, filename, err := RenderDesignTokens(brief.CompanyName, brief.Results.BrandIdentity, format.ID)
+		if err != nil {
+			return err
+		}
+		file, err := zipWriter.Create(designTokensFolder + "/" + filename)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateDesignTokensExport renders a brief's design tokens in one format for download
+func (s *ExportService) GenerateDesignTokensExport(ctx context.Context, briefID string, userID string, format string) ([]byte, string, string, error) {
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch brief: %w", err)
+	}
+	if brief.Results == nil || brief.Results.BrandIdentity == nil {
+		return nil, "", "", fmt.Errorf("brief has no brand identity to export")
+	}
+
+	data, contentType, filename, err := RenderDesignTokens(brief.CompanyName, brief.Results.BrandIdentity, format)
+	if err != nil {
+		return nil, "", "", err
+	}
+	filename = fmt.Sprintf("%s-%s", strings.ReplaceAll(brief.CompanyName, " ", "-"), strings.TrimPrefix(filename, "_"))
+
+	log.Printf("✅ EXPORT: %s design tokens created for %s", format, brief.CompanyName)
+	return data, contentType, filename, nil
+}
+
+// designTokenDescriptions explain each token file in the ZIP manifest
+var designTokenDescriptions = map[string]string{
+	"css":           "CSS custom properties for colors, fonts, type scale and spacing",
+	"scss":          "SCSS variables and maps",
+	"tailwind":      "Tailwind CSS theme extension",
+	"json":          "W3C design tokens JSON (also imports into Tokens Studio)",
+	"tokens-studio": "Tokens Studio for Figma token set",
+	"ase":           "Adobe Swatch Exchange palette for Illustrator, Photoshop and InDesign",
+	"gpl":           "GIMP palette, also read by Inkscape and Krita",
+}
+
+// logoVariantFilename is the ZIP path of a logo variant
+func logoVariantFilename(variant models.LogoVariant) string {
+	return fmt.Sprintf("04-Logo-Variants/%s.%s", variant.Name, variant.Format)
+}
+
+// generateTypographyText formats the typography system
+func (s *ExportService) generateTypographyText(companyName string, typography *models.Typography) string {
+	weights := func(ws []int) string {
+		parts := make([]string, len(ws))
+		for i, w := range ws {
+			parts[i] = fmt.Sprint(w)
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	content := fmt.Sprintf(`TYPOGRAPHY SYSTEM
+Company: %s
+
+HEADING FONT: %s (%s)
+  Weights: %s
+  License: %s
+  Download: %s
+  CSS fallback: %s
+
+BODY FONT: %s (%s)
+  Weights: %s
+  License: %s
+  Download: %s
+  CSS fallback: %s
+`, companyName,
+		typography.Heading.Family, typography.Heading.Category, weights(typography.Heading.Weights),
+		typography.Heading.License, typography.Heading.SourceURL, typography.Heading.Fallback,
+		typography.Body.Family, typography.Body.Category, weights(typography.Body.Weights),
+		typography.Body.License, typography.Body.SourceURL, typography.Body.Fallback)
+
+	if typography.Rationale != "" {
+		content += fmt.Sprintf("\nWHY THIS PAIRING:\n%s\n", typography.Rationale)
+	}
+
+	content += fmt.Sprintf("\nTYPE SCALE (%s, ratio %.3g, 16px base):\n", typography.ScaleName, typography.ScaleRatio)
+	for _, step := range typography.Scale {
+		content += fmt.Sprintf("• %-8s %s %d - %.0fpx / %.3grem, line height %.2g\n",
+			step.Name, step.Family, step.Weight, step.SizePx, step.SizeRem, step.LineHeight)
+	}
+
+	content += "\nUSAGE RULES:\n"
+	for _, rule := range typography.UsageRules {
+		content += fmt.Sprintf("• %s\n", rule)
+	}
+	return content
+}
+
+// paletteAnalysisFor returns the stored palette analysis, analysing older briefs on the fly
+func paletteAnalysisFor(identity *models.BrandIdentity) *models.PaletteAnalysis {
+	if identity.PaletteAnalysis != nil {
+		return identity.PaletteAnalysis
+	}
+	_, analysis := AnalyzePalette(identity.ColorPalette)
+	return analysis
+}
+
+// generatePaletteAnalysisText formats the accessibility, harmony and dark-mode analysis
+func (s *ExportService) generatePaletteAnalysisText(analysis *models.PaletteAnalysis) string {
+	content := "\nACCESSIBILITY (WCAG 2.2 CONTRAST):\n"
+	for _, check := range analysis.Contrast {
+		grade := "FAIL - not for text"
+		switch {
+		case check.AAA:
+			grade = "AAA"
+		case check.AA:
+			grade = "AA"
+		case check.AALarge:
+			grade = "AA large text only (18pt+ or 14pt bold)"
+		}
+		content += fmt.Sprintf("• %s on %s: %.2f:1 - %s\n", check.Foreground, check.Background, check.Ratio, grade)
+	}
+
+	if len(analysis.Issues) > 0 {
+		content += "\nPALETTE ISSUES:\n"
+		for _, issue := range analysis.Issues {
+			content += fmt.Sprintf("• %s\n", issue)
+		}
+	}
+
+	harmony := analysis.Harmony
+	content += fmt.Sprintf(`
+COLOR HARMONY:
+Scheme: %s
+Complementary: %s
+Analogous: %s
+Split complementary: %s
+Triadic: %s
+`, strings.ReplaceAll(harmony.Scheme, "_", " "), harmony.Complementary,
+		strings.Join(harmony.Analogous, ", "), strings.Join(harmony.SplitComplementary, ", "), strings.Join(harmony.Triadic, ", "))
+
+	content += "\nDARK MODE:\n"
+	for _, color := range analysis.DarkMode {
+		content += fmt.Sprintf("• %s (%s): %s\n", color.Name, color.Usage, color.Hex)
+	}
+
+	return content
+}
+
+// addAdsToZip adds each advertisement's copy to the ZIP; its images are bundled by addAssetsToZip
 func (s *ExportService) addAdsToZip(zipWriter *zip.Writer, brief *models.BrandBrief) error {
 	if len(brief.Results.Ads) == 0 {
 		return nil
@@ -251,6 +498,17 @@ IMAGE PROMPT USED:
 `, i+1, brief.CompanyName, time.Now().Format("January 2, 2006"),
 			ad.Title, ad.Copy.Headline, ad.Copy.Body, ad.Copy.CTA, ad.Platform, ad.Format, ad.ImagePrompt)
 
+		if ad.Copy.Description != "" {
+			adContent += fmt.Sprintf("\nDESCRIPTION:\n%s\n", ad.Copy.Description)
+		}
+
+		if violations := storedAdCopyViolations(ad); len(violations) > 0 {
+			adContent += fmt.Sprintf("\nCOPY RULE WARNINGS (rules %s):\n", copyRulesForVersion(ad.CopyRulesVersion, ad.Placement).Version)
+			for _, violation := range violations {
+				adContent += fmt.Sprintf("• %s\n", violation.Message)
+			}
+		}
+
 		filename := fmt.Sprintf("05-Ads/Ad-%d-Content.txt", i+1)
 		file, err := zipWriter.Create(filename)
 		if err != nil {
@@ -260,21 +518,66 @@ IMAGE PROMPT USED:
 		if _, err = file.Write([]byte(adContent)); err != nil {
 			return err
 		}
+	}
 
-		// Add ad image if available
-		if ad.ImageURL != "" {
-			imageFilename := fmt.Sprintf("05-Ads/Ad-%d-Image.jpg", i+1)
-			if err := s.addImageToZip(zipWriter, ad.ImageURL, imageFilename); err != nil {
-				log.Printf("⚠️ EXPORT: Failed to add ad image %d to ZIP: %v", i+1, err)
-			}
+	return nil
+}
+
+// addVideosToZip adds each video's shooting script to the ZIP; its rendered files are bundled by addAssetsToZip
+func (s *ExportService) addVideosToZip(zipWriter *zip.Writer, brief *models.BrandBrief) error {
+	for _, video := range brief.Results.VideoAds {
+		folder := videoExportFolder(video)
+
+		file, err := zipWriter.Create(folder + "/Script.txt")
+		if err != nil {
+			return err
+		}
+		if _, err = file.Write([]byte(s.generateVideoScriptText(brief.CompanyName, video))); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
-// addManifestToZip adds a manifest file listing all included assets
-func (s *ExportService) addManifestToZip(zipWriter *zip.Writer, brief *models.BrandBrief) error {
+// videoExportFolder is the ZIP folder holding one video's files
+func videoExportFolder(video models.VideoAd) string {
+	return fmt.Sprintf("06-Video/%ds-%s", video.Duration, strings.ReplaceAll(video.AspectRatio, ":", "x"))
+}
+
+// generateVideoScriptText formats a storyboard as a shooting script
+func (s *ExportService) generateVideoScriptText(companyName string, video models.VideoAd) string {
+	content := fmt.Sprintf(`VIDEO SCRIPT
+Company: %s
+Title: %s
+Length: %ds
+Aspect Ratio: %s
+Voice: %s
+
+═══════════════════════════════════════════════════════════════
+`, companyName, video.Title, video.Duration, video.AspectRatio, video.Voice)
+
+	for i, scene := range video.Scenes {
+		content += fmt.Sprintf("\nSCENE %d  [%s - %s]\n", i+1, timecode(scene.StartTime), timecode(scene.StartTime+scene.Duration))
+		content += fmt.Sprintf("Action: %s\n", scene.Description)
+		if scene.Voiceover != "" {
+			content += fmt.Sprintf("Voiceover: %s\n", scene.Voiceover)
+		}
+		if scene.VoiceoverDuration > 0 {
+			content += fmt.Sprintf("Voiceover length: %.1fs\n", scene.VoiceoverDuration)
+		}
+		if scene.OnScreenText != "" {
+			content += fmt.Sprintf("On-screen text: %s\n", scene.OnScreenText)
+		}
+		content += fmt.Sprintf("Keyframe prompt: %s\n", scene.VisualPrompt)
+	}
+
+	content += fmt.Sprintf("\nFULL VOICEOVER\n%s\n", video.Script)
+	return content
+}
+
+// addManifestToZip adds a manifest file listing all included files and any stored assets that could not be read.
+// Stored files are listed from assets, the list the ZIP was written from, so the manifest matches the archive.
+func (s *ExportService) addManifestToZip(zipWriter *zip.Writer, brief *models.BrandBrief, assets []exportAsset, missing []missingAsset) error {
 	manifest := fmt.Sprintf(`BRAND KIT MANIFEST
 Company: %s
 Generated: %s
@@ -283,31 +586,83 @@ Export Date: %s
 INCLUDED FILES:
 `, brief.CompanyName, brief.CreatedAt.Format("January 2, 2006"), time.Now().Format("January 2, 2006 at 3:04 PM"))
 
+	written := make(map[string]bool, len(assets))
+	for _, asset := range assets {
+		written[asset.filename] = true
+	}
+	for _, asset := range missing {
+		delete(written, asset.filename)
+	}
+
+	total := 1 // the manifest itself
+	// document lists a file the export always writes; stored lists one only if it was copied into the ZIP
+	document := func(filename, description string) {
+		manifest += fmt.Sprintf("• %s - %s\n", filename, description)
+		total++
+	}
+	stored := func(filename, description string) {
+		if written[filename] {
+			document(filename, description)
+		}
+	}
+
 	manifest += "\n📋 STRATEGY & POSITIONING:\n"
-	manifest += "• 01-Brand-Strategy.txt - Complete brand strategy document\n"
+	document("01-Brand-Strategy.txt", "Complete brand strategy document")
 
-	if brief.Results.BrandNames != nil && len(brief.Results.BrandNames) > 0 {
+	if len(brief.Results.BrandNames) > 0 {
 		manifest += "\n🏷️ BRAND NAMES:\n"
-		manifest += fmt.Sprintf("• 02-Brand-Name-Suggestions.txt - %d alternative brand name options\n", len(brief.Results.BrandNames))
+		document("02-Brand-Name-Suggestions.txt", fmt.Sprintf("%d alternative brand name options", len(brief.Results.BrandNames)))
 	}
 
-	if brief.Results.BrandIdentity != nil {
+	if identity := brief.Results.BrandIdentity; identity != nil {
 		manifest += "\n🎨 VISUAL IDENTITY:\n"
-		manifest += "• 03-Logo-Concept-and-Colors.txt - Logo concept and color palette\n"
-		if brief.Results.BrandIdentity.LogoImageURL != "" {
-			manifest += "• 04-Logo-Concept.jpg - Generated logo concept image\n"
+		document("03-Logo-Concept-and-Colors.txt", "Logo concept, color palette, contrast and dark-mode guidance")
+		document("03-Color-Palette.json", "Palette with RGB/HSL/CMYK values, tints, shades and contrast checks")
+		if identity.Typography != nil {
+			document("03-Typography.txt", "Font pairing, weights, type scale and usage rules")
+		}
+		for _, format := range designTokenFormats {
+			document(designTokensFolder+"/"+format.Filename, designTokenDescriptions[format.ID])
+		}
+		stored("04-Logo-Concept.jpg", "Generated logo concept image")
+		for _, variant := range identity.LogoVariants {
+			stored(logoVariantFilename(variant), fmt.Sprintf("%s (%dx%d)", variant.Usage, variant.Width, variant.Height))
 		}
 	}
 
 	if len(brief.Results.Ads) > 0 {
 		manifest += "\n📢 ADVERTISEMENTS:\n"
-		for i := range brief.Results.Ads {
-			manifest += fmt.Sprintf("• 05-Ads/Ad-%d-Content.txt - Advertisement copy and details\n", i+1)
-			manifest += fmt.Sprintf("• 05-Ads/Ad-%d-Image.jpg - Advertisement image\n", i+1)
+		for i, ad := range brief.Results.Ads {
+			document(fmt.Sprintf("05-Ads/Ad-%d-Content.txt", i+1), "Advertisement copy and details")
+			stored(fmt.Sprintf("05-Ads/Ad-%d-Image.jpg", i+1), "Advertisement image")
+			for _, creative := range ad.Creatives {
+				if creative.Layout == cleanCreativeLayout {
+					stored(adCreativeFilename(i, creative), "Text-free image for responsive ads")
+					continue
+				}
+				stored(adCreativeFilename(i, creative), fmt.Sprintf("Ready-to-post %s creative", creative.Layout))
+			}
 		}
 	}
 
-	manifest += fmt.Sprintf("\nTOTAL ASSETS: %d files\n", s.countTotalAssets(brief))
+	if len(brief.Results.VideoAds) > 0 {
+		manifest += "\n🎬 VIDEO STORYBOARDS:\n"
+		for _, video := range brief.Results.VideoAds {
+			folder := videoExportFolder(video)
+			document(folder+"/Script.txt", fmt.Sprintf("%ds script with timed scenes and voiceover", video.Duration))
+			stored(folder+"/Subtitles.srt", "Voiceover subtitles")
+			stored(folder+"/Storyboard.png", "Contact sheet of every scene")
+			stored(folder+"/Animatic.mp4", fmt.Sprintf("%ds rough cut with captions and voiceover", video.Duration))
+			stored(folder+"/Thumbnail.png", "Poster frame")
+			for i, scene := range video.Scenes {
+				stored(fmt.Sprintf("%s/Scene-%02d.png", folder, i+1), fmt.Sprintf("Keyframe for scene %d", i+1))
+				stored(fmt.Sprintf("%s/Voiceover-Scene-%02d.wav", folder, i+1), fmt.Sprintf("Voiceover for scene %d (%.1fs, %s voice)", i+1, scene.VoiceoverDuration, video.Voice))
+			}
+		}
+	}
+
+	manifest += missingAssetsText(missing)
+	manifest += fmt.Sprintf("\nTOTAL ASSETS: %d files\n", total)
 	manifest += "\nNOTE: All images are high-resolution and suitable for both digital and print use.\n"
 
 	file, err := zipWriter.Create("00-README.txt")
@@ -319,28 +674,251 @@ INCLUDED FILES:
 	return err
 }
 
-// addImageToZip downloads an image and adds it to the ZIP
-func (s *ExportService) addImageToZip(zipWriter *zip.Writer, imageURL string, filename string) error {
-	// Download the image
-	resp, err := http.Get(imageURL)
+// exportFetchWorkers caps how many stored files an export downloads at once
+const exportFetchWorkers = 8
+
+// exportAsset is a stored file copied into a ZIP export
+type exportAsset struct {
+	object   string // GCS object name; empty for legacy records, which only have url
+	url      string
+	filename string // path inside the ZIP
+}
+
+// missingAsset is a file an export meant to include but could not read
+type missingAsset struct {
+	filename string
+	err      error
+}
+
+// adCreativeFilename is the ZIP path of the i-th ad's finished creative or text-free image
+func adCreativeFilename(i int, creative models.AdCreative) string {
+	if creative.Layout == cleanCreativeLayout {
+		return fmt.Sprintf("05-Ads/Ad-%d-Image-%s.png", i+1, creative.Size)
+	}
+	return fmt.Sprintf("05-Ads/Ad-%d-Creative-%s.png", i+1, creative.Size)
+}
+
+// storedObject is the full GCS object name for a stored file, or "" when the record predates object names
+func storedObject(objectName, ext string) string {
+	if objectName == "" {
+		return ""
+	}
+	return objectName + ext
+}
+
+// zipExportAssets lists the stored files a brand kit ZIP bundles, in archive order
+func zipExportAssets(brief *models.BrandBrief) []exportAsset {
+	var assets []exportAsset
+	add := func(object, url, filename string) {
+		if object != "" || url != "" {
+			assets = append(assets, exportAsset{object: object, url: url, filename: filename})
+		}
+	}
+
+	if identity := brief.Results.BrandIdentity; identity != nil {
+		add(storedObject(identity.LogoObjectName, ".png"), identity.LogoImageURL, "04-Logo-Concept.jpg")
+		for _, variant := range identity.LogoVariants {
+			add(storedObject(variant.ObjectName, "."+variant.Format), variant.ImageURL, logoVariantFilename(variant))
+		}
+	}
+
+	for i, ad := range brief.Results.Ads {
+		add(storedObject(ad.ObjectName, ".png"), ad.ImageURL, fmt.Sprintf("05-Ads/Ad-%d-Image.jpg", i+1))
+		for _, creative := range ad.Creatives {
+			add(storedObject(creative.ObjectName, ".png"), creative.ImageURL, adCreativeFilename(i, creative))
+		}
+	}
+
+	for _, video := range brief.Results.VideoAds {
+		folder := videoExportFolder(video)
+		add(storedObject(video.SubtitlesObjectName, ".srt"), video.SubtitlesURL, folder+"/Subtitles.srt")
+		add(storedObject(video.StoryboardObjectName, ".png"), video.StoryboardURL, folder+"/Storyboard.png")
+		add(storedObject(video.VideoObjectName, ".mp4"), video.VideoURL, folder+"/Animatic.mp4")
+		add(storedObject(video.ThumbnailObjectName, ".png"), video.ThumbnailURL, folder+"/Thumbnail.png")
+		for i, scene := range video.Scenes {
+			add(storedObject(scene.KeyframeObjectName, ".png"), scene.KeyframeURL, fmt.Sprintf("%s/Scene-%02d.png", folder, i+1))
+			add(storedObject(scene.VoiceoverObjectName, ".wav"), scene.VoiceoverURL, fmt.Sprintf("%s/Voiceover-Scene-%02d.wav", folder, i+1))
+		}
+	}
+
+	return assets
+}
+
+// addAssetsToZip reads assets in parallel and writes them to the ZIP in order. Files that can't be read
+// are left out and returned so the export can say what is missing; an error means the ZIP itself could
+// not be written
+func (s *ExportService) addAssetsToZip(ctx context.Context, zipWriter *zip.Writer, assets []exportAsset) ([]missingAsset, error) {
+	var missing []missingAsset
+	err := fetchInOrder(ctx, assets, exportFetchWorkers, s.fetchExportAsset, func(asset exportAsset, data []byte, err error) error {
+		if err != nil {
+			log.Printf("⚠️ EXPORT: Failed to add %s to ZIP: %v", asset.filename, err)
+			missing = append(missing, missingAsset{filename: asset.filename, err: err})
+			return nil
+		}
+		file, err := zipWriter.Create(asset.filename)
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(data)
+		return err
+	})
+	return missing, err
+}
+
+// fetchExportAsset reads one stored file for an export
+func (s *ExportService) fetchExportAsset(ctx context.Context, asset exportAsset) ([]byte, error) {
+	return s.readExportFile(ctx, asset.object, asset.url)
+}
+
+// readExportFile reads a stored file through the storage client by object name. Only legacy records
+// without an object name fall back to the URL, a signed link that stops working after a day
+func (s *ExportService) readExportFile(ctx context.Context, object string, fileURL string) ([]byte, error) {
+	if object == "" {
+		if fileURL == "" {
+			return nil, fmt.Errorf("no stored file")
+		}
+		return downloadFile(ctx, fileURL)
+	}
+	if s.storage == nil {
+		return nil, fmt.Errorf("storage is not configured to read %s", object)
+	}
+
+	reader, err := s.storage.Bucket(s.bucketName).Object(object).NewReader(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to download image: %w", err)
+		return nil, fmt.Errorf("failed to open %s: %w", object, err)
 	}
-	defer resp.Body.Close()
+	defer reader.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download image: status %d", resp.StatusCode)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", object, err)
 	}
+	return data, nil
+}
 
-	// Create file in ZIP
-	file, err := zipWriter.Create(filename)
+// missingAssetsText lists the files an export left out and why, for its README
+func missingAssetsText(missing []missingAsset) string {
+	if len(missing) == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("\n⚠️ FILES NOT INCLUDED (%d):\nThese could not be read from storage. Export again later, or regenerate the assets if they are still missing.\n", len(missing))
+	for _, asset := range missing {
+		text += fmt.Sprintf("• %s - %v\n", asset.filename, asset.err)
+	}
+	return text
+}
+
+// fetchedAsset is one download's outcome
+type fetchedAsset struct {
+	data []byte
+	err  error
+}
+
+// fetchInOrder runs fetch over assets with up to workers downloads in flight and hands each result to
+// write in the assets' order. A download slot frees up only once its result is written, so at most
+// workers files are held in memory however large the export
+func fetchInOrder(ctx context.Context, assets []exportAsset, workers int,
+	fetch func(context.Context, exportAsset) ([]byte, error),
+	write func(exportAsset, []byte, error) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]chan fetchedAsset, len(assets))
+	for i := range results {
+		results[i] = make(chan fetchedAsset, 1)
+	}
+	slots := make(chan struct{}, workers)
+
+	go func() {
+		for i, asset := range assets {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func(i int, asset exportAsset) {
+				data, err := fetch(ctx, asset)
+				results[i] <- fetchedAsset{data: data, err: err}
+			}(i, asset)
+		}
+	}()
+
+	for i, asset := range assets {
+		var result fetchedAsset
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := write(asset, result.data, result.err); err != nil {
+			return err
+		}
+		<-slots
+	}
+	return nil
+}
+
+// exportImages are the images embedded in document exports; ads is parallel to the brief's ads, with nil
+// entries for images that could not be loaded
+type exportImages struct {
+	logo image.Image
+	ads  []image.Image
+}
+
+// loadExportImages reads the logo and ad images in parallel, best effort
+func (s *ExportService) loadExportImages(ctx context.Context, brief *models.BrandBrief) exportImages {
+	images := exportImages{ads: make([]image.Image, len(brief.Results.Ads))}
+
+	// targets[i] is where assets[i] is stored once decoded
+	var assets []exportAsset
+	var targets []*image.Image
+	if identity := brief.Results.BrandIdentity; identity != nil && (identity.LogoObjectName != "" || identity.LogoImageURL != "") {
+		assets = append(assets, exportAsset{object: storedObject(identity.LogoObjectName, ".png"), url: identity.LogoImageURL, filename: "logo"})
+		targets = append(targets, &images.logo)
+	}
+	for i, ad := range brief.Results.Ads {
+		if ad.ObjectName == "" && ad.ImageURL == "" {
+			continue
+		}
+		assets = append(assets, exportAsset{object: storedObject(ad.ObjectName, ".png"), url: ad.ImageURL, filename: "image for ad " + ad.ID})
+		targets = append(targets, &images.ads[i])
+	}
+
+	next := 0
+	err := fetchInOrder(ctx, assets, exportFetchWorkers, s.fetchExportAsset, func(asset exportAsset, data []byte, err error) error {
+		target := targets[next]
+		next++
+		if err == nil {
+			*target, _, err = image.Decode(bytes.NewReader(data))
+		}
+		if err != nil {
+			log.Printf("⚠️ EXPORT: Failed to load %s: %v", asset.filename, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		log.Printf("⚠️ EXPORT: Stopped loading images: %v", err)
 	}
 
-	// Copy image data to ZIP file
-	_, err = io.Copy(file, resp.Body)
-	return err
+	return images
+}
+
+// downloadFile fetches a file's bytes over HTTP, for legacy records stored before object names
+func downloadFile(ctx context.Context, fileURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
 }
 
 // generateStrategyText creates a formatted text version of the brand strategy
@@ -418,77 +996,3 @@ Don'ts:
 
 	return content
 }
-
-// countTotalAssets counts the total number of files that will be included
-func (s *ExportService) countTotalAssets(brief *models.BrandBrief) int {
-	count := 2 // Always include manifest and strategy
-
-	if brief.Results.BrandNames != nil && len(brief.Results.BrandNames) > 0 {
-		count++ // Brand names document
-	}
-
-	if brief.Results.BrandIdentity != nil {
-		count++ // Logo concept document
-		if brief.Results.BrandIdentity.LogoImageURL != "" {
-			count++ // Logo image
-		}
-	}
-
-	// Count ad assets (text + image for each ad)
-	count += len(brief.Results.Ads) * 2
-
-	return count
-}
-
-// GenerateCanvaExport generates Canva-compatible export data
-func (s *ExportService) GenerateCanvaExport(ctx context.Context, briefID string, userID string) (*CanvaExportData, error) {
-	// Placeholder for Canva integration
-	// This would integrate with Canva's API to create pre-populated designs
-	return nil, fmt.Errorf("Canva export not yet implemented")
-}
-
-// GenerateMetaAdsExport generates Meta Ads Manager compatible export
-func (s *ExportService) GenerateMetaAdsExport(ctx context.Context, briefID string, userID string) (*MetaAdsExportData, error) {
-	// Placeholder for Meta Ads integration
-	// This would format ads for Facebook Ads Manager import
-	return nil, fmt.Errorf("Meta Ads export not yet implemented")
-}
-
-// Export data structures for future integrations
-type CanvaExportData struct {
-	DesignURL string         `json:"designUrl"`
-	Elements  []CanvaElement `json:"elements"`
-	Metadata  ExportMetadata `json:"metadata"`
-}
-
-type CanvaElement struct {
-	Type    string      `json:"type"` // "text", "image", "shape"
-	Content interface{} `json:"content"`
-	Style   interface{} `json:"style"`
-}
-
-type MetaAdsExportData struct {
-	CampaignName   string         `json:"campaignName"`
-	AdSets         []MetaAdSet    `json:"adSets"`
-	CreativeAssets []MetaCreative `json:"creativeAssets"`
-}
-
-type MetaAdSet struct {
-	Name           string `json:"name"`
-	TargetAudience string `json:"targetAudience"`
-	Budget         string `json:"budget"`
-	Optimization   string `json:"optimization"`
-}
-
-type MetaCreative struct {
-	Headline     string `json:"headline"`
-	Body         string `json:"body"`
-	ImageURL     string `json:"imageUrl"`
-	CallToAction string `json:"callToAction"`
-}
-
-type ExportMetadata struct {
-	CompanyName string    `json:"companyName"`
-	ExportDate  time.Time `json:"exportDate"`
-	Format      string    `json:"format"`
-}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bezz-backend/internal/models"
+)
+
+const (
+	exportStatusQueued    = "queued"
+	exportStatusRunning   = "running"
+	exportStatusCompleted = "completed"
+	exportStatusFailed    = "failed"
+
+	exportJobTimeout   = 30 * time.Minute // longest a background export may run
+	exportAbandonGrace = time.Minute      // extra time for a finished export to record its outcome before it counts as abandoned
+	exportLinkTTL      = time.Hour        // how long a signed download link stays valid
+)
+
+// ErrExportJobNotFound is returned for jobs that don't exist or belong to another user
+var ErrExportJobNotFound = errors.New("export not found")
+
+// StartExportJob records a batch export and builds it in the background, streaming the archive to GCS.
+// The returned job is queued; poll GetExportJob until it completes to get its download link. Requests the
+// user can fix fail with ErrUnsupportedExportFormat, ErrNothingToExport, ErrBriefNotFound or ErrBriefAccessDenied.
+//
+// The export runs in a goroutine on the instance that accepted the request, which relies on the backend's
+// Cloud Run service keeping CPU allocated after the response (run.googleapis.com/cpu-throttling: "false").
+// An instance can still be shut down mid-export; such jobs are failed once they pass exportJobTimeout.
+func (s *ExportService) StartExportJob(ctx context.Context, briefID string, userID string, format string, template string) (*models.ExportJob, error) {
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedExportFormat, format)
+	}
+
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch brief: %w", err)
+	}
+	if brief.Results == nil {
+		return nil, ErrNothingToExport
+	}
+
+	jobID := generateID()
+	job := &models.ExportJob{
+		ID:          jobID,
+		UserID:      userID,
+		BriefID:     briefID,
+		CompanyName: brief.CompanyName,
+		Format:      format,
+		Status:      exportStatusQueued,
+		ObjectName:  fmt.Sprintf("exports/%s/%s", userID, jobID),
+		Filename:    batchExportFilename(brief, format),
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
+	}
+	if format == "pdf" {
+		job.Template = template
+	}
+
+	if _, err := s.db.Collection("exports").Doc(job.ID).Set(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save export: %w", err)
+	}
+
+	log.Printf("📦 EXPORT: Queued %s export %s for brief %s", format, job.ID, briefID)
+	go s.runExportJob(job, brief)
+
+	return job, nil
+}
+
+// runExportJob builds the export and records the outcome on the job
+func (s *ExportService) runExportJob(job *models.ExportJob, brief *models.BrandBrief) {
+	// The timeout starts now, so abandonment is measured from startedAt rather than from when the job was queued
+	startedAt := time.Now()
+	started := s.updateExportJob(job.ID, func(stored *models.ExportJob) []firestore.Update {
+		if stored.Status != exportStatusQueued {
+			return nil
+		}
+		return []firestore.Update{
+			{Path: "status", Value: exportStatusRunning},
+			{Path: "startedAt", Value: startedAt},
+		}
+	})
+	if !started {
+		log.Printf("⚠️ EXPORT: Export %s is no longer queued, not running it", job.ID)
+		return
+	}
+
+	// Status updates use their own context so a timed-out export can still be marked failed
+	ctx, cancel := context.WithTimeout(context.Background(), exportJobTimeout)
+	defer cancel()
+
+	size, err := s.storeExport(ctx, job, brief)
+	completedAt := time.Now()
+	if err != nil {
+		log.Printf("❌ EXPORT: Export %s failed: %v", job.ID, err)
+		s.updateExportJob(job.ID, func(*models.ExportJob) []firestore.Update {
+			return []firestore.Update{
+				{Path: "status", Value: exportStatusFailed},
+				{Path: "error", Value: err.Error()},
+				{Path: "completedAt", Value: completedAt},
+			}
+		})
+		return
+	}
+
+	if !s.updateExportJob(job.ID, func(*models.ExportJob) []firestore.Update {
+		return []firestore.Update{
+			{Path: "status", Value: exportStatusCompleted},
+			{Path: "size", Value: size},
+			{Path: "completedAt", Value: completedAt},
+		}
+	}) {
+		log.Printf("⚠️ EXPORT: Export %s finished after it was marked failed", job.ID)
+		return
+	}
+	log.Printf("✅ EXPORT: Export %s stored (%d bytes in %s)", job.ID, size, completedAt.Sub(startedAt).Round(time.Second))
+}
+
+// storeExport writes the export straight into its GCS object and returns the stored size
+func (s *ExportService) storeExport(ctx context.Context, job *models.ExportJob, brief *models.BrandBrief) (int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := s.storage.Bucket(s.bucketName).Object(job.ObjectName).NewWriter(ctx)
+	writer.ContentType = job.ContentType
+	writer.ContentDisposition = fmt.Sprintf("attachment; filename=%q", job.Filename)
+
+	if err := s.writeBatchExport(ctx, writer, brief, job.Format, job.Template); err != nil {
+		cancel() // abandon the upload so no partial export is left behind
+		writer.Close()
+		return 0, err
+	}
+	if err := writer.Close(); err != nil {
+		return 0, fmt.Errorf("failed to store export: %w", err)
+	}
+	return writer.Attrs().Size, nil
+}
+
+// updateExportJob applies the updates change returns for the stored job, in a transaction and only while the job
+// is queued or running, so a late completion can't overwrite a timeout or the reverse. It reports whether
+// the job was updated, logging rather than failing since the export itself is done.
+func (s *ExportService) updateExportJob(jobID string, change func(job *models.ExportJob) []firestore.Update) bool {
+	ref := s.db.Collection("exports").Doc(jobID)
+	updated := false
+	err := s.db.RunTransaction(context.Background(), func(ctx context.Context, tx *firestore.Transaction) error {
+		updated = false
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		var job models.ExportJob
+		if err := doc.DataTo(&job); err != nil {
+			return err
+		}
+		if job.Status != exportStatusQueued && job.Status != exportStatusRunning {
+			return nil
+		}
+
+		updates := change(&job)
+		if updates == nil {
+			return nil
+		}
+		updated = true
+		return tx.Update(ref, updates)
+	})
+	if err != nil {
+		log.Printf("⚠️ EXPORT: Failed to update export %s: %v", jobID, err)
+		return false
+	}
+	return updated
+}
+
+// GetExportJob returns one of the user's exports, with a fresh download link once it has completed
+func (s *ExportService) GetExportJob(ctx context.Context, userID string, jobID string) (*models.ExportJob, error) {
+	doc, err := s.db.Collection("exports").Doc(jobID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrExportJobNotFound
+		}
+		return nil, err
+	}
+
+	var job models.ExportJob
+	if err := doc.DataTo(&job); err != nil {
+		return nil, err
+	}
+
+	// Don't reveal other users' exports
+	if job.UserID != userID {
+		return nil, ErrExportJobNotFound
+	}
+
+	s.failAbandonedExportJob(&job)
+	s.signExportLink(&job)
+	return &job, nil
+}
+
+// ListExportJobs returns the user's exports newest first, optionally only those for one brief, so past
+// exports can be downloaded again
+func (s *ExportService) ListExportJobs(ctx context.Context, userID string, briefID string) ([]models.ExportJob, error) {
+	query := s.db.Collection("exports").Where("userId", "==", userID)
+	if briefID != "" {
+		query = query.Where("briefId", "==", briefID)
+	}
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	jobs := []models.ExportJob{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var job models.ExportJob
+		if err := doc.DataTo(&job); err != nil {
+			continue
+		}
+		s.failAbandonedExportJob(&job)
+		s.signExportLink(&job)
+		jobs = append(jobs, job)
+	}
+
+	// Sorted here rather than in the query to avoid needing a composite index
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// failAbandonedExportJob marks an export that is still unfinished after exportJobTimeout as failed. Exports run in
+// the instance that queued them, so one that restarted or was scaled down mid-export would otherwise stay
+// running forever.
+func (s *ExportService) failAbandonedExportJob(job *models.ExportJob) {
+	now := time.Now()
+	abandoned := *job
+	if abandonExportJob(&abandoned, now) == nil {
+		return
+	}
+
+	// Check again against the stored job in case it finished since it was read
+	if s.updateExportJob(job.ID, func(stored *models.ExportJob) []firestore.Update { return abandonExportJob(stored, now) }) {
+		log.Printf("⚠️ EXPORT: Export %s didn't finish within %s, marking it failed", job.ID, exportJobTimeout)
+		*job = abandoned
+	}
+}
+
+// abandonExportJob fails the job if it has been unfinished for longer than exportJobTimeout since it started
+// (or, if it never started, since it was queued), returning the updates to store, or nil if the job is
+// finished or may still be running
+func abandonExportJob(job *models.ExportJob, now time.Time) []firestore.Update {
+	if job.Status != exportStatusQueued && job.Status != exportStatusRunning {
+		return nil
+	}
+	since := job.CreatedAt
+	if job.StartedAt != nil {
+		since = *job.StartedAt
+	}
+	if now.Sub(since) <= exportJobTimeout+exportAbandonGrace {
+		return nil
+	}
+
+	job.Status = exportStatusFailed
+	job.Error = "export timed out"
+	job.CompletedAt = &now
+	return []firestore.Update{
+		{Path: "status", Value: job.Status},
+		{Path: "error", Value: job.Error},
+		{Path: "completedAt", Value: now},
+	}
+}
+
+// signExportLink gives a completed export a short-lived download link
+func (s *ExportService) signExportLink(job *models.ExportJob) {
+	if job.Status != exportStatusCompleted || job.ObjectName == "" {
+		return
+	}
+
+	expiresAt := time.Now().Add(exportLinkTTL)
+	url, err := s.storage.Bucket(s.bucketName).SignedURL(job.ObjectName, &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  "GET",
+		Expires: expiresAt,
+	})
+	if err != nil {
+		log.Printf("⚠️ EXPORT: Failed to sign download link for export %s: %v", job.ID, err)
+		return
+	}
+
+	job.DownloadURL = url
+	job.ExpiresAt = &expiresAt
+}
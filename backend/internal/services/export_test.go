@@ -0,0 +1,249 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestFetchInOrder_WritesInOrderWithBoundedDownloads(t *testing.T) {
+	var assets []exportAsset
+	for i := 0; i < 20; i++ {
+		assets = append(assets, exportAsset{filename: fmt.Sprintf("file-%02d", i)})
+	}
+
+	var inFlight, maxInFlight int32
+	fetch := func(ctx context.Context, asset exportAsset) ([]byte, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		// Later files finish first, so writing in order means waiting on slower downloads
+		var i int
+		fmt.Sscanf(asset.filename, "file-%d", &i)
+		time.Sleep(time.Duration(20-i) * time.Millisecond)
+		if asset.filename == "file-03" {
+			return nil, errors.New("gone")
+		}
+		return []byte(asset.filename), nil
+	}
+
+	var mu sync.Mutex
+	var written []string
+	err := fetchInOrder(context.Background(), assets, 4, fetch, func(asset exportAsset, data []byte, err error) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			written = append(written, asset.filename+" failed")
+			return nil
+		}
+		assert.Equal(t, asset.filename, string(data))
+		written = append(written, asset.filename)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, written, 20)
+	assert.Equal(t, "file-00", written[0])
+	assert.Equal(t, "file-03 failed", written[3], "a failed download is reported in its place")
+	assert.Equal(t, "file-19", written[19])
+	assert.LessOrEqual(t, maxInFlight, int32(4))
+	assert.Greater(t, maxInFlight, int32(1), "downloads run in parallel")
+}
+
+func TestFetchInOrder_StopsOnWriteError(t *testing.T) {
+	assets := make([]exportAsset, 10)
+	var fetched int32
+	fetch := func(ctx context.Context, asset exportAsset) ([]byte, error) {
+		atomic.AddInt32(&fetched, 1)
+		return nil, nil
+	}
+
+	writes := 0
+	err := fetchInOrder(context.Background(), assets, 2, fetch, func(exportAsset, []byte, error) error {
+		writes++
+		return errors.New("disk full")
+	})
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, 1, writes)
+	assert.Less(t, atomic.LoadInt32(&fetched), int32(10), "no more downloads start once writing fails")
+}
+
+func TestWriteZipExport_BundlesStoredFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.png" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "bytes of "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	brief := sampleBrandBookBrief()
+	brief.Results.BrandIdentity.LogoImageURL = server.URL + "/logo.png"
+	brief.Results.Ads[0].ImageURL = server.URL + "/ad.png"
+	brief.Results.Ads[0].Creatives = []models.AdCreative{{Size: "1080x1920", ImageURL: server.URL + "/story.png"}}
+	brief.Results.Ads[1].ImageURL = server.URL + "/missing.png"
+
+	var buf bytes.Buffer
+	require.NoError(t, NewExportService(nil, nil, nil, "").writeZipExport(context.Background(), &buf, brief))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(data)
+	}
+
+	assert.Equal(t, "bytes of /logo.png", files["04-Logo-Concept.jpg"])
+	assert.Equal(t, "bytes of /ad.png", files["05-Ads/Ad-1-Image.jpg"])
+	assert.Equal(t, "bytes of /story.png", files["05-Ads/Ad-1-Creative-1080x1920.png"])
+	assert.Contains(t, files, "05-Ads/Ad-2-Content.txt")
+	assert.NotContains(t, files, "05-Ads/Ad-2-Image.jpg")
+	assert.Contains(t, files["00-README.txt"], "Acme Coffee")
+	assert.Contains(t, files["00-README.txt"], "FILES NOT INCLUDED (1)")
+	assert.Contains(t, files["00-README.txt"], "• 05-Ads/Ad-2-Image.jpg - failed to download file: status 404")
+
+	// The manifest lists exactly what the archive holds
+	assert.Contains(t, files["00-README.txt"], "• 05-Ads/Ad-1-Image.jpg - Advertisement image")
+	assert.NotContains(t, files["00-README.txt"], "• 05-Ads/Ad-2-Image.jpg - Advertisement image")
+	assert.Contains(t, files["00-README.txt"], fmt.Sprintf("TOTAL ASSETS: %d files", len(archive.File)))
+}
+
+func TestLoadExportImages_PlacesEachImage(t *testing.T) {
+	sizes := map[string]int{"/logo.png": 10, "/ad.png": 20}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, ok := sizes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		png.Encode(w, image.NewRGBA(image.Rect(0, 0, size, size)))
+	}))
+	defer server.Close()
+
+	brief := sampleBrandBookBrief()
+	brief.Results.BrandIdentity.LogoImageURL = server.URL + "/logo.png"
+	brief.Results.Ads[0].ImageURL = server.URL + "/missing.png"
+	brief.Results.Ads[1].ImageURL = server.URL + "/ad.png"
+
+	images := NewExportService(nil, nil, nil, "").loadExportImages(context.Background(), brief)
+
+	require.NotNil(t, images.logo)
+	assert.Equal(t, 10, images.logo.Bounds().Dx())
+	require.Len(t, images.ads, len(brief.Results.Ads))
+	assert.Nil(t, images.ads[0], "images that can't be read are left out")
+	require.NotNil(t, images.ads[1])
+	assert.Equal(t, 20, images.ads[1].Bounds().Dx())
+}
+
+func TestWriteZipExport_StoredObjectsNeverFallBackToURLs(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		io.WriteString(w, "expired link")
+	}))
+	defer server.Close()
+
+	brief := sampleBrandBookBrief()
+	brief.Results.Ads[0].ObjectName = "ads/brief_1/ad_1"
+	brief.Results.Ads[0].ImageURL = server.URL + "/ad.png"
+
+	var buf bytes.Buffer
+	require.NoError(t, NewExportService(nil, nil, nil, "").writeZipExport(context.Background(), &buf, brief))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var manifest string
+	for _, f := range archive.File {
+		assert.NotEqual(t, "05-Ads/Ad-1-Image.jpg", f.Name)
+		if f.Name == "00-README.txt" {
+			r, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			manifest = string(data)
+		}
+	}
+
+	assert.Zero(t, atomic.LoadInt32(&requests), "records with an object name are read from storage, not their signed URL")
+	assert.Contains(t, manifest, "• 05-Ads/Ad-1-Image.jpg - storage is not configured to read ads/brief_1/ad_1.png")
+}
+
+func TestZipExportAssets_PrefersObjectNames(t *testing.T) {
+	brief := sampleBrandBookBrief()
+	identity := brief.Results.BrandIdentity
+	identity.LogoObjectName = "logos/brief_1"
+	identity.LogoImageURL = "https://storage.example.com/logos/brief_1.png?sig=old"
+	identity.LogoVariants = []models.LogoVariant{{Kind: "icon", Name: "Icon", Format: "svg", ObjectName: "logos/brief_1_icon"}}
+	brief.Results.Ads[1].ImageURL = "https://legacy.example.com/ad.png"
+	brief.Results.VideoAds = []models.VideoAd{{Duration: 15, AspectRatio: "9:16", VideoObjectName: "videos/v1", VideoURL: "https://storage.example.com/v1.mp4"}}
+
+	assets := zipExportAssets(brief)
+	require.Len(t, assets, 4)
+	assert.Equal(t, exportAsset{object: "logos/brief_1.png", url: identity.LogoImageURL, filename: "04-Logo-Concept.jpg"}, assets[0])
+	assert.Equal(t, "logos/brief_1_icon.svg", assets[1].object, "variants are read without needing a URL")
+	assert.Equal(t, exportAsset{url: "https://legacy.example.com/ad.png", filename: "05-Ads/Ad-2-Image.jpg"}, assets[2], "legacy records keep their URL")
+	assert.Equal(t, exportAsset{object: "videos/v1.mp4", url: "https://storage.example.com/v1.mp4", filename: "06-Video/15s-9x16/Animatic.mp4"}, assets[3])
+}
+
+func TestAbandonExportJob(t *testing.T) {
+	now := time.Now()
+
+	running := &models.ExportJob{Status: exportStatusRunning, CreatedAt: now.Add(-time.Minute)}
+	assert.Nil(t, abandonExportJob(running, now), "exports within the timeout may still finish")
+	assert.Equal(t, exportStatusRunning, running.Status)
+
+	done := &models.ExportJob{Status: exportStatusCompleted, CreatedAt: now.Add(-2 * exportJobTimeout)}
+	assert.Nil(t, abandonExportJob(done, now))
+
+	startedLate := now.Add(-time.Minute)
+	queuedLong := &models.ExportJob{Status: exportStatusRunning, CreatedAt: now.Add(-2 * exportJobTimeout), StartedAt: &startedLate}
+	assert.Nil(t, abandonExportJob(queuedLong, now), "the timeout runs from when the export started, not when it was queued")
+
+	stuck := &models.ExportJob{Status: exportStatusQueued, CreatedAt: now.Add(-exportJobTimeout - exportAbandonGrace - time.Minute)}
+	updates := abandonExportJob(stuck, now)
+	require.Len(t, updates, 3)
+	assert.Equal(t, exportStatusFailed, stuck.Status)
+	assert.Equal(t, "export timed out", stuck.Error)
+	require.NotNil(t, stuck.CompletedAt)
+	assert.Equal(t, now, *stuck.CompletedAt)
+}
+
+func TestStartExportJob_RejectsUnknownFormats(t *testing.T) {
+	_, err := NewExportService(nil, nil, nil, "").StartExportJob(context.Background(), "brief", "user", "docx", "")
+	assert.ErrorIs(t, err, ErrUnsupportedExportFormat)
+}
+
+func TestBatchExportFilename(t *testing.T) {
+	brief := &models.BrandBrief{CompanyName: "Acme Coffee"}
+	today := time.Now().Format("2006-01-02")
+	assert.Equal(t, "Acme-Coffee-brand-kit-"+today+".zip", batchExportFilename(brief, "zip"))
+	assert.Equal(t, "Acme-Coffee-brand-book-"+today+".pdf", batchExportFilename(brief, "pdf"))
+	assert.True(t, strings.HasSuffix(batchExportFilename(brief, "pptx"), "pitch-deck-"+today+".pptx"))
+}
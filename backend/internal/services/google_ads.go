@@ -0,0 +1,256 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"bezz-backend/internal/models"
+)
+
+// Google Ads Editor import settings and asset limits
+const (
+	googleDateLayout       = "2006-01-02"
+	googleAdsSheetName     = "google-ads-editor-import.csv"
+	googleHeadlineMax      = 30
+	googleLongHeadlineMax  = 90
+	googleDescriptionMax   = 90
+	googleBusinessNameMax  = 25
+	googlePathMax          = 15
+	googleRSAHeadlines     = 15
+	googleRSADescriptions  = 4
+	googleRDAHeadlines     = 5
+	googleRDADescriptions  = 5
+	googleRSAMinHeadlines  = 3
+	googleRSAMinDescs      = 2
+	googleDisplayPlacement = "google_display"
+)
+
+// googleAdsColumns are the Google Ads Editor CSV headers; each row fills the columns for one campaign,
+// location, ad group or ad
+var googleAdsColumns = func() []string {
+	columns := []string{
+		"Campaign", "Campaign Type", "Campaign Status", "Budget", "Budget type", "Bid Strategy Type",
+		"Start Date", "End Date", "Networks", "Location",
+		"Ad Group", "Ad Group Status", "Ad type", "Status",
+	}
+	for i := 1; i <= googleRSAHeadlines; i++ {
+		columns = append(columns, fmt.Sprintf("Headline %d", i))
+	}
+	for i := 1; i <= googleRDADescriptions; i++ {
+		columns = append(columns, fmt.Sprintf("Description %d", i))
+	}
+	return append(columns,
+		"Long headline", "Business name", "Final URL", "Path 1", "Path 2", "Call to action",
+		"Marketing image", "Square marketing image", "Logo image",
+	)
+}()
+
+// googleAdsExporter builds Google Ads Editor imports: responsive search ads from every ad's copy
+// and responsive display ads from the Google display ads
+type googleAdsExporter struct{}
+
+func (googleAdsExporter) Network() AdNetwork {
+	return AdNetwork{ID: "google", Name: "Google Ads Editor", Description: "Responsive search and display ads for Google Ads Editor"}
+}
+
+func (googleAdsExporter) Build(brief *models.BrandBrief, opts AdNetworkExportOptions) (*AdNetworkBundle, error) {
+	ads := networkAdsFor(brief)
+	if len(ads) == 0 {
+		return nil, fmt.Errorf("brief has no ads to export")
+	}
+
+	var displayAds []networkAd
+	for _, ad := range networkAdsFor(brief, "google") {
+		if _, ok := googleDisplayImage(ad, googleMarketingImage); ok {
+			displayAds = append(displayAds, ad)
+		}
+	}
+
+	company := brief.CompanyName
+	searchCampaign := company + " - Search"
+	displayCampaign := company + " - Display"
+	campaigns := []string{searchCampaign}
+	if len(displayAds) > 0 {
+		campaigns = append(campaigns, displayCampaign)
+	}
+
+	// Google budgets are daily
+	daily := splitBudget(math.Floor(opts.TotalBudget/float64(opts.Days)*100)/100, len(campaigns))
+	var rows []map[string]string
+	for i, campaign := range campaigns {
+		campaignType, networks := "Search", "Google search"
+		if campaign == displayCampaign {
+			campaignType, networks = "Display", "Display Network"
+		}
+		rows = append(rows, map[string]string{
+			"Campaign": campaign, "Campaign Type": campaignType, "Campaign Status": "Paused",
+			"Budget": strconv.FormatFloat(daily[i], 'f', 2, 64), "Budget type": "Daily", "Bid Strategy Type": "Maximize clicks",
+			"Start Date": opts.StartDate.Format(googleDateLayout), "End Date": opts.StartDate.AddDate(0, 0, opts.Days).Format(googleDateLayout),
+			"Networks": networks,
+		})
+		for _, country := range opts.Countries {
+			rows = append(rows, map[string]string{"Campaign": campaign, "Location": country})
+		}
+	}
+
+	path1 := truncateWords(strings.ReplaceAll(tokenSlug(company), "-", ""), googlePathMax)
+	strategy := brief.Results.Strategy
+	brandHeadlines := append([]string{strategy.Tagline, company}, strategy.BrandPillars...)
+	brandDescriptions := []string{strategy.ValueProposition, strategy.MessagingFramework.PrimaryMessage, strategy.Positioning}
+
+	// One responsive search ad per segment, pooling the segment's ad copy with the brand's lines
+	for _, group := range groupAdsBySegment(brief, ads) {
+		var headlines, descriptions []string
+		for _, ad := range group.ads {
+			headlines = append(headlines, ad.ad.Copy.Headline)
+			descriptions = append(descriptions, ad.ad.Copy.Body, ad.ad.Copy.Description)
+		}
+		headlines = collectCopy(append(headlines, brandHeadlines...), googleHeadlineMax, googleRSAHeadlines, googleRSAMinHeadlines, googleHeadline)
+		descriptions = collectCopy(append(descriptions, brandDescriptions...), googleDescriptionMax, googleRSADescriptions, googleRSAMinDescs, googleText)
+
+		adGroup := fmt.Sprintf("%s - %s", company, group.segment.Name)
+		rows = append(rows, map[string]string{"Campaign": searchCampaign, "Ad Group": adGroup, "Ad Group Status": "Paused"})
+		row := map[string]string{
+			"Campaign": searchCampaign, "Ad Group": adGroup, "Ad type": "Responsive search ad", "Status": "Paused",
+			"Final URL": opts.LandingURL, "Path 1": path1,
+		}
+		fillNumbered(row, "Headline", headlines)
+		fillNumbered(row, "Description", descriptions)
+		rows = append(rows, row)
+	}
+
+	bundle := &AdNetworkBundle{}
+	logo := ""
+	if identity := brief.Results.BrandIdentity; identity != nil && identity.LogoImageURL != "" && len(displayAds) > 0 {
+		logo = "logo" + imageURLExt(identity.LogoImageURL, ".png")
+		bundle.Images = append(bundle.Images, AdNetworkImage{Filename: logo, URL: identity.LogoImageURL, Object: storedObject(identity.LogoObjectName, ".png")})
+	}
+
+	// One responsive display ad per Google display ad, with its text-free 1.91:1 and square crops
+	for _, group := range groupAdsBySegment(brief, displayAds) {
+		adGroup := fmt.Sprintf("%s - %s", company, group.segment.Name)
+		rows = append(rows, map[string]string{"Campaign": displayCampaign, "Ad Group": adGroup, "Ad Group Status": "Paused"})
+		for _, ad := range group.ads {
+			img, _ := googleDisplayImage(ad, googleMarketingImage)
+			bundle.Images = append(bundle.Images, img)
+
+			adCopy := ad.ad.Copy
+			longHeadline := adCopy.Headline
+			if adCopy.Body != "" {
+				longHeadline = adCopy.Body
+			}
+			row := map[string]string{
+				"Campaign": displayCampaign, "Ad Group": adGroup, "Ad type": "Responsive display ad", "Status": "Paused",
+				"Long headline":   truncateWords(googleText(longHeadline), googleLongHeadlineMax),
+				"Business name":   truncateWords(company, googleBusinessNameMax),
+				"Final URL":       opts.LandingURL,
+				"Call to action":  matchCTA(adCopy.CTA, copyRulesFor(googleDisplayPlacement).CTAs),
+				"Marketing image": img.Filename,
+				"Logo image":      logo,
+			}
+			fillNumbered(row, "Headline", collectCopy([]string{adCopy.Headline, strategy.Tagline, company}, googleHeadlineMax, googleRDAHeadlines, 1, googleHeadline))
+			fillNumbered(row, "Description", collectCopy([]string{adCopy.Description, adCopy.Body, strategy.ValueProposition}, googleDescriptionMax, googleRDADescriptions, 1, googleText))
+
+			if square, ok := googleDisplayImage(ad, googleSquareMarketingImage); ok {
+				bundle.Images = append(bundle.Images, square)
+				row["Square marketing image"] = square.Filename
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	sheet, err := writeSheet(googleAdsColumns, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write Google Ads Editor sheet: %w", err)
+	}
+	bundle.Files = []AdNetworkFile{{Name: googleAdsSheetName, Data: sheet}}
+	bundle.Readme = googleAdsReadme(company, campaigns, daily, opts)
+	return bundle, nil
+}
+
+// Responsive display ad image sizes; Google overlays its own text, so these are the compositor's clean crops
+var (
+	googleMarketingImage       = CreativeSize{Width: 1200, Height: 628}
+	googleSquareMarketingImage = CreativeSize{Width: 1200, Height: 1200}
+)
+
+// googleDisplayImage is an ad's text-free crop at a responsive display ad size
+func googleDisplayImage(ad networkAd, size CreativeSize) (AdNetworkImage, bool) {
+	for _, creative := range ad.ad.Creatives {
+		if creative.Layout == cleanCreativeLayout && creative.Size == size.String() && creative.ImageURL != "" {
+			filename := fmt.Sprintf("ad-%d.png", ad.number)
+			if size == googleSquareMarketingImage {
+				filename = fmt.Sprintf("ad-%d-square.png", ad.number)
+			}
+			return AdNetworkImage{Filename: filename, URL: creative.ImageURL, Object: storedObject(creative.ObjectName, ".png")}, true
+		}
+	}
+	return AdNetworkImage{}, false
+}
+
+// googleText strips the characters Google's editorial policy rejects
+func googleText(text string) string {
+	banned := copyRulesFor(googleDisplayPlacement).BannedChars
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(banned, r) || isEmoji(r) {
+			return -1
+		}
+		return r
+	}, text))
+}
+
+// googleHeadline cleans a headline; Google headlines cannot contain exclamation marks
+func googleHeadline(text string) string {
+	return strings.TrimSpace(strings.ReplaceAll(googleText(text), "!", ""))
+}
+
+// collectCopy gathers up to want distinct, cleaned candidates that fit max characters; when fewer than
+// need fit, the longer candidates are shortened at a word boundary to make up the numbers
+func collectCopy(candidates []string, max, want, need int, clean func(string) string) []string {
+	var picked []string
+	seen := map[string]bool{}
+	add := func(text string) {
+		if len(picked) < want && fitsLimit(text, max) && !seen[strings.ToLower(text)] {
+			seen[strings.ToLower(text)] = true
+			picked = append(picked, text)
+		}
+	}
+	for _, candidate := range candidates {
+		add(clean(candidate))
+	}
+	for _, candidate := range candidates {
+		if len(picked) >= need {
+			break
+		}
+		add(truncateWords(clean(candidate), max))
+	}
+	return picked
+}
+
+// fillNumbered writes values into the numbered columns "<prefix> 1", "<prefix> 2", ...
+func fillNumbered(row map[string]string, prefix string, values []string) {
+	for i, value := range values {
+		row[fmt.Sprintf("%s %d", prefix, i+1)] = value
+	}
+}
+
+// googleAdsReadme explains how to import the bundle into Google Ads Editor
+func googleAdsReadme(companyName string, campaigns []string, daily []float64, opts AdNetworkExportOptions) string {
+	readme := adNetworkReadme("GOOGLE ADS EDITOR IMPORT", companyName, opts)
+	readme += "CAMPAIGNS:\n"
+	for i, campaign := range campaigns {
+		readme += fmt.Sprintf("• %s - daily budget %.2f\n", campaign, daily[i])
+	}
+
+	readme += fmt.Sprintf(`
+HOW TO IMPORT:
+1. In Google Ads Editor, open Account > Import > From file and choose %s
+2. Review the proposed changes; responsive display ads reference images in the %s/ folder,
+   so add them to the account's asset library with the same file names first
+3. Add keywords to the search ad groups - the sheet only carries the ads
+4. Locations use country codes; check they resolved, then post the paused campaigns
+`, googleAdsSheetName, adNetworkImagesFolder)
+	return readme
+}
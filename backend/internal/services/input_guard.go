@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// moderationActionSuspicious marks input that looks like a prompt-injection attempt; the brief is processed
+// with its inputs delimited and queued for review
+const moderationActionSuspicious = "suspicious"
+
+// ErrPromptLeak is returned when every model's output repeated our prompt instructions
+var ErrPromptLeak = errors.New("model output leaked prompt instructions")
+
+// briefTextField is a free-text brief field; its length limit is the binding:"max" tag on BrandBriefRequest
+type briefTextField struct {
+	name     string
+	value    *string
+	required bool // binding:"required" only rejects empty input before sanitising
+}
+
+func briefTextFields(req *bezzmodels.BrandBriefRequest) []briefTextField {
+	return []briefTextField{
+		{name: "companyName", value: &req.CompanyName, required: true},
+		{name: "tone", value: &req.Tone, required: true},
+		{name: "targetAudience", value: &req.TargetAudience, required: true},
+		{name: "additionalInfo", value: &req.AdditionalInfo},
+	}
+}
+
+var excessBlankLines = regexp.MustCompile(`\n{3,}`)
+
+// SanitizeBriefRequest strips control, zero-width and bidi override characters from free-text fields,
+// collapses runs of blank lines and trims surrounding space
+func SanitizeBriefRequest(req *bezzmodels.BrandBriefRequest) {
+	for _, field := range briefTextFields(req) {
+		*field.value = sanitizeText(*field.value)
+	}
+}
+
+func sanitizeText(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r): // Cf covers zero-width and bidi formatting characters
+			return -1
+		}
+		return r
+	}, value)
+	return strings.TrimSpace(excessBlankLines.ReplaceAllString(cleaned, "\n\n"))
+}
+
+// ValidateBriefInputs re-checks the required free-text fields, since sanitising can empty them after binding
+func ValidateBriefInputs(req *bezzmodels.BrandBriefRequest) error {
+	for _, field := range briefTextFields(req) {
+		if field.required && *field.value == "" {
+			return fmt.Errorf("%s is required", field.name)
+		}
+	}
+	return nil
+}
+
+// injectionPatterns match text that addresses the model rather than describing a business
+var injectionPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"override", regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\b.{0,40}\b(previous|prior|above|earlier|all|any|your|the)\b.{0,20}\b(instructions?|prompts?|rules|directions|guidelines)\b`)},
+	{"role-change", regexp.MustCompile(`(?i)\b(you are now|from now on,? you|pretend (to be|you are)|act as (an? )?(ai|assistant|system|developer|dan)\b|new persona)`)},
+	{"prompt-extraction", regexp.MustCompile(`(?i)\b(reveal|print|show|repeat|output|leak)\b.{0,30}\b(system prompt|your (instructions|prompt)|initial prompt|hidden (instructions|prompt))`)},
+	{"jailbreak", regexp.MustCompile(`(?i)\b(jailbreak|developer mode|do anything now|dan mode)\b`)},
+	{"role-markup", regexp.MustCompile(`(?im)(<\s*/?\s*(system|assistant|user_input|im_start|im_end)\b|\[/?(system|inst)\]|^\s*(system|assistant)\s*:)`)},
+	{"output-hijack", regexp.MustCompile(`(?i)\b(respond|reply|answer)\s+(only\s+)?with\s+(exactly|the following|this)\b`)},
+}
+
+// detectPromptInjection flags brief fields containing instruction-like text
+func detectPromptInjection(req *bezzmodels.BrandBriefRequest) []bezzmodels.ModerationFlag {
+	var flags []bezzmodels.ModerationFlag
+	for _, field := range briefTextFields(req) {
+		var matched []string
+		for _, p := range injectionPatterns {
+			if p.pattern.MatchString(*field.value) {
+				matched = append(matched, "prompt-injection/"+p.name)
+			}
+		}
+		if len(matched) > 0 {
+			flags = append(flags, newModerationFlag("input", "brief", field.name, *field.value, matched, moderationActionSuspicious))
+		}
+	}
+	return flags
+}
+
+var userInputTag = regexp.MustCompile(`(?i)<\s*/?\s*user_input[^>]*>`)
+
+// delimitUserInput wraps customer-typed text in the tags prompts.UserInputRule describes, removing any such tags
+// from the text itself so it cannot close the block early
+func delimitUserInput(name, value string) string {
+	return fmt.Sprintf("<user_input name=%q>%s</user_input>", name, userInputTag.ReplaceAllString(value, ""))
+}
+
+// promptLeakMarkers are fragments of our prompts that never belong in generated brand content
+var promptLeakMarkers = []string{
+	"<user_input",
+	"typed by the customer",
+	"respond only with valid json",
+	"always respond with valid json",
+	"you are brief-gpt",
+	"you are strategist-gpt",
+	"you are creative-director-gpt",
+	"you are brand-name-gpt",
+	"you are logo-designer-gpt",
+	"you are video-director-gpt",
+	"you are copy-editor-gpt",
+	"you are safety-editor-gpt",
+	"you are vector-logo-gpt",
+}
+
+// leakedPromptMarkers returns the prompt fragments found in a model's output
+func leakedPromptMarkers(output string) []string {
+	lower := strings.ToLower(output)
+	var found []string
+	for _, marker := range promptLeakMarkers {
+		if strings.Contains(lower, marker) {
+			found = append(found, marker)
+		}
+	}
+	return found
+}
+
+// promptLeakFlags returns a flag with the given action when err is a prompt leak, and nil otherwise.
+// Stages the brief can't complete without block it; optional stages drop their output.
+func promptLeakFlags(stage, action string, err error) []bezzmodels.ModerationFlag {
+	if !errors.Is(err, ErrPromptLeak) {
+		return nil
+	}
+	return []bezzmodels.ModerationFlag{newModerationFlag(stage, stage, "", err.Error(), []string{"prompt-leak"}, action)}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bezz-backend/internal/models"
+)
+
+// LinkedIn Campaign Manager bulk upload settings and sponsored content limits
+const (
+	linkedInDateLayout      = "2006-01-02"
+	linkedInSheetName       = "linkedin-campaign-manager-bulk.csv"
+	linkedInIntroMax        = 600
+	linkedInHeadlineMax     = 200
+	linkedInDescriptionMax  = 300
+	linkedInSponsoredFormat = "Single Image"
+	linkedInPlacement       = "linkedin_sponsored"
+)
+
+// linkedInColumns are the Campaign Manager bulk sponsored content headers, one row per ad
+var linkedInColumns = []string{
+	"Campaign Group Name", "Campaign Name", "Campaign Objective", "Campaign Status", "Ad Format",
+	"Total Budget", "Start Date", "End Date", "Locations", "Audience",
+	"Ad Name", "Ad Status", "Introductory Text", "Headline", "Description", "Destination URL", "Call To Action",
+	"Image File Name",
+}
+
+// linkedInObjectives maps our ad objectives to Campaign Manager objectives
+var linkedInObjectives = []networkObjective{
+	{keywords: []string{"sale", "conversion", "purchase"}, objective: "Website conversions"},
+	{keywords: []string{"lead", "sign up", "signup"}, objective: "Lead generation"},
+	{keywords: []string{"traffic", "click", "visit"}, objective: "Website visits"},
+	{keywords: []string{"engagement", "interaction"}, objective: "Engagement"},
+	{keywords: []string{"awareness", "reach", "recognition"}, objective: "Brand awareness"},
+}
+
+// linkedInAdsExporter builds Campaign Manager bulk sponsored content sheets, one campaign per target segment
+type linkedInAdsExporter struct{}
+
+func (linkedInAdsExporter) Network() AdNetwork {
+	return AdNetwork{ID: "linkedin", Name: "LinkedIn Campaign Manager", Description: "Bulk sponsored content sheet and images for LinkedIn"}
+}
+
+func (linkedInAdsExporter) Build(brief *models.BrandBrief, opts AdNetworkExportOptions) (*AdNetworkBundle, error) {
+	ads := networkAdsFor(brief, "linkedin")
+	if len(ads) == 0 {
+		return nil, fmt.Errorf("brief has no LinkedIn ads to export")
+	}
+
+	company := brief.CompanyName
+	group := fmt.Sprintf("%s - %s", company, opts.StartDate.Format("Jan 2006"))
+	objective := matchObjective(ads, linkedInObjectives).objective
+	segments := groupAdsBySegment(brief, ads)
+	budgets := splitBudget(opts.TotalBudget, len(segments))
+
+	bundle := &AdNetworkBundle{}
+	var rows []map[string]string
+	campaigns := make([]string, len(segments))
+	for i, segment := range segments {
+		campaigns[i] = fmt.Sprintf("%s - %s", company, segment.segment.Name)
+		for _, ad := range segment.ads {
+			row := map[string]string{
+				"Campaign Group Name": group,
+				"Campaign Name":       campaigns[i],
+				"Campaign Objective":  objective,
+				"Campaign Status":     "Draft",
+				"Ad Format":           linkedInSponsoredFormat,
+				"Total Budget":        strconv.FormatFloat(budgets[i], 'f', 2, 64),
+				"Start Date":          opts.StartDate.Format(linkedInDateLayout),
+				"End Date":            opts.StartDate.AddDate(0, 0, opts.Days).Format(linkedInDateLayout),
+				"Locations":           strings.Join(opts.Countries, ", "),
+				"Audience":            strings.Trim(strings.Join([]string{segment.segment.Role, segment.segment.Demographics}, ", "), ", "),
+				"Ad Name":             fmt.Sprintf("%s - Ad %d", company, ad.number),
+				"Ad Status":           "Draft",
+				"Introductory Text":   truncateWords(ad.ad.Copy.Body, linkedInIntroMax),
+				"Headline":            truncateWords(ad.ad.Copy.Headline, linkedInHeadlineMax),
+				"Description":         truncateWords(ad.ad.Copy.Description, linkedInDescriptionMax),
+				"Destination URL":     opts.LandingURL,
+				"Call To Action":      matchCTA(ad.ad.Copy.CTA, copyRulesFor(linkedInPlacement).CTAs),
+			}
+			if img, ok := networkAdImage(ad); ok {
+				bundle.Images = append(bundle.Images, img)
+				row["Image File Name"] = img.Filename
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	sheet, err := writeSheet(linkedInColumns, rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write LinkedIn bulk sheet: %w", err)
+	}
+	bundle.Files = []AdNetworkFile{{Name: linkedInSheetName, Data: sheet}}
+
+	readme := adNetworkReadme("LINKEDIN CAMPAIGN MANAGER BULK UPLOAD", company, opts)
+	readme += fmt.Sprintf("CAMPAIGN GROUP: %s\nOBJECTIVE: %s\n\nCAMPAIGNS:\n", group, objective)
+	for i, campaign := range campaigns {
+		readme += fmt.Sprintf("• %s - total budget %.2f\n", campaign, budgets[i])
+	}
+	readme += fmt.Sprintf(`
+HOW TO IMPORT:
+1. In Campaign Manager, open the ad account and choose Bulk actions > Upload campaigns
+2. Upload %s, then attach the matching image from the %s/ folder to each ad
+3. Everything uploads as drafts - refine the audience targeting for each campaign, then launch
+`, linkedInSheetName, adNetworkImagesFolder)
+	bundle.Readme = readme
+
+	return bundle, nil
+}
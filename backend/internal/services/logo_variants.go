@@ -0,0 +1,451 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"sort"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+const (
+	variantIconSize      = 1024 // square icon mark
+	variantWordmarkH     = 400  // horizontal lockup height
+	variantPadding       = 0.1  // share of each side left empty around marks
+	avatarSafeArea       = 0.6  // avatars are cropped to circles, so the mark stays inside the central area
+	bandGapShare         = 0.03 // empty rows/columns needed to split a logo into separate parts
+	logoVariantFolderFmt = "%s_variants/%s"
+)
+
+var (
+	monoDark  = color.RGBA{R: 0x11, G: 0x11, B: 0x11, A: 0xff}
+	monoLight = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// Favicon sizes bundled into one ICO file
+var faviconSizes = []int{16, 32, 48}
+
+// renderedVariant is a logo variant ready to be stored
+type renderedVariant struct {
+	variant     bezzmodels.LogoVariant
+	data        []byte
+	contentType string
+}
+
+// BuildLogoVariants derives the logo suite from one raster logo: a transparent version, icon mark,
+// horizontal wordmark, monochrome versions and standard-size exports
+func BuildLogoVariants(logo image.Image, companyName string, palette []bezzmodels.Color) ([]renderedVariant, error) {
+	transparent := trimTransparent(removeBackground(logo))
+	if transparent.Bounds().Empty() {
+		return nil, fmt.Errorf("logo has no visible content after background removal")
+	}
+
+	primary := paletteColor(palette, "primary", monoDark)
+	icon := squareMark(transparent.SubImage(iconRegion(transparent)), variantIconSize, variantPadding, nil)
+	wordmark, err := renderWordmark(icon, companyName, primary)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []renderedVariant
+	addPNG := func(kind, name, usage string, img image.Image) error {
+		data, err := encodePNG(img)
+		if err != nil {
+			return err
+		}
+		b := img.Bounds()
+		variants = append(variants, renderedVariant{
+			variant: bezzmodels.LogoVariant{Kind: kind, Name: name, Usage: usage, Width: b.Dx(), Height: b.Dy(), Format: "png"},
+			data:    data, contentType: "image/png",
+		})
+		return nil
+	}
+
+	steps := []struct {
+		kind, name, usage string
+		img               image.Image
+	}{
+		{"transparent", "logo-transparent", "Primary logo on any background", transparent},
+		{"icon", "icon", "Square mark for small spaces and app icons", icon},
+		{"wordmark", "wordmark-horizontal", "Horizontal lockup for headers and letterheads", wordmark},
+		{"mono_dark", "logo-mono-dark", "Single-color logo for light backgrounds and print", recolor(transparent, monoDark)},
+		{"mono_light", "logo-mono-light", "Single-color logo for dark backgrounds and photos", recolor(transparent, monoLight)},
+		{"app_icon", "apple-touch-icon-180", "iOS home screen icon", squareMark(icon, 180, variantPadding, &monoLight)},
+		{"app_icon", "android-chrome-192", "Android and PWA icon", squareMark(icon, 192, 0, nil)},
+		{"app_icon", "android-chrome-512", "Android splash and PWA install icon", squareMark(icon, 512, 0, nil)},
+		{"avatar", "avatar-400", "Profile picture for X, LinkedIn and Facebook", avatar(icon, 400, primary)},
+		{"avatar", "avatar-1080", "Profile picture for Instagram and TikTok", avatar(icon, 1080, primary)},
+	}
+	for _, step := range steps {
+		if err := addPNG(step.kind, step.name, step.usage, step.img); err != nil {
+			return nil, err
+		}
+	}
+
+	favicon, err := encodeICO(icon, faviconSizes)
+	if err != nil {
+		return nil, err
+	}
+	variants = append(variants, renderedVariant{
+		variant: bezzmodels.LogoVariant{Kind: "favicon", Name: "favicon", Usage: "Browser tab icon (16, 32 and 48px)", Width: 48, Height: 48, Format: "ico"},
+		data:    favicon, contentType: "image/x-icon",
+	})
+
+	return variants, nil
+}
+
+// GenerateLogoVariants renders the logo suite for an identity and stores each variant next to the logo.
+// Failures are logged; the identity keeps whatever variants were stored.
+func (s *AIService) GenerateLogoVariants(ctx context.Context, identity *bezzmodels.BrandIdentity, companyName string) {
+	if identity == nil || identity.LogoObjectName == "" {
+		return
+	}
+
+	logo, err := s.readImageFromGCS(ctx, identity.LogoObjectName+".png")
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Could not load logo for variants: %v", err)
+		return
+	}
+
+	variants, err := BuildLogoVariants(logo, companyName, identity.ColorPalette)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Logo variant generation failed: %v", err)
+		return
+	}
+
+	for _, rendered := range variants {
+		v := rendered.variant
+		v.ObjectName = fmt.Sprintf(logoVariantFolderFmt, identity.LogoObjectName, v.Name)
+		v.ImageURL, err = s.uploadBytesToGCS(ctx, rendered.data, logoVariantObject(v), rendered.contentType)
+		if err != nil {
+			log.Printf("⚠️ AI PIPELINE: Failed to store logo variant %s: %v", v.Name, err)
+			continue
+		}
+		identity.LogoVariants = append(identity.LogoVariants, v)
+	}
+
+	log.Printf("✅ AI PIPELINE: Stored %d logo variants", len(identity.LogoVariants))
+}
+
+// logoVariantObject returns the full GCS object name of a stored variant
+func logoVariantObject(v bezzmodels.LogoVariant) string {
+	return v.ObjectName + "." + v.Format
+}
+
+// logoVariant returns the identity's variant of the given kind, if it has one
+func logoVariant(identity *bezzmodels.BrandIdentity, kind string) (bezzmodels.LogoVariant, bool) {
+	for _, v := range identity.LogoVariants {
+		if v.Kind == kind {
+			return v, true
+		}
+	}
+	return bezzmodels.LogoVariant{}, false
+}
+
+// removeBackground makes the solid background around a logo transparent. The background is flood-filled
+// from the border, so enclosed areas of the same color (the inside of an "o") are only cleared when
+// they touch the edge; pixels on the boundary get partial alpha to keep anti-aliased edges smooth.
+func removeBackground(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Src)
+
+	background, ok := detectBackground(out)
+	if !ok {
+		return out // already transparent, or no uniform background to remove
+	}
+
+	w, h := b.Dx(), b.Dy()
+	distance := func(x, y int) float64 {
+		c := out.NRGBAAt(x, y)
+		return rgbDistance([3]float64{float64(c.R), float64(c.G), float64(c.B)}, background)
+	}
+
+	visited := make([]bool, w*h)
+	queue := make([]image.Point, 0, 2*(w+h))
+	push := func(x, y int) {
+		if x < 0 || y < 0 || x >= w || y >= h || visited[y*w+x] {
+			return
+		}
+		visited[y*w+x] = true
+		if distance(x, y) < backgroundDistance {
+			queue = append(queue, image.Pt(x, y))
+		}
+	}
+	for x := 0; x < w; x++ {
+		push(x, 0)
+		push(x, h-1)
+	}
+	for y := 0; y < h; y++ {
+		push(0, y)
+		push(w-1, y)
+	}
+
+	for len(queue) > 0 {
+		p := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		out.SetNRGBA(p.X, p.Y, color.NRGBA{})
+		push(p.X+1, p.Y)
+		push(p.X-1, p.Y)
+		push(p.X, p.Y+1)
+		push(p.X, p.Y-1)
+	}
+
+	// Soften the edge: pixels next to removed background fade out the closer they are to the background color
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := out.NRGBAAt(x, y)
+			if c.A == 0 || !touchesTransparent(out, x, y) {
+				continue
+			}
+			if d := distance(x, y); d < 2*backgroundDistance {
+				c.A = uint8(float64(c.A) * (d - backgroundDistance) / backgroundDistance)
+				out.SetNRGBA(x, y, c)
+			}
+		}
+	}
+	return out
+}
+
+// touchesTransparent reports whether any 4-neighbour of (x, y) is fully transparent
+func touchesTransparent(img *image.NRGBA, x, y int) bool {
+	b := img.Bounds()
+	for _, d := range []image.Point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		p := image.Pt(x+d.X, y+d.Y)
+		if p.In(b) && img.NRGBAAt(p.X, p.Y).A == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// trimTransparent crops img to the bounding box of its visible pixels
+func trimTransparent(img *image.NRGBA) *image.NRGBA {
+	b := img.Bounds()
+	box := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.NRGBAAt(x, y).A > 0 {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	out := image.NewNRGBA(image.Rect(0, 0, box.Dx(), box.Dy()))
+	draw.Draw(out, out.Bounds(), img, box.Min, draw.Src)
+	return out
+}
+
+// span is a run of rows or columns that contain visible pixels
+type span struct{ start, end int }
+
+// contentSpans splits the image into runs of occupied rows (or columns) separated by empty gaps
+func contentSpans(img *image.NRGBA, columns bool) []span {
+	b := img.Bounds()
+	length, across := b.Dy(), b.Dx()
+	if columns {
+		length, across = across, length
+	}
+	minGap := max(1, int(float64(length)*bandGapShare))
+
+	var spans []span
+	start, gap := -1, 0
+	for i := 0; i < length; i++ {
+		occupied := false
+		for j := 0; j < across && !occupied; j++ {
+			x, y := b.Min.X+j, b.Min.Y+i
+			if columns {
+				x, y = b.Min.X+i, b.Min.Y+j
+			}
+			occupied = img.NRGBAAt(x, y).A > 0
+		}
+		switch {
+		case occupied && start < 0:
+			start, gap = i, 0
+		case occupied:
+			gap = 0
+		case start >= 0:
+			gap++
+			if gap >= minGap {
+				spans = append(spans, span{start, i - gap + 1})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, length - gap})
+	}
+	return spans
+}
+
+// iconRegion finds the symbol in a trimmed logo. A mark stacked above or below the wordmark is the
+// tallest row band; a mark beside the wordmark is the leading column group when it is roughly square
+// and separated from the lettering by a wider gap than the letters are from each other. Otherwise the
+// whole logo is the icon.
+func iconRegion(img *image.NRGBA) image.Rectangle {
+	b := img.Bounds()
+
+	if rows := contentSpans(img, false); len(rows) >= 2 {
+		sort.Slice(rows, func(i, j int) bool { return rows[i].end-rows[i].start > rows[j].end-rows[j].start })
+		if tallest, next := rows[0], rows[1]; tallest.end-tallest.start >= 2*(next.end-next.start) {
+			return trimmedRect(img, image.Rect(b.Min.X, b.Min.Y+tallest.start, b.Max.X, b.Min.Y+tallest.end))
+		}
+	}
+
+	cols := contentSpans(img, true)
+	if len(cols) < 2 {
+		return b
+	}
+	var gaps []int
+	for i := 1; i < len(cols); i++ {
+		gaps = append(gaps, cols[i].start-cols[i-1].end)
+	}
+	firstGap := gaps[0]
+	rest := append([]int(nil), gaps[1:]...)
+	sort.Ints(rest)
+	if len(rest) > 0 && firstGap < 2*rest[len(rest)/2] {
+		return b
+	}
+
+	region := trimmedRect(img, image.Rect(b.Min.X+cols[0].start, b.Min.Y, b.Min.X+cols[0].end, b.Max.Y))
+	aspect := float64(region.Dx()) / float64(region.Dy())
+	if aspect < 0.5 || aspect > 2 {
+		return b
+	}
+	return region
+}
+
+// trimmedRect shrinks r to the visible pixels inside it
+func trimmedRect(img *image.NRGBA, r image.Rectangle) image.Rectangle {
+	sub := img.SubImage(r).(*image.NRGBA)
+	trimmed := image.Rectangle{}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if sub.NRGBAAt(x, y).A > 0 {
+				trimmed = trimmed.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if trimmed.Empty() {
+		return r
+	}
+	return trimmed
+}
+
+// squareMark fits src into a size x size square with padding, on bg or transparent when bg is nil
+func squareMark(src image.Image, size int, padding float64, bg *color.RGBA) *image.NRGBA {
+	out := image.NewNRGBA(image.Rect(0, 0, size, size))
+	if bg != nil {
+		draw.Draw(out, out.Bounds(), image.NewUniform(*bg), image.Point{}, draw.Src)
+	}
+	inset := int(float64(size) * padding)
+	fitInto(out, image.Rect(inset, inset, size-inset, size-inset), src)
+	return out
+}
+
+// avatar places the mark in white or near-black on the primary color, inside the circle-safe area
+func avatar(icon image.Image, size int, primary color.RGBA) *image.NRGBA {
+	return squareMark(recolor(icon, readableTextColor(primary)), size, (1-avatarSafeArea)/2, &primary)
+}
+
+// fitInto scales src to fit box, preserving its aspect ratio, and centres it
+func fitInto(dst draw.Image, box image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	if sb.Empty() || box.Empty() {
+		return
+	}
+	scale := minFloat(float64(box.Dx())/float64(sb.Dx()), float64(box.Dy())/float64(sb.Dy()))
+	w, h := max(1, int(float64(sb.Dx())*scale)), max(1, int(float64(sb.Dy())*scale))
+	x0, y0 := box.Min.X+(box.Dx()-w)/2, box.Min.Y+(box.Dy()-h)/2
+	xdraw.CatmullRom.Scale(dst, image.Rect(x0, y0, x0+w, y0+h), src, sb, draw.Over, nil)
+}
+
+// recolor paints every visible pixel in col, keeping the alpha channel
+func recolor(src image.Image, col color.RGBA) *image.NRGBA {
+	b := src.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			a := color.NRGBAModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA).A
+			if a > 0 {
+				out.SetNRGBA(x, y, color.NRGBA{R: col.R, G: col.G, B: col.B, A: a})
+			}
+		}
+	}
+	return out
+}
+
+// renderWordmark lays the icon out beside the company name in the primary color
+func renderWordmark(icon image.Image, companyName string, primary color.RGBA) (*image.NRGBA, error) {
+	fonts, err := loadCreativeFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &creativeCanvas{unit: variantWordmarkH}
+	defer c.closeFaces()
+	face := c.face(fonts.bold, 0.32)
+
+	name := strings.TrimSpace(companyName)
+	pad := int(variantWordmarkH * variantPadding)
+	iconSize := variantWordmarkH - 2*pad
+	textWidth := font.MeasureString(face, name).Ceil()
+	width := pad + iconSize + pad/2 + textWidth + pad
+	if name == "" {
+		width = variantWordmarkH
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, width, variantWordmarkH))
+	fitInto(out, image.Rect(pad, pad, pad+iconSize, pad+iconSize), icon)
+
+	metrics := face.Metrics()
+	baseline := (variantWordmarkH + metrics.Ascent.Ceil() - metrics.Descent.Ceil()) / 2
+	d := &font.Drawer{Dst: out, Src: image.NewUniform(primary), Face: face, Dot: fixed.P(pad+iconSize+pad/2, baseline)}
+	d.DrawString(name)
+	return out, nil
+}
+
+// encodeICO packs PNG renditions of img at each size into a Windows ICO file
+func encodeICO(img image.Image, sizes []int) ([]byte, error) {
+	images := make([][]byte, len(sizes))
+	for i, size := range sizes {
+		data, err := encodePNG(squareMark(img, size, 0, nil))
+		if err != nil {
+			return nil, err
+		}
+		images[i] = data
+	}
+
+	var buf bytes.Buffer
+	// ICONDIR: reserved, type 1 (icon), image count
+	binary.Write(&buf, binary.LittleEndian, [3]uint16{0, 1, uint16(len(sizes))})
+
+	offset := 6 + 16*len(sizes)
+	for i, size := range sizes {
+		dim := uint8(size)
+		if size >= 256 {
+			dim = 0 // 0 means 256 in ICO headers
+		}
+		// ICONDIRENTRY: width, height, palette size, reserved, planes, bits per pixel, data size, data offset
+		binary.Write(&buf, binary.LittleEndian, struct {
+			Width, Height, Colors, Reserved uint8
+			Planes, BitCount                uint16
+			Size, Offset                    uint32
+		}{dim, dim, 0, 0, 1, 32, uint32(len(images[i])), uint32(offset)})
+		offset += len(images[i])
+	}
+	for _, data := range images {
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+// testLogo draws a navy square symbol to the left of three "letters" on a white background
+func testLogo() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 600, 200))
+	fillRect(img, img.Bounds(), color.White)
+	navy := color.RGBA{0x0a, 0x25, 0x40, 0xff}
+	fillRect(img, image.Rect(40, 40, 160, 160), navy)
+	for i := 0; i < 3; i++ {
+		x := 240 + i*100
+		fillRect(img, image.Rect(x, 80, x+80, 140), navy)
+	}
+	return img
+}
+
+func TestRemoveBackground_ClearsBorderConnectedBackground(t *testing.T) {
+	img := testLogo()
+	// A white hole inside the symbol is not connected to the border and must stay opaque
+	fillRect(img, image.Rect(90, 90, 110, 110), color.White)
+
+	out := removeBackground(img)
+	assert.Equal(t, uint8(0), out.NRGBAAt(5, 5).A)
+	assert.Equal(t, uint8(255), out.NRGBAAt(50, 50).A)
+	assert.Equal(t, uint8(255), out.NRGBAAt(100, 100).A)
+
+	trimmed := trimTransparent(out)
+	assert.Equal(t, image.Rect(0, 0, 480, 120), trimmed.Bounds())
+}
+
+func TestIconRegion_FindsSymbolBesideWordmark(t *testing.T) {
+	trimmed := trimTransparent(removeBackground(testLogo()))
+	assert.Equal(t, image.Rect(0, 0, 120, 120), iconRegion(trimmed))
+}
+
+func TestIconRegion_FindsSymbolAboveWordmark(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 400))
+	fillRect(img, img.Bounds(), color.White)
+	fillRect(img, image.Rect(140, 40, 260, 240), color.Black) // symbol
+	fillRect(img, image.Rect(60, 300, 340, 340), color.Black) // wordmark line
+
+	trimmed := trimTransparent(removeBackground(img))
+	region := iconRegion(trimmed)
+	assert.Equal(t, 120, region.Dx())
+	assert.Equal(t, 200, region.Dy())
+}
+
+func TestIconRegion_WholeLogoWhenNoSeparateSymbol(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 300))
+	fillRect(img, img.Bounds(), color.White)
+	fillRect(img, image.Rect(50, 50, 250, 250), color.Black)
+
+	trimmed := trimTransparent(removeBackground(img))
+	assert.Equal(t, trimmed.Bounds(), iconRegion(trimmed))
+}
+
+func TestBuildLogoVariants_ProducesFullSuite(t *testing.T) {
+	variants, err := BuildLogoVariants(testLogo(), "Acme", []models.Color{{Hex: "#0A2540", Usage: "primary"}})
+	require.NoError(t, err)
+
+	byName := make(map[string]renderedVariant)
+	kinds := make(map[string]bool)
+	for _, v := range variants {
+		byName[v.variant.Name] = v
+		kinds[v.variant.Kind] = true
+	}
+	for _, kind := range []string{"transparent", "icon", "wordmark", "mono_dark", "mono_light", "favicon", "app_icon", "avatar"} {
+		assert.True(t, kinds[kind], kind)
+	}
+
+	icon := byName["icon"]
+	assert.Equal(t, variantIconSize, icon.variant.Width)
+	assert.Equal(t, variantIconSize, icon.variant.Height)
+
+	wordmark := byName["wordmark-horizontal"]
+	assert.Equal(t, variantWordmarkH, wordmark.variant.Height)
+	assert.Greater(t, wordmark.variant.Width, wordmark.variant.Height)
+
+	// The mono light version keeps the shape but is pure white
+	mono, err := png.Decode(bytes.NewReader(byName["logo-mono-light"].data))
+	require.NoError(t, err)
+	assert.Equal(t, color.NRGBA{0xff, 0xff, 0xff, 0xff}, color.NRGBAModel.Convert(mono.At(60, 60)))
+	assert.Equal(t, uint8(0), color.NRGBAModel.Convert(mono.At(150, 10)).(color.NRGBA).A)
+
+	// iOS fills transparency with black, so the apple touch icon is opaque
+	touch, err := png.Decode(bytes.NewReader(byName["apple-touch-icon-180"].data))
+	require.NoError(t, err)
+	assert.Equal(t, color.NRGBA{0xff, 0xff, 0xff, 0xff}, color.NRGBAModel.Convert(touch.At(1, 1)))
+
+	assert.Equal(t, "image/x-icon", byName["favicon"].contentType)
+	assert.Equal(t, "ico", byName["favicon"].variant.Format)
+}
+
+func TestEncodeICO_Header(t *testing.T) {
+	icon := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	data, err := encodeICO(icon, []int{16, 32, 256})
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint16{0, 1, 3}, []uint16{
+		binary.LittleEndian.Uint16(data[0:]), binary.LittleEndian.Uint16(data[2:]), binary.LittleEndian.Uint16(data[4:]),
+	})
+	assert.Equal(t, byte(16), data[6])
+	assert.Equal(t, byte(0), data[6+2*16], "256px entries are written as 0")
+
+	// The first image's offset points just past the directory, at a PNG signature
+	offset := binary.LittleEndian.Uint32(data[6+12:])
+	assert.Equal(t, uint32(6+3*16), offset)
+	assert.Equal(t, []byte("\x89PNG"), data[offset:offset+4])
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openai/openai-go/v2"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/vector"
+
+	bezzmodels "bezz-backend/internal/models"
+	"bezz-backend/internal/prompts"
+)
+
+const (
+	traceMaxSize     = 512  // logos are traced at most this many pixels on the long side
+	traceTolerance   = 0.75 // Douglas-Peucker tolerance in traced pixels
+	traceMinAccuracy = 0.9  // share of pixels the re-rendered trace must get right
+	traceMaxNodes    = 8000 // beyond this the logo is photographic and tracing isn't worth it
+)
+
+// VectorLogoGPTResponse is the JSON returned by Vector-Logo-GPT
+type VectorLogoGPTResponse struct {
+	SVG string `json:"svg"`
+}
+
+// GenerateVectorLogo stores an SVG version of the logo next to the PNG. The raster logo is traced
+// locally first; if the trace doesn't reproduce the logo faithfully, Vector-Logo-GPT writes one instead.
+// Either way the SVG is sanitised before it is stored.
+func (s *AIService) GenerateVectorLogo(ctx context.Context, identity *bezzmodels.BrandIdentity, companyName string) {
+	if identity == nil || identity.LogoObjectName == "" {
+		return
+	}
+
+	var svg []byte
+	source := "traced from the PNG logo"
+	logo, err := s.readImageFromGCS(ctx, identity.LogoObjectName+".png")
+	if err == nil {
+		svg, err = TraceLogoSVG(logo)
+	}
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Logo tracing failed, asking Vector-Logo-GPT instead: %v", err)
+		source = "drawn by hand as a vector interpretation of the logo concept"
+		svg, err = s.generateSVGLogo(ctx, identity, companyName)
+	}
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: No vector logo available: %v", err)
+		return
+	}
+
+	variant := bezzmodels.LogoVariant{
+		Kind:       "vector",
+		Name:       "logo",
+		Usage:      "Scalable logo for print, signage and large formats, " + source,
+		Format:     "svg",
+		ObjectName: fmt.Sprintf(logoVariantFolderFmt, identity.LogoObjectName, "logo"),
+	}
+	variant.Width, variant.Height = svgSize(svg)
+	variant.ImageURL, err = s.uploadBytesToGCS(ctx, svg, logoVariantObject(variant), "image/svg+xml")
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Failed to store vector logo: %v", err)
+		return
+	}
+
+	identity.LogoVariants = append(identity.LogoVariants, variant)
+	log.Printf("✅ AI PIPELINE: Stored vector logo (%d bytes, %s)", len(svg), source)
+}
+
+// generateSVGLogo asks Vector-Logo-GPT for a hand-written SVG and sanitises it
+func (s *AIService) generateSVGLogo(ctx context.Context, identity *bezzmodels.BrandIdentity, companyName string) ([]byte, error) {
+	colors := make([]string, len(identity.ColorPalette))
+	for i, c := range identity.ColorPalette {
+		colors[i] = fmt.Sprintf("%s %s (%s)", c.Name, c.Hex, c.Usage)
+	}
+	headingFont := defaultHeading
+	if identity.Typography != nil {
+		headingFont = identity.Typography.Heading.Family
+	}
+
+	prompt := fmt.Sprintf(prompts.VectorLogoGPTPrompt, delimitUserInput("company_name", companyName), identity.LogoConcept, strings.Join(colors, ", "), headingFont)
+	temperature := float64(0.4)
+	var response VectorLogoGPTResponse
+	if _, _, err := s.chatJSONWithFallback(ctx, []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("You are Vector-Logo-GPT. Always respond with valid JSON only."),
+		openai.UserMessage(prompt),
+	}, 4000, &temperature, &response); err != nil {
+		return nil, fmt.Errorf("Vector-Logo-GPT API call failed: %w", err)
+	}
+
+	return SanitizeSVG([]byte(response.SVG))
+}
+
+// TraceLogoSVG vectorises a raster logo: the background is removed, the remaining pixels are grouped
+// into flat color layers, and each layer's outline is traced and simplified into SVG paths. The trace
+// is rendered back and compared with the source so a poor trace is rejected instead of shipped.
+func TraceLogoSVG(logo image.Image) ([]byte, error) {
+	src := trimTransparent(removeBackground(logo))
+	if src.Bounds().Empty() {
+		return nil, fmt.Errorf("logo has no visible content")
+	}
+	src = downscaleForTrace(src)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+
+	labels, layerColors := quantizeLayers(src)
+	if len(layerColors) == 0 {
+		return nil, fmt.Errorf("logo has no visible content")
+	}
+
+	var paths []tracedLayer
+	nodes := 0
+	for layer, col := range layerColors {
+		loops := traceMask(labels, w, h, layer)
+		for i := range loops {
+			loops[i] = simplifyLoop(loops[i], traceTolerance)
+			nodes += len(loops[i])
+		}
+		paths = append(paths, tracedLayer{color: col, loops: loops})
+	}
+	if nodes > traceMaxNodes {
+		return nil, fmt.Errorf("trace needs %d nodes; the logo is too detailed to vectorise", nodes)
+	}
+
+	if accuracy := traceAccuracy(paths, labels, w, h); accuracy < traceMinAccuracy {
+		return nil, fmt.Errorf("trace reproduces only %.0f%% of the logo", accuracy*100)
+	}
+
+	var svg bytes.Buffer
+	fmt.Fprintf(&svg, `<svg xmlns="%s" viewBox="0 0 %d %d">`, svgNamespace, w, h)
+	for _, layer := range paths {
+		if len(layer.loops) == 0 {
+			continue
+		}
+		fmt.Fprintf(&svg, `<path fill="%s" fill-rule="evenodd" d="%s"/>`, hexString(layer.color), pathData(layer.loops))
+	}
+	svg.WriteString("</svg>")
+
+	// Our own output goes through the same sanitiser as model output
+	return SanitizeSVG(svg.Bytes())
+}
+
+// tracedLayer is one flat color of the logo and its outlines
+type tracedLayer struct {
+	color color.RGBA
+	loops [][]vectorPoint
+}
+
+// vectorPoint is a point on the pixel-corner grid
+type vectorPoint struct{ x, y float64 }
+
+// downscaleForTrace limits the tracing resolution; outlines are resolution independent anyway
+func downscaleForTrace(img *image.NRGBA) *image.NRGBA {
+	b := img.Bounds()
+	longest := max(b.Dx(), b.Dy())
+	if longest <= traceMaxSize {
+		return img
+	}
+	scale := float64(traceMaxSize) / float64(longest)
+	out := image.NewNRGBA(image.Rect(0, 0, max(1, int(float64(b.Dx())*scale)), max(1, int(float64(b.Dy())*scale))))
+	xdraw.ApproxBiLinear.Scale(out, out.Bounds(), img, b, draw.Src, nil)
+	return out
+}
+
+// quantizeLayers assigns every visible pixel to one of a few flat colors. It returns a label per
+// pixel (-1 for transparent) and the layer colors, largest layer first.
+func quantizeLayers(img *image.NRGBA) ([]int, []color.RGBA) {
+	b := img.Bounds()
+	var samples [][3]float64
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			if c := img.NRGBAAt(x, y); c.A >= 128 {
+				samples = append(samples, [3]float64{float64(c.R), float64(c.G), float64(c.B)})
+			}
+		}
+	}
+	if len(samples) == 0 {
+		return nil, nil
+	}
+
+	// Small clusters (anti-aliasing, noise) are dropped, so their pixels join the nearest layer
+	clusters := mergeClusters(kMeans(samples, paletteClusters), len(samples))
+	centers := make([][3]float64, len(clusters))
+	for i, c := range clusters {
+		centers[i] = c.center
+	}
+
+	labels := make([]int, b.Dx()*b.Dy())
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			c := img.NRGBAAt(x, y)
+			labels[y*b.Dx()+x] = -1
+			if c.A < 128 {
+				continue
+			}
+			p := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+			best, bestDist := 0, math.MaxFloat64
+			for i, center := range centers {
+				if d := rgbDistance(p, center); d < bestDist {
+					best, bestDist = i, d
+				}
+			}
+			labels[y*b.Dx()+x] = best
+		}
+	}
+
+	colors := make([]color.RGBA, len(centers))
+	for i, center := range centers {
+		colors[i] = color.RGBA{R: clampByte(center[0]), G: clampByte(center[1]), B: clampByte(center[2]), A: 0xff}
+	}
+	return labels, colors
+}
+
+// traceMask follows the boundary between pixels with the given label and everything else. Each inside
+// pixel contributes its outward-facing sides as directed edges (inside on the right), which are then
+// chained into closed loops; holes come out with the opposite winding to their outlines.
+func traceMask(labels []int, w, h, label int) [][]vectorPoint {
+	inside := func(x, y int) bool {
+		return x >= 0 && y >= 0 && x < w && y < h && labels[y*w+x] == label
+	}
+
+	type edge struct{ from, to image.Point }
+	outgoing := make(map[image.Point][]edge)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !inside(x, y) {
+				continue
+			}
+			if !inside(x, y-1) {
+				outgoing[image.Pt(x, y)] = append(outgoing[image.Pt(x, y)], edge{image.Pt(x, y), image.Pt(x+1, y)})
+			}
+			if !inside(x+1, y) {
+				outgoing[image.Pt(x+1, y)] = append(outgoing[image.Pt(x+1, y)], edge{image.Pt(x+1, y), image.Pt(x+1, y+1)})
+			}
+			if !inside(x, y+1) {
+				outgoing[image.Pt(x+1, y+1)] = append(outgoing[image.Pt(x+1, y+1)], edge{image.Pt(x+1, y+1), image.Pt(x, y+1)})
+			}
+			if !inside(x-1, y) {
+				outgoing[image.Pt(x, y+1)] = append(outgoing[image.Pt(x, y+1)], edge{image.Pt(x, y+1), image.Pt(x, y)})
+			}
+		}
+	}
+
+	// Walk edges in a stable order so the output is deterministic
+	starts := make([]image.Point, 0, len(outgoing))
+	for p := range outgoing {
+		starts = append(starts, p)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		if starts[i].Y != starts[j].Y {
+			return starts[i].Y < starts[j].Y
+		}
+		return starts[i].X < starts[j].X
+	})
+
+	var loops [][]vectorPoint
+	for _, start := range starts {
+		for len(outgoing[start]) > 0 {
+			var loop []vectorPoint
+			p := start
+			for {
+				edges := outgoing[p]
+				if len(edges) == 0 {
+					break
+				}
+				e := edges[len(edges)-1]
+				outgoing[p] = edges[:len(edges)-1]
+				loop = append(loop, vectorPoint{float64(e.from.X), float64(e.from.Y)})
+				p = e.to
+				if p == start && len(outgoing[start]) == 0 {
+					break
+				}
+			}
+			if len(loop) >= 3 {
+				loops = append(loops, loop)
+			}
+		}
+	}
+	return loops
+}
+
+// simplifyLoop applies Douglas-Peucker to a closed loop, which also removes the staircase's collinear points
+func simplifyLoop(loop []vectorPoint, tolerance float64) []vectorPoint {
+	if len(loop) < 4 {
+		return loop
+	}
+
+	// Split the loop at the point farthest from the first, simplify both halves
+	far, farDist := 0, -1.0
+	for i, p := range loop {
+		if d := math.Hypot(p.x-loop[0].x, p.y-loop[0].y); d > farDist {
+			far, farDist = i, d
+		}
+	}
+	first := douglasPeucker(loop[:far+1], tolerance)
+	second := douglasPeucker(append(append([]vectorPoint(nil), loop[far:]...), loop[0]), tolerance)
+	return append(first[:len(first)-1], second[:len(second)-1]...)
+}
+
+// douglasPeucker simplifies an open polyline, keeping both end points
+func douglasPeucker(points []vectorPoint, tolerance float64) []vectorPoint {
+	if len(points) < 3 {
+		return points
+	}
+	a, b := points[0], points[len(points)-1]
+	index, maxDist := 0, 0.0
+	for i := 1; i < len(points)-1; i++ {
+		if d := pointSegmentDistance(points[i], a, b); d > maxDist {
+			index, maxDist = i, d
+		}
+	}
+	if maxDist <= tolerance {
+		return []vectorPoint{a, b}
+	}
+	left := douglasPeucker(points[:index+1], tolerance)
+	right := douglasPeucker(points[index:], tolerance)
+	return append(left[:len(left)-1], right...)
+}
+
+// pointSegmentDistance is the distance from p to the segment ab
+func pointSegmentDistance(p, a, b vectorPoint) float64 {
+	dx, dy := b.x-a.x, b.y-a.y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.x-a.x, p.y-a.y)
+	}
+	t := math.Max(0, math.Min(1, ((p.x-a.x)*dx+(p.y-a.y)*dy)/(dx*dx+dy*dy)))
+	return math.Hypot(p.x-(a.x+t*dx), p.y-(a.y+t*dy))
+}
+
+// pathData formats loops as SVG path data
+func pathData(loops [][]vectorPoint) string {
+	var d strings.Builder
+	for _, loop := range loops {
+		for i, p := range loop {
+			if i == 0 {
+				fmt.Fprintf(&d, "M%s %s", formatCoord(p.x), formatCoord(p.y))
+			} else {
+				fmt.Fprintf(&d, "L%s %s", formatCoord(p.x), formatCoord(p.y))
+			}
+		}
+		d.WriteString("Z")
+	}
+	return d.String()
+}
+
+// formatCoord writes a coordinate with at most two decimals
+func formatCoord(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
+
+// traceAccuracy renders the traced layers in order and returns the share of pixels whose color layer
+// matches the source, over every pixel that is visible in either
+func traceAccuracy(layers []tracedLayer, labels []int, w, h int) float64 {
+	rendered := make([]int, w*h)
+	for i := range rendered {
+		rendered[i] = -1
+	}
+
+	for label, layer := range layers {
+		if len(layer.loops) == 0 {
+			continue
+		}
+		r := vector.NewRasterizer(w, h)
+		for _, loop := range layer.loops {
+			r.MoveTo(float32(loop[0].x), float32(loop[0].y))
+			for _, p := range loop[1:] {
+				r.LineTo(float32(p.x), float32(p.y))
+			}
+			r.ClosePath()
+		}
+		mask := image.NewAlpha(image.Rect(0, 0, w, h))
+		r.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+		for i, a := range mask.Pix {
+			if a >= 128 {
+				rendered[i] = label
+			}
+		}
+	}
+
+	matches, considered := 0, 0
+	for i := range labels {
+		if labels[i] < 0 && rendered[i] < 0 {
+			continue
+		}
+		considered++
+		if labels[i] == rendered[i] {
+			matches++
+		}
+	}
+	if considered == 0 {
+		return 0
+	}
+	return float64(matches) / float64(considered)
+}
+
+// svgSize returns the viewBox width and height of a sanitised SVG, rounded to whole units
+func svgSize(svg []byte) (int, int) {
+	_, rest, ok := bytes.Cut(svg, []byte(`viewBox="`))
+	if !ok {
+		return 0, 0
+	}
+	viewBox, _, _ := bytes.Cut(rest, []byte(`"`))
+	fields := strings.FieldsFunc(string(viewBox), func(r rune) bool { return r == ' ' || r == ',' })
+	if len(fields) != 4 {
+		return 0, 0
+	}
+	w, _ := strconv.ParseFloat(fields[2], 64)
+	h, _ := strconv.ParseFloat(fields[3], 64)
+	return int(math.Round(w)), int(math.Round(h))
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceLogoSVG_FlatLogo(t *testing.T) {
+	img := testLogo()
+	// An orange ring inside the symbol exercises holes and a second color layer
+	fillRect(img, image.Rect(70, 70, 130, 130), color.RGBA{0xf5, 0x8a, 0x07, 0xff})
+	fillRect(img, image.Rect(85, 85, 115, 115), color.RGBA{0x0a, 0x25, 0x40, 0xff})
+
+	svg, err := TraceLogoSVG(img)
+	require.NoError(t, err)
+
+	out := string(svg)
+	assert.True(t, strings.HasPrefix(out, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 480 120">`))
+	assert.Contains(t, out, `fill="#0A2540"`)
+	assert.Contains(t, out, `fill="#F58A07"`)
+	assert.NotContains(t, out, "#FFFFFF", "background must not be traced")
+
+	w, h := svgSize(svg)
+	assert.Equal(t, 480, w)
+	assert.Equal(t, 120, h)
+}
+
+func TestTraceLogoSVG_RejectsPhotographicImages(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(rng.Intn(256))
+		if i%4 == 3 {
+			img.Pix[i] = 0xff
+		}
+	}
+
+	_, err := TraceLogoSVG(img)
+	assert.Error(t, err)
+}
+
+func TestTraceMask_HolesAndSimplification(t *testing.T) {
+	// A 6x6 square with a 2x2 hole in the middle
+	w, h := 6, 6
+	labels := make([]int, w*h)
+	for _, p := range []int{2*w + 2, 2*w + 3, 3*w + 2, 3*w + 3} {
+		labels[p] = -1
+	}
+
+	loops := traceMask(labels, w, h, 0)
+	require.Len(t, loops, 2)
+	for i := range loops {
+		loops[i] = simplifyLoop(loops[i], traceTolerance)
+		assert.Len(t, loops[i], 4, "squares simplify to their corners")
+	}
+
+	assert.InDelta(t, 1.0, traceAccuracy([]tracedLayer{{loops: loops}}, labels, w, h), 0.001)
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"bezz-backend/internal/models"
+)
+
+// Meta Ads bulk import settings
+const (
+	metaTimeLayout    = "01/02/2006 15:04"
+	metaAgeMin        = 18
+	metaAgeMax        = 65
+	metaBulkSheetName = "meta-ads-bulk-import.csv"
+)
+
+// metaAdsColumns are the Ads Manager bulk import headers, one row per ad
+var metaAdsColumns = []string{
+	"Campaign Name", "Campaign Status", "Campaign Objective", "Buying Type",
+	"Ad Set Name", "Ad Set Run Status", "Ad Set Lifetime Budget", "Ad Set Time Start", "Ad Set Time Stop",
+	"Countries", "Age Min", "Age Max", "Publisher Platforms", "Instagram Positions",
+	"Optimization Goal", "Billing Event",
+	"Ad Name", "Ad Status", "Title", "Body", "Link Description", "Link", "Call to Action",
+	"Image File Name", "Creative Type",
+}
+
+// metaObjectives maps our ad objectives to Meta's outcome-based objectives and the matching ad set optimization goal
+var metaObjectives = []networkObjective{
+	{[]string{"sale", "conversion", "purchase"}, "Outcome Sales", "OFFSITE_CONVERSIONS"},
+	{[]string{"lead", "sign up", "signup"}, "Outcome Leads", "OFFSITE_CONVERSIONS"},
+	{[]string{"traffic", "click", "visit"}, "Outcome Traffic", "LINK_CLICKS"},
+	{[]string{"engagement", "interaction"}, "Outcome Engagement", "POST_ENGAGEMENT"},
+	{[]string{"awareness", "reach", "recognition"}, "Outcome Awareness", "REACH"},
+}
+
+// metaCallToActions maps placement CTAs to Meta's call-to-action types
+var metaCallToActions = map[string]string{
+	"learn more": "LEARN_MORE",
+	"shop now":   "SHOP_NOW",
+	"sign up":    "SIGN_UP",
+	"book now":   "BOOK_TRAVEL",
+	"contact us": "CONTACT_US",
+	"download":   "DOWNLOAD",
+	"get offer":  "GET_OFFER",
+	"order now":  "ORDER_NOW",
+	"subscribe":  "SUBSCRIBE",
+}
+
+// metaInstagramPositions maps our Meta placements to Instagram positions
+var metaInstagramPositions = map[string]string{
+	"instagram_feed":  "stream",
+	"instagram_story": "story",
+}
+
+var (
+	ageRangePattern = regexp.MustCompile(`(\d{2})\s*(?:-|–|to)\s*(\d{2})`)
+	agePlusPattern  = regexp.MustCompile(`(\d{2})\s*\+`)
+)
+
+// MetaAdsExportData is a campaign laid out the way Ads Manager imports it
+type MetaAdsExportData struct {
+	CampaignName   string         `json:"campaignName"`
+	Objective      string         `json:"objective"`
+	StartTime      time.Time      `json:"startTime"`
+	StopTime       time.Time      `json:"stopTime"`
+	AdSets         []MetaAdSet    `json:"adSets"`
+	CreativeAssets []MetaCreative `json:"creativeAssets"`
+}
+
+// MetaAdSet is one ad set, built from a strategy target segment
+type MetaAdSet struct {
+	Name           string   `json:"name"`
+	TargetAudience string   `json:"targetAudience"`
+	Budget         float64  `json:"budget"` // lifetime budget
+	Optimization   string   `json:"optimization"`
+	Countries      []string `json:"countries"`
+	AgeMin         int      `json:"ageMin"`
+	AgeMax         int      `json:"ageMax"`
+	Platforms      []string `json:"platforms"` // publisher platforms
+	Positions      []string `json:"positions"` // Instagram positions
+}
+
+// MetaCreative is one single-image ad, built from an ad campaign
+type MetaCreative struct {
+	Name         string `json:"name"`
+	AdSetName    string `json:"adSetName"`
+	Headline     string `json:"headline"`
+	Body         string `json:"body"`
+	Description  string `json:"description"`
+	Link         string `json:"link"`
+	ImageURL     string `json:"imageUrl"`
+	ImageFile    string `json:"imageFile"` // file name in the bundle's images folder
+	ImageObject  string `json:"-"`         // GCS object the image is read from
+	CallToAction string `json:"callToAction"`
+}
+
+// metaAdsExporter builds Meta Ads Manager bulk imports for Instagram and Facebook ads
+type metaAdsExporter struct{}
+
+func (metaAdsExporter) Network() AdNetwork {
+	return AdNetwork{ID: "meta", Name: "Meta Ads Manager", Description: "Bulk import sheet and images for Facebook & Instagram"}
+}
+
+func (metaAdsExporter) Build(brief *models.BrandBrief, opts AdNetworkExportOptions) (*AdNetworkBundle, error) {
+	data, err := buildMetaAdsExportData(brief, opts)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := metaAdsCSV(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write bulk import sheet: %w", err)
+	}
+
+	bundle := &AdNetworkBundle{
+		Files:  []AdNetworkFile{{Name: metaBulkSheetName, Data: sheet}},
+		Readme: metaAdsReadme(brief.CompanyName, data, opts),
+	}
+	for _, creative := range data.CreativeAssets {
+		if creative.ImageFile != "" {
+			bundle.Images = append(bundle.Images, AdNetworkImage{Filename: creative.ImageFile, URL: creative.ImageURL, Object: creative.ImageObject})
+		}
+	}
+	return bundle, nil
+}
+
+// buildMetaAdsExportData maps target segments to ad sets and the brief's Meta ads to creatives,
+// splitting the budget evenly across the ad sets that end up with ads
+func buildMetaAdsExportData(brief *models.BrandBrief, opts AdNetworkExportOptions) (*MetaAdsExportData, error) {
+	ads := networkAdsFor(brief, "instagram", "facebook", "meta")
+	if len(ads) == 0 {
+		return nil, fmt.Errorf("brief has no Instagram or Facebook ads to export")
+	}
+
+	objective := matchObjective(ads, metaObjectives)
+	data := &MetaAdsExportData{
+		CampaignName: fmt.Sprintf("%s - %s", brief.CompanyName, opts.StartDate.Format("Jan 2006")),
+		Objective:    objective.objective,
+		StartTime:    opts.StartDate,
+		StopTime:     opts.StartDate.AddDate(0, 0, opts.Days),
+	}
+
+	groups := groupAdsBySegment(brief, ads)
+	budgets := splitBudget(opts.TotalBudget, len(groups))
+	for i, group := range groups {
+		ageMin, ageMax := metaAgeRange(group.segment.Demographics)
+		set := MetaAdSet{
+			Name:           fmt.Sprintf("%s - %s", brief.CompanyName, group.segment.Name),
+			TargetAudience: strings.Trim(strings.Join([]string{group.segment.Role, group.segment.Demographics}, ", "), ", "),
+			Budget:         budgets[i],
+			Optimization:   objective.optimization,
+			Countries:      opts.Countries,
+			AgeMin:         ageMin,
+			AgeMax:         ageMax,
+		}
+
+		for _, ad := range group.ads {
+			platform := strings.ToLower(ad.ad.Platform)
+			if platform == "meta" {
+				platform = "facebook"
+			}
+			if !containsString(set.Platforms, platform) {
+				set.Platforms = append(set.Platforms, platform)
+			}
+			if position, ok := metaInstagramPositions[ad.ad.Placement]; ok && !containsString(set.Positions, position) {
+				set.Positions = append(set.Positions, position)
+			}
+
+			creative := MetaCreative{
+				Name:         fmt.Sprintf("%s - Ad %d", brief.CompanyName, ad.number),
+				AdSetName:    set.Name,
+				Headline:     ad.ad.Copy.Headline,
+				Body:         ad.ad.Copy.Body,
+				Description:  ad.ad.Copy.Description,
+				Link:         opts.LandingURL,
+				CallToAction: metaCallToAction(ad.ad.Copy.CTA),
+			}
+			if img, ok := networkAdImage(ad); ok {
+				creative.ImageURL, creative.ImageFile, creative.ImageObject = img.URL, img.Filename, img.Object
+			}
+			data.CreativeAssets = append(data.CreativeAssets, creative)
+		}
+		data.AdSets = append(data.AdSets, set)
+	}
+
+	return data, nil
+}
+
+// metaAgeRange reads an age range like "25-34" or "45+" out of a segment's demographics
+func metaAgeRange(demographics string) (int, int) {
+	ageMin, ageMax := metaAgeMin, metaAgeMax
+	if m := ageRangePattern.FindStringSubmatch(demographics); m != nil {
+		ageMin, _ = strconv.Atoi(m[1])
+		ageMax, _ = strconv.Atoi(m[2])
+	} else if m := agePlusPattern.FindStringSubmatch(demographics); m != nil {
+		ageMin, _ = strconv.Atoi(m[1])
+	}
+
+	ageMin = int(math.Max(metaAgeMin, math.Min(float64(ageMin), metaAgeMax)))
+	ageMax = int(math.Max(float64(ageMin), math.Min(float64(ageMax), metaAgeMax)))
+	return ageMin, ageMax
+}
+
+// metaCallToAction maps a CTA label to Meta's call-to-action type, defaulting to Learn More
+func metaCallToAction(cta string) string {
+	if action, ok := metaCallToActions[strings.ToLower(strings.TrimSpace(cta))]; ok {
+		return action
+	}
+	return "LEARN_MORE"
+}
+
+// metaAdsCSV writes the bulk import sheet, one row per ad with its ad set and campaign repeated
+func metaAdsCSV(data *MetaAdsExportData) ([]byte, error) {
+	adSets := make(map[string]MetaAdSet, len(data.AdSets))
+	for _, set := range data.AdSets {
+		adSets[set.Name] = set
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(metaAdsColumns); err != nil {
+		return nil, err
+	}
+	for _, creative := range data.CreativeAssets {
+		set := adSets[creative.AdSetName]
+		row := []string{
+			data.CampaignName, "PAUSED", data.Objective, "AUCTION",
+			set.Name, "PAUSED", strconv.FormatFloat(set.Budget, 'f', 2, 64),
+			data.StartTime.Format(metaTimeLayout), data.StopTime.Format(metaTimeLayout),
+			strings.Join(set.Countries, ", "), strconv.Itoa(set.AgeMin), strconv.Itoa(set.AgeMax),
+			strings.Join(set.Platforms, ", "), strings.Join(set.Positions, ", "),
+			set.Optimization, "IMPRESSIONS",
+			creative.Name, "PAUSED", creative.Headline, creative.Body, creative.Description, creative.Link, creative.CallToAction,
+			creative.ImageFile, "Link Page Post Ad",
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// metaAdsReadme explains how to import the bundle into Ads Manager
+func metaAdsReadme(companyName string, data *MetaAdsExportData, opts AdNetworkExportOptions) string {
+	readme := adNetworkReadme("META ADS MANAGER BULK IMPORT", companyName, opts)
+	readme += fmt.Sprintf("CAMPAIGN: %s\nOBJECTIVE: %s\n\nAD SETS:\n", data.CampaignName, data.Objective)
+	for _, set := range data.AdSets {
+		readme += fmt.Sprintf("• %s - lifetime budget %.2f, ages %d-%d\n", set.Name, set.Budget, set.AgeMin, set.AgeMax)
+	}
+
+	readme += fmt.Sprintf(`
+HOW TO IMPORT:
+1. Upload everything in the %s/ folder to your ad account's Media Library
+2. In Ads Manager, open Import & export > Import ads in bulk
+3. Upload %s and review the preview
+4. Everything imports paused - check targeting and budgets (in your ad account's currency), then publish
+`, adNetworkImagesFolder, metaBulkSheetName)
+	return readme
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func sampleMetaAdsBrief() *models.BrandBrief {
+	brief := sampleBrandBookBrief()
+	brief.Results.Strategy.TargetSegments = append(brief.Results.Strategy.TargetSegments,
+		models.TargetSegment{Name: "Student Sam", Demographics: "Ages 16 to 24"},
+		models.TargetSegment{Name: "Unused Uche", Demographics: "50+"},
+	)
+	brief.Results.Ads = []models.AdCampaign{
+		{Platform: "instagram", Placement: "instagram_feed", TargetSegment: "busy bola", ImageURL: "https://cdn.example.com/ads/1.png?sig=x",
+			Objectives: []string{"Brand Awareness", "Engagement"}, Copy: models.AdCopy{Headline: "Brew better", Body: "Fresh coffee", CTA: "Shop Now"}},
+		{Platform: "instagram", Placement: "instagram_story", TargetSegment: "Student Sam",
+			Creatives: []models.AdCreative{{ImageURL: "https://cdn.example.com/creative.png"}}, Copy: models.AdCopy{Headline: "Wake up", CTA: "Book Now"}},
+		{Platform: "linkedin", Placement: "linkedin_sponsored", TargetSegment: "Busy Bola", Copy: models.AdCopy{Headline: "Not for Meta"}},
+		{Platform: "instagram", Placement: "instagram_feed", TargetSegment: "Someone else", Copy: models.AdCopy{Headline: "No image", CTA: "Apply"}},
+	}
+	return brief
+}
+
+func sampleMetaAdsOptions() AdNetworkExportOptions {
+	return AdNetworkExportOptions{
+		TotalBudget: 100.01,
+		Days:        14,
+		StartDate:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		LandingURL:  "https://acme.example.com",
+		Countries:   []string{"NG", "GH"},
+	}
+}
+
+func TestBuildMetaAdsExportData_MapsSegmentsAndAds(t *testing.T) {
+	data, err := buildMetaAdsExportData(sampleMetaAdsBrief(), sampleMetaAdsOptions())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Acme Coffee - Nov 2026", data.CampaignName)
+	assert.Equal(t, "Outcome Awareness", data.Objective)
+	assert.Equal(t, time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC), data.StopTime)
+
+	// The unused segment gets no ad set; the budget splits across the rest to the cent
+	require.Len(t, data.AdSets, 2)
+	assert.Equal(t, "Acme Coffee - Busy Bola", data.AdSets[0].Name)
+	assert.Equal(t, 50.01, data.AdSets[0].Budget)
+	assert.Equal(t, 50.0, data.AdSets[1].Budget)
+	assert.Equal(t, []int{28, 40}, []int{data.AdSets[0].AgeMin, data.AdSets[0].AgeMax})
+	assert.Equal(t, []int{18, 24}, []int{data.AdSets[1].AgeMin, data.AdSets[1].AgeMax}, "ages are clamped to Meta's minimum")
+	assert.Equal(t, []string{"stream"}, data.AdSets[0].Positions)
+	assert.Equal(t, "REACH", data.AdSets[0].Optimization)
+
+	// LinkedIn ads are left out; unknown segments fall back to the first ad set, and ads keep their brief numbers
+	require.Len(t, data.CreativeAssets, 3)
+	assert.Equal(t, "ad-1.png", data.CreativeAssets[0].ImageFile)
+	assert.Equal(t, "SHOP_NOW", data.CreativeAssets[0].CallToAction)
+	assert.Equal(t, "Acme Coffee - Ad 4", data.CreativeAssets[1].Name)
+	assert.Equal(t, data.AdSets[0].Name, data.CreativeAssets[1].AdSetName)
+	assert.Empty(t, data.CreativeAssets[1].ImageFile)
+	assert.Equal(t, "LEARN_MORE", data.CreativeAssets[1].CallToAction)
+	assert.Equal(t, "ad-2.png", data.CreativeAssets[2].ImageFile, "the first creative stands in for a missing ad image")
+	assert.Equal(t, "BOOK_TRAVEL", data.CreativeAssets[2].CallToAction)
+}
+
+func TestBuildMetaAdsExportData_NoMetaAds(t *testing.T) {
+	brief := sampleMetaAdsBrief()
+	brief.Results.Ads = brief.Results.Ads[2:3]
+	_, err := buildMetaAdsExportData(brief, sampleMetaAdsOptions())
+	assert.Error(t, err)
+}
+
+func TestMetaAdsCSV_MatchesImportColumns(t *testing.T) {
+	data, err := buildMetaAdsExportData(sampleMetaAdsBrief(), sampleMetaAdsOptions())
+	require.NoError(t, err)
+	sheet, err := metaAdsCSV(data)
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(strings.NewReader(string(sheet))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, metaAdsColumns, rows[0])
+
+	row := map[string]string{}
+	for i, column := range rows[0] {
+		row[column] = rows[1][i]
+	}
+	assert.Equal(t, "50.01", row["Ad Set Lifetime Budget"])
+	assert.Equal(t, "11/01/2026 00:00", row["Ad Set Time Start"])
+	assert.Equal(t, "NG, GH", row["Countries"])
+	assert.Equal(t, "instagram", row["Publisher Platforms"])
+	assert.Equal(t, "PAUSED", row["Ad Status"])
+	assert.Equal(t, "https://acme.example.com", row["Link"])
+}
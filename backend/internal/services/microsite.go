@@ -0,0 +1,614 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"log"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/storage"
+
+	"bezz-backend/internal/models"
+)
+
+// Brand guidelines microsite settings
+const (
+	micrositeAssetsFolder = "assets"
+	micrositePhotoQuality = 85
+	micrositeFallbackFont = `system-ui, -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif`
+	shareTokenBytes       = 16
+	sharedMicrositeFolder = "shares"
+)
+
+// ErrShareNotFound is returned for share links that do not exist or were revoked
+var ErrShareNotFound = errors.New("share link not found")
+
+// ErrShareNotAllowed is returned when a brief's content hasn't passed moderation, so it can't be made public
+var ErrShareNotAllowed = errors.New("brief must pass content review before it can be shared")
+
+var (
+	shareTokenPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	// micrositeFontPattern keeps font stacks to names, quotes and commas before they go into the page's CSS
+	micrositeFontPattern = regexp.MustCompile(`^[A-Za-z0-9 ,"'-]+$`)
+)
+
+// micrositeFile is a file written next to index.html in the ZIP
+type micrositeFile struct {
+	name string
+	data []byte
+}
+
+// micrositeSources are the downloaded images and logo files the site embeds
+type micrositeSources struct {
+	images   exportImages
+	variants [][]byte // parallel to the identity's logo variants; nil entries were unavailable
+}
+
+// micrositeBuilder collects the site's assets: as files in the ZIP's assets folder, or inline as data URIs
+// when the page is served on its own from a share link
+type micrositeBuilder struct {
+	inline bool
+	files  []micrositeFile
+}
+
+// asset stores a file and returns the URL the page references it by
+func (b *micrositeBuilder) asset(name string, data []byte) template.URL {
+	if b.inline {
+		mimeType := mime.TypeByExtension(path.Ext(name))
+		if path.Ext(name) == ".ico" {
+			mimeType = "image/x-icon"
+		}
+		return template.URL("data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data))
+	}
+	b.files = append(b.files, micrositeFile{name: micrositeAssetsFolder + "/" + name, data: data})
+	return template.URL(micrositeAssetsFolder + "/" + name)
+}
+
+// micrositePage is the template data for the site
+type micrositePage struct {
+	Company     string
+	Tagline     string
+	Generated   string
+	Logo        template.URL
+	Theme       micrositeTheme
+	Strategy    models.BrandStrategy
+	LogoConcept string
+	Colors      []micrositeColor
+	Typography  *models.Typography
+	Logos       []micrositeLogo
+	Ads         []micrositeAd
+}
+
+// micrositeTheme is the brief's palette and fonts as CSS values
+type micrositeTheme struct {
+	Primary, OnPrimary, Secondary, Accent, OnAccent, Text, Muted, Surface template.CSS
+	HeadingFont, BodyFont                                                 template.CSS
+}
+
+type micrositeColor struct {
+	Name, Hex, RGB, Usage, Psychology string
+	Swatch, OnSwatch                  template.CSS
+}
+
+type micrositeLogo struct {
+	Name, Usage, Filename, Format string
+	Src                           template.URL
+	Dark                          bool // preview on a dark background
+}
+
+type micrositeAd struct {
+	Title, Placement, Segment string
+	Copy                      models.AdCopy
+	Src                       template.URL
+}
+
+// renderBrandMicrosite renders the brief's results as a single-page brand guide styled with its palette,
+// returning index.html and the asset files it references (none when inline)
+func renderBrandMicrosite(brief *models.BrandBrief, sources micrositeSources, inline bool) ([]byte, []micrositeFile, error) {
+	if brief.Results == nil {
+		return nil, nil, fmt.Errorf("brief has no results to export")
+	}
+	results := brief.Results
+	builder := &micrositeBuilder{inline: inline}
+
+	colors := brandBookPalette(results.BrandIdentity)
+	page := micrositePage{
+		Company:   brief.CompanyName,
+		Tagline:   results.Strategy.Tagline,
+		Generated: time.Now().Format("January 2, 2006"),
+		Strategy:  results.Strategy,
+		Theme: micrositeTheme{
+			Primary:     template.CSS(hexString(colors.primary)),
+			OnPrimary:   template.CSS(hexString(readableTextColor(colors.primary))),
+			Secondary:   template.CSS(hexString(colors.secondary)),
+			Accent:      template.CSS(hexString(colors.accent)),
+			OnAccent:    template.CSS(hexString(readableTextColor(colors.accent))),
+			Text:        template.CSS(hexString(colors.text)),
+			Muted:       template.CSS(hexString(colors.muted)),
+			Surface:     template.CSS(hexString(colors.surface)),
+			HeadingFont: micrositeFallbackFont,
+			BodyFont:    micrositeFallbackFont,
+		},
+	}
+
+	if sources.images.logo != nil {
+		data, err := encodePNG(sources.images.logo)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.Logo = builder.asset("logo.png", data)
+		page.Logos = append(page.Logos, micrositeLogo{Name: "Primary logo", Usage: "Full-colour logo for general use", Filename: "logo.png", Format: "png", Src: page.Logo})
+	}
+
+	if identity := results.BrandIdentity; identity != nil {
+		page.LogoConcept = identity.LogoConcept
+		page.Typography = identity.Typography
+		for _, f := range buildDesignTokens(brief.CompanyName, identity).fonts {
+			stack := cssFontStack(f.stack)
+			if len(f.stack) == 1 {
+				stack += ", sans-serif"
+			}
+			if !micrositeFontPattern.MatchString(stack) {
+				continue
+			}
+			if f.key == "heading" {
+				page.Theme.HeadingFont = template.CSS(stack)
+			} else {
+				page.Theme.BodyFont = template.CSS(stack)
+			}
+		}
+
+		for _, c := range identity.ColorPalette {
+			rgb, err := parseHexColor(c.Hex)
+			if err != nil {
+				continue
+			}
+			page.Colors = append(page.Colors, micrositeColor{
+				Name: c.Name, Usage: c.Usage, Psychology: c.Psychology,
+				Hex:      hexString(rgb),
+				RGB:      fmt.Sprintf("rgb(%d, %d, %d)", rgb.R, rgb.G, rgb.B),
+				Swatch:   template.CSS(hexString(rgb)),
+				OnSwatch: template.CSS(hexString(readableTextColor(rgb))),
+			})
+		}
+
+		for i, variant := range identity.LogoVariants {
+			if i >= len(sources.variants) || sources.variants[i] == nil {
+				continue
+			}
+			filename := fmt.Sprintf("%s.%s", variant.Name, variant.Format)
+			page.Logos = append(page.Logos, micrositeLogo{
+				Name: variant.Name, Usage: variant.Usage, Filename: filename, Format: variant.Format,
+				Src:  builder.asset("logos/"+filename, sources.variants[i]),
+				Dark: variant.Kind == "mono_light",
+			})
+		}
+	}
+
+	for i, ad := range results.Ads {
+		item := micrositeAd{Title: ad.Title, Segment: ad.TargetSegment, Copy: ad.Copy, Placement: ad.Platform}
+		if placement, ok := adPlacements[ad.Placement]; ok {
+			item.Placement = placement.Name
+		}
+		if i < len(sources.images.ads) && sources.images.ads[i] != nil {
+			data, err := encodeMicrositePhoto(sources.images.ads[i])
+			if err != nil {
+				log.Printf("⚠️ EXPORT: Leaving image out of microsite for ad %s: %v", ad.ID, err)
+			} else {
+				item.Src = builder.asset(fmt.Sprintf("ads/ad-%d.jpg", i+1), data)
+			}
+		}
+		page.Ads = append(page.Ads, item)
+	}
+
+	var buf bytes.Buffer
+	if err := micrositeTemplate.Execute(&buf, page); err != nil {
+		return nil, nil, fmt.Errorf("failed to render microsite: %w", err)
+	}
+	return buf.Bytes(), builder.files, nil
+}
+
+// encodeMicrositePhoto encodes an ad image as JPEG to keep the page light
+func encodeMicrositePhoto(img image.Image) ([]byte, error) {
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, rgba, &jpeg.Options{Quality: micrositePhotoQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// loadMicrositeSources reads the logo, ad images and logo variant files, best effort
+func (s *ExportService) loadMicrositeSources(ctx context.Context, brief *models.BrandBrief) micrositeSources {
+	sources := micrositeSources{images: s.loadExportImages(ctx, brief)}
+	if identity := brief.Results.BrandIdentity; identity != nil {
+		sources.variants = make([][]byte, len(identity.LogoVariants))
+		for i, variant := range identity.LogoVariants {
+			if variant.ObjectName == "" && variant.ImageURL == "" {
+				continue
+			}
+			data, err := s.readExportFile(ctx, storedObject(variant.ObjectName, "."+variant.Format), variant.ImageURL)
+			if err != nil {
+				log.Printf("⚠️ EXPORT: Failed to load logo variant %s: %v", variant.Name, err)
+				continue
+			}
+			sources.variants[i] = data
+		}
+	}
+	return sources
+}
+
+// GenerateMicrositeExport builds the brand guidelines microsite as a ZIP: index.html plus its assets folder
+func (s *ExportService) GenerateMicrositeExport(ctx context.Context, briefID string, userID string) ([]byte, string, string, error) {
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch brief: %w", err)
+	}
+	if brief.Results == nil {
+		return nil, "", "", fmt.Errorf("brief has no results to export")
+	}
+
+	page, files, err := renderBrandMicrosite(brief, s.loadMicrositeSources(ctx, brief), false)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for _, f := range append([]micrositeFile{{name: "index.html", data: page}}, files...) {
+		file, err := zipWriter.Create(f.name)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if _, err := file.Write(f.data); err != nil {
+			return nil, "", "", err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, "", "", fmt.Errorf("failed to finalize ZIP: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s-brand-guidelines-%s.zip", strings.ReplaceAll(brief.CompanyName, " ", "-"), time.Now().Format("2006-01-02"))
+	log.Printf("✅ EXPORT: Brand guidelines microsite created for %s (%d assets)", brief.CompanyName, len(files))
+	return buf.Bytes(), "application/zip", filename, nil
+}
+
+// ShareMicrosite turns on the brief's read-only share link, returning its token. The page is rendered and
+// stored here so the public route only serves the stored copy; sharing again keeps the same link and
+// refreshes its page.
+func (s *ExportService) ShareMicrosite(ctx context.Context, briefID string, userID string) (string, error) {
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch brief: %w", err)
+	}
+	if brief.Results == nil {
+		return "", fmt.Errorf("brief has no results to share")
+	}
+	if !moderationAllowsSharing(brief.Moderation) {
+		return "", ErrShareNotAllowed
+	}
+	if brief.ShareToken != "" {
+		if _, err := s.storeSharedMicrosite(ctx, brief.ShareToken, brief); err != nil {
+			return "", err
+		}
+		return brief.ShareToken, nil
+	}
+
+	raw := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	// Store the page before the link goes live
+	if _, err := s.storeSharedMicrosite(ctx, token, brief); err != nil {
+		return "", err
+	}
+
+	updates := []firestore.Update{
+		{Path: "shareToken", Value: token},
+		{Path: "updatedAt", Value: time.Now()},
+	}
+	if _, err := s.db.Collection("briefs").Doc(briefID).Update(ctx, updates); err != nil {
+		return "", fmt.Errorf("failed to save share token: %w", err)
+	}
+
+	log.Printf("🔗 EXPORT: Brand guidelines shared for %s", brief.CompanyName)
+	return token, nil
+}
+
+// UnshareMicrosite revokes the brief's share link and deletes its stored page
+func (s *ExportService) UnshareMicrosite(ctx context.Context, briefID string, userID string) error {
+	brief, err := s.getBriefWithValidation(ctx, briefID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch brief: %w", err)
+	}
+
+	updates := []firestore.Update{
+		{Path: "shareToken", Value: firestore.Delete},
+		{Path: "updatedAt", Value: time.Now()},
+	}
+	if _, err := s.db.Collection("briefs").Doc(briefID).Update(ctx, updates); err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	if brief.ShareToken != "" {
+		deleteSharedMicrosite(ctx, s.storage, s.bucketName, brief.ShareToken)
+	}
+	return nil
+}
+
+// ValidateShareToken checks a share token's format before it is looked up
+func ValidateShareToken(token string) error {
+	if !shareTokenPattern.MatchString(token) {
+		return fmt.Errorf("invalid share link")
+	}
+	return nil
+}
+
+// sharedMicrositeObject names the stored page for a share link
+func sharedMicrositeObject(token string) string {
+	return sharedMicrositeFolder + "/" + token + ".html"
+}
+
+// storeSharedMicrosite renders the brief's guidelines as a single self-contained page and stores it for the share link
+func (s *ExportService) storeSharedMicrosite(ctx context.Context, token string, brief *models.BrandBrief) ([]byte, error) {
+	page, _, err := renderBrandMicrosite(brief, s.loadMicrositeSources(ctx, brief), true)
+	if err != nil {
+		return nil, err
+	}
+	if s.storage == nil {
+		return nil, fmt.Errorf("storage is not configured to store the shared page")
+	}
+
+	writer := s.storage.Bucket(s.bucketName).Object(sharedMicrositeObject(token)).NewWriter(ctx)
+	writer.ContentType = "text/html; charset=utf-8"
+	if _, err := writer.Write(page); err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("failed to store shared page: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to store shared page: %w", err)
+	}
+	return page, nil
+}
+
+// deleteSharedMicrosite removes a revoked link's stored page, best effort; the link itself is already dark
+func deleteSharedMicrosite(ctx context.Context, client *storage.Client, bucketName, token string) {
+	if client == nil {
+		return
+	}
+	if err := client.Bucket(bucketName).Object(sharedMicrositeObject(token)).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		log.Printf("⚠️ EXPORT: Failed to delete shared page for a revoked link: %v", err)
+	}
+}
+
+// SharedMicrosite returns the stored page for a share link. The brief is still looked up on every request
+// so revoked links and briefs that fail review go dark straight away.
+func (s *ExportService) SharedMicrosite(ctx context.Context, token string) ([]byte, error) {
+	docs, err := s.db.Collection("briefs").Where("shareToken", "==", token).Limit(1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up share link: %w", err)
+	}
+	if len(docs) == 0 {
+		return nil, ErrShareNotFound
+	}
+
+	var brief models.BrandBrief
+	if err := docs[0].DataTo(&brief); err != nil {
+		return nil, fmt.Errorf("failed to parse brief: %w", err)
+	}
+	// Links stay dark while the brief is under review or after it was rejected
+	if brief.Results == nil || !moderationAllowsSharing(brief.Moderation) {
+		return nil, ErrShareNotFound
+	}
+
+	page, err := s.readExportFile(ctx, sharedMicrositeObject(token), "")
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		// Links shared before pages were stored are rendered once, then served like the rest
+		return s.storeSharedMicrosite(ctx, token, &brief)
+	}
+	return page, err
+}
+
+var micrositeTemplate = template.Must(template.New("microsite").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Company}} Brand Guidelines</title>
+<style>
+:root {
+  --primary: {{.Theme.Primary}}; --on-primary: {{.Theme.OnPrimary}}; --secondary: {{.Theme.Secondary}};
+  --accent: {{.Theme.Accent}}; --on-accent: {{.Theme.OnAccent}};
+  --text: {{.Theme.Text}}; --muted: {{.Theme.Muted}}; --surface: {{.Theme.Surface}};
+  --heading: {{.Theme.HeadingFont}}; --body: {{.Theme.BodyFont}};
+}
+* { box-sizing: border-box; }
+body { margin: 0; font-family: var(--body); color: var(--text); line-height: 1.6; background: #fff; }
+h1, h2, h3 { font-family: var(--heading); line-height: 1.2; margin: 0 0 .5em; }
+header { background: var(--primary); color: var(--on-primary); padding: 64px 24px; }
+header img { max-height: 72px; max-width: 240px; background: #fff; border-radius: 8px; padding: 8px; margin-bottom: 24px; }
+header h1 { font-size: 2.75rem; }
+header p { font-size: 1.25rem; opacity: .9; margin: 0; }
+nav { position: sticky; top: 0; background: #fff; border-bottom: 1px solid var(--surface); z-index: 1; }
+nav ul { display: flex; flex-wrap: wrap; gap: 24px; list-style: none; margin: 0 auto; padding: 14px 24px; max-width: 1080px; }
+nav a { color: var(--text); text-decoration: none; font-weight: 600; }
+nav a:hover { color: var(--primary); }
+.wrap { max-width: 1080px; margin: 0 auto; padding: 0 24px; }
+section { padding: 56px 0; border-bottom: 1px solid var(--surface); }
+section h2 { font-size: 2rem; color: var(--primary); }
+.lead { font-size: 1.25rem; }
+.muted { color: var(--muted); }
+.grid { display: grid; gap: 20px; grid-template-columns: repeat(auto-fill, minmax(240px, 1fr)); }
+.card { background: var(--surface); border-radius: 12px; padding: 20px; }
+.card h3 { font-size: 1.1rem; }
+.chips { display: flex; flex-wrap: wrap; gap: 8px; padding: 0; list-style: none; }
+.chips li { background: var(--secondary); color: var(--text); border-radius: 999px; padding: 4px 14px; font-weight: 600; }
+.dos { display: grid; gap: 20px; grid-template-columns: repeat(auto-fit, minmax(280px, 1fr)); }
+.dos ul { padding-left: 1.2em; margin: 0; }
+.do { border-top: 4px solid #16A34A; }
+.dont { border-top: 4px solid #DC2626; }
+.swatch { border-radius: 12px; overflow: hidden; border: 1px solid var(--surface); }
+.swatch .chip { height: 120px; padding: 16px; display: flex; align-items: flex-end; font-weight: 700; }
+.swatch .info { padding: 16px; }
+.swatch .info p { margin: 0 0 8px; font-size: .9rem; }
+button.copy { font: inherit; font-size: .85rem; border: 1px solid var(--muted); background: #fff; color: var(--text); border-radius: 6px; padding: 4px 10px; margin: 0 6px 6px 0; cursor: pointer; }
+button.copy:hover { border-color: var(--primary); color: var(--primary); }
+.specimen { font-size: 2rem; margin: 0; }
+.logo-preview { height: 160px; display: flex; align-items: center; justify-content: center; background: #fff; border-radius: 8px; margin-bottom: 12px; }
+.logo-preview.dark { background: var(--primary); }
+.logo-preview img { max-width: 80%; max-height: 120px; }
+a.download { display: inline-block; background: var(--accent); color: var(--on-accent); border-radius: 6px; padding: 6px 14px; text-decoration: none; font-weight: 600; }
+.ad img { width: 100%; border-radius: 8px; display: block; margin-bottom: 12px; }
+.ad .cta { display: inline-block; background: var(--accent); color: var(--on-accent); border-radius: 6px; padding: 4px 12px; font-weight: 600; font-size: .9rem; }
+footer { padding: 32px 24px; text-align: center; color: var(--muted); font-size: .9rem; }
+</style>
+</head>
+<body>
+<header>
+  <div class="wrap">
+    {{if .Logo}}<img src="{{.Logo}}" alt="{{.Company}} logo">{{end}}
+    <h1>{{.Company}}</h1>
+    {{if .Tagline}}<p>{{.Tagline}}</p>{{end}}
+  </div>
+</header>
+<nav>
+  <ul>
+    <li><a href="#strategy">Strategy</a></li>
+    <li><a href="#voice">Voice</a></li>
+    {{if .Colors}}<li><a href="#colors">Colours</a></li>{{end}}
+    {{if .Typography}}<li><a href="#typography">Typography</a></li>{{end}}
+    {{if .Logos}}<li><a href="#logos">Logos</a></li>{{end}}
+    {{if .Ads}}<li><a href="#ads">Ads</a></li>{{end}}
+  </ul>
+</nav>
+<main class="wrap">
+  <section id="strategy">
+    <h2>Strategy</h2>
+    <p class="lead">{{.Strategy.Positioning}}</p>
+    {{if .Strategy.ValueProposition}}<h3>Value proposition</h3><p>{{.Strategy.ValueProposition}}</p>{{end}}
+    {{if .Strategy.BrandPillars}}<h3>Brand pillars</h3>
+    <ul class="chips">{{range .Strategy.BrandPillars}}<li>{{.}}</li>{{end}}</ul>{{end}}
+    {{with .Strategy.MessagingFramework}}{{if .PrimaryMessage}}<h3>Key message</h3><p>{{.PrimaryMessage}}</p>
+    {{if .SupportingMessages}}<ul>{{range .SupportingMessages}}<li>{{.}}</li>{{end}}</ul>{{end}}{{end}}{{end}}
+    {{if .Strategy.TargetSegments}}<h3>Who we talk to</h3>
+    <div class="grid">{{range .Strategy.TargetSegments}}
+      <div class="card">
+        <h3>{{.Name}}</h3>
+        {{if .Role}}<p class="muted">{{.Role}}</p>{{end}}
+        {{if .Demographics}}<p>{{.Demographics}}</p>{{end}}
+        {{if .PainPoints}}<p><strong>Pain points:</strong> {{range $i, $p := .PainPoints}}{{if $i}}, {{end}}{{$p}}{{end}}</p>{{end}}
+      </div>{{end}}
+    </div>{{end}}
+  </section>
+
+  <section id="voice">
+    <h2>Voice</h2>
+    {{with .Strategy.TonalGuidelines}}
+    {{if .Voice}}<p class="lead">{{.Voice}}</p>{{end}}
+    {{if .Personality}}<ul class="chips">{{range .Personality}}<li>{{.}}</li>{{end}}</ul>{{end}}
+    <div class="dos">
+      <div class="card do"><h3>Do</h3><ul>{{range .DoAndDonts.Do}}<li>{{.}}</li>{{end}}</ul></div>
+      <div class="card dont"><h3>Don't</h3><ul>{{range .DoAndDonts.Dont}}<li>{{.}}</li>{{end}}</ul></div>
+    </div>
+    {{end}}
+  </section>
+
+  {{if .Colors}}<section id="colors">
+    <h2>Colours</h2>
+    <p class="muted">Click a code to copy it.</p>
+    <div class="grid">{{range .Colors}}
+      <div class="swatch">
+        <div class="chip" style="background: {{.Swatch}}; color: {{.OnSwatch}}">{{.Name}}</div>
+        <div class="info">
+          {{if .Usage}}<p class="muted">{{.Usage}}</p>{{end}}
+          {{if .Psychology}}<p>{{.Psychology}}</p>{{end}}
+          <button class="copy" data-copy="{{.Hex}}">{{.Hex}}</button><button class="copy" data-copy="{{.RGB}}">{{.RGB}}</button>
+        </div>
+      </div>{{end}}
+    </div>
+  </section>{{end}}
+
+  {{with .Typography}}<section id="typography">
+    <h2>Typography</h2>
+    <div class="grid">
+      <div class="card"><p class="muted">Headings</p><p class="specimen" style="font-family: var(--heading)">{{.Heading.Family}}</p></div>
+      <div class="card"><p class="muted">Body</p><p class="specimen" style="font-family: var(--body)">{{.Body.Family}}</p></div>
+    </div>
+    {{if .UsageRules}}<ul>{{range .UsageRules}}<li>{{.}}</li>{{end}}</ul>{{end}}
+  </section>{{end}}
+
+  {{if .Logos}}<section id="logos">
+    <h2>Logos</h2>
+    {{if .LogoConcept}}<p>{{.LogoConcept}}</p>{{end}}
+    <div class="grid">{{range .Logos}}
+      <div class="card">
+        <div class="logo-preview{{if .Dark}} dark{{end}}"><img src="{{.Src}}" alt="{{.Name}}"></div>
+        <h3>{{.Name}}</h3>
+        {{if .Usage}}<p class="muted">{{.Usage}}</p>{{end}}
+        <a class="download" href="{{.Src}}" download="{{.Filename}}">Download {{.Format}}</a>
+      </div>{{end}}
+    </div>
+  </section>{{end}}
+
+  {{if .Ads}}<section id="ads">
+    <h2>Ads</h2>
+    <div class="grid">{{range .Ads}}
+      <div class="card ad">
+        {{if .Src}}<img src="{{.Src}}" alt="{{.Copy.Headline}}">{{end}}
+        <p class="muted">{{.Placement}}{{if .Segment}} · {{.Segment}}{{end}}</p>
+        <h3>{{.Copy.Headline}}</h3>
+        {{if .Copy.Body}}<p>{{.Copy.Body}}</p>{{end}}
+        {{if .Copy.CTA}}<span class="cta">{{.Copy.CTA}}</span>{{end}}
+      </div>{{end}}
+    </div>
+  </section>{{end}}
+</main>
+<footer>{{.Company}} brand guidelines · {{.Generated}}</footer>
+<script>
+document.querySelectorAll('button.copy').forEach(function (button) {
+  button.addEventListener('click', function () {
+    var value = button.getAttribute('data-copy');
+    var done = function () {
+      button.textContent = 'Copied';
+      setTimeout(function () { button.textContent = value; }, 1200);
+    };
+    if (navigator.clipboard && window.isSecureContext) {
+      navigator.clipboard.writeText(value).then(done);
+      return;
+    }
+    var field = document.createElement('textarea');
+    field.value = value;
+    document.body.appendChild(field);
+    field.select();
+    document.execCommand('copy');
+    document.body.removeChild(field);
+    done();
+  });
+});
+</script>
+</body>
+</html>
+`))
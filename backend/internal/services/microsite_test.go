@@ -0,0 +1,108 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func sampleMicrositeSources() micrositeSources {
+	photo := solidImage(32, 32, color.NRGBA{G: 0x80, A: 0xff})
+	return micrositeSources{
+		images:   exportImages{logo: solidImage(40, 20, color.NRGBA{R: 0x3b, A: 0xff}), ads: []image.Image{photo, nil}},
+		variants: [][]byte{[]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"), nil},
+	}
+}
+
+func sampleMicrositeBrief() *models.BrandBrief {
+	brief := sampleBrandBookBrief()
+	brief.Results.Strategy.TonalGuidelines = models.TonalGuidelines{
+		Voice:      "Warm, upbeat and <b>never</b> pushy",
+		DoAndDonts: models.DoAndDonts{Do: []string{"Speak like a neighbour"}, Dont: []string{"Use jargon"}},
+	}
+	brief.Results.BrandIdentity.LogoVariants = []models.LogoVariant{
+		{Kind: "vector", Name: "logo-vector", Usage: "Scalable logo", Format: "svg"},
+		{Kind: "favicon", Name: "favicon", Format: "ico"},
+	}
+	brief.Results.Ads[0].Placement = "instagram_feed"
+	return brief
+}
+
+func TestRenderBrandMicrosite_ZipLayout(t *testing.T) {
+	brief := sampleMicrositeBrief()
+	page, files, err := renderBrandMicrosite(brief, sampleMicrositeSources(), false)
+	require.NoError(t, err)
+	html := string(page)
+
+	for _, section := range []string{`id="strategy"`, `id="voice"`, `id="colors"`, `id="typography"`, `id="logos"`, `id="ads"`} {
+		assert.Contains(t, html, section)
+	}
+
+	// Styled with the brief's palette and fonts
+	assert.Contains(t, html, "--primary: #3B2416")
+	assert.Contains(t, html, `--heading: "Playfair Display", sans-serif`)
+
+	// Colour codes can be copied
+	assert.Contains(t, html, `data-copy="#F28C28"`)
+	assert.Contains(t, html, `data-copy="rgb(242, 140, 40)"`)
+
+	// Brief text is escaped
+	assert.Contains(t, html, "Warm, upbeat and &lt;b&gt;never&lt;/b&gt; pushy")
+	assert.Contains(t, html, "Speak like a neighbour")
+	assert.Contains(t, html, "Instagram Feed")
+
+	// Assets live next to index.html; variants that failed to download are left out
+	var names []string
+	for _, f := range files {
+		names = append(names, f.name)
+	}
+	assert.Equal(t, []string{"assets/logo.png", "assets/logos/logo-vector.svg", "assets/ads/ad-1.jpg"}, names)
+	assert.Contains(t, html, `href="assets/logos/logo-vector.svg" download="logo-vector.svg"`)
+	assert.NotContains(t, html, "favicon.ico")
+	assert.NotContains(t, html, "data:image")
+}
+
+func TestRenderBrandMicrosite_InlineForSharing(t *testing.T) {
+	brief := sampleMicrositeBrief()
+	page, files, err := renderBrandMicrosite(brief, sampleMicrositeSources(), true)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	html := string(page)
+	assert.Contains(t, html, `src="data:image/png;base64,`)
+	assert.Contains(t, html, `src="data:image/jpeg;base64,`)
+	assert.Contains(t, html, `href="data:image/svg&#43;xml;base64,`, "html/template entity-encodes the plus")
+	assert.NotContains(t, html, `"assets/`)
+}
+
+func TestRenderBrandMicrosite_WithoutIdentity(t *testing.T) {
+	brief := sampleMicrositeBrief()
+	brief.Results.BrandIdentity = nil
+	page, _, err := renderBrandMicrosite(brief, micrositeSources{}, false)
+	require.NoError(t, err)
+	html := string(page)
+	assert.NotContains(t, html, `id="colors"`)
+	assert.True(t, strings.Contains(html, "--primary: #1F2937"), "falls back to the default palette")
+	assert.Contains(t, html, "--heading: system-ui")
+}
+
+func TestValidateShareToken(t *testing.T) {
+	assert.NoError(t, ValidateShareToken(strings.Repeat("a1", 16)))
+	assert.Error(t, ValidateShareToken("abc"))
+	assert.Error(t, ValidateShareToken(strings.Repeat("Z", 32)))
+}
+
+func TestModerationAllowsSharing(t *testing.T) {
+	assert.True(t, moderationAllowsSharing(nil), "briefs from before moderation")
+	assert.True(t, moderationAllowsSharing(&models.ModerationReport{Status: moderationClear}))
+	assert.False(t, moderationAllowsSharing(&models.ModerationReport{Status: moderationFlagged, NeedsReview: true}))
+	assert.False(t, moderationAllowsSharing(&models.ModerationReport{Status: moderationUnchecked, NeedsReview: true}))
+	assert.True(t, moderationAllowsSharing(&models.ModerationReport{Status: moderationFlagged, Decision: moderationDecisionApproved}))
+	assert.False(t, moderationAllowsSharing(&models.ModerationReport{Status: moderationClear, Decision: moderationDecisionRejected}))
+}
@@ -0,0 +1,642 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	openai "github.com/openai/openai-go/v2"
+
+	bezzmodels "bezz-backend/internal/models"
+	"bezz-backend/internal/prompts"
+)
+
+// ContentModerator checks text against the content policy. Verdicts are returned in input order.
+type ContentModerator interface {
+	Moderate(ctx context.Context, inputs []string) ([]ModerationVerdict, error)
+}
+
+// ModerationVerdict is the moderation outcome for one input
+type ModerationVerdict struct {
+	Flagged    bool
+	Categories []string // flagged category names, e.g. "violence", "self-harm/intent"
+}
+
+// ModerationErrorCode is returned to clients when a brief's inputs are blocked
+const ModerationErrorCode = "content_flagged"
+
+// Moderation report statuses and flag actions
+const (
+	moderationClear     = "clear"
+	moderationFlagged   = "flagged"
+	moderationBlocked   = "blocked"
+	moderationUnchecked = "unchecked"
+
+	moderationActionBlocked     = "blocked"
+	moderationActionRegenerated = "regenerated"
+	moderationActionDropped     = "dropped"
+	moderationActionUnchecked   = "unchecked" // the moderation API failed, so the content went through unchecked
+
+	moderationExcerptLength = 200
+
+	moderationDecisionApproved = "approved"
+	moderationDecisionRejected = "rejected"
+)
+
+// ErrContentBlocked is returned when generated content a brief can't do without is still flagged after rewriting
+var ErrContentBlocked = errors.New("generated content failed moderation")
+
+// OpenAIModerator checks content with OpenAI's moderation endpoint
+type OpenAIModerator struct {
+	client *openai.Client
+}
+
+// NewOpenAIModerator creates a moderator backed by omni-moderation-latest
+func NewOpenAIModerator(client *openai.Client) *OpenAIModerator {
+	return &OpenAIModerator{client: client}
+}
+
+// Moderate checks every input in one request
+func (m *OpenAIModerator) Moderate(ctx context.Context, inputs []string) ([]ModerationVerdict, error) {
+	resp, err := m.client.Moderations.New(ctx, openai.ModerationNewParams{
+		Input: openai.ModerationNewParamsInputUnion{OfStringArray: inputs},
+		Model: openai.ModerationModelOmniModerationLatest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("moderation request failed: %w", err)
+	}
+	if len(resp.Results) != len(inputs) {
+		return nil, fmt.Errorf("moderation returned %d results for %d inputs", len(resp.Results), len(inputs))
+	}
+
+	verdicts := make([]ModerationVerdict, len(resp.Results))
+	for i, result := range resp.Results {
+		verdicts[i] = ModerationVerdict{Flagged: result.Flagged, Categories: flaggedCategories(result.Categories.RawJSON())}
+	}
+	return verdicts, nil
+}
+
+// flaggedCategories lists the categories set in a moderation result's categories object
+func flaggedCategories(raw string) []string {
+	var categories map[string]bool
+	if err := json.Unmarshal([]byte(raw), &categories); err != nil {
+		return nil
+	}
+	var flagged []string
+	for name, set := range categories {
+		if set {
+			flagged = append(flagged, name)
+		}
+	}
+	sort.Strings(flagged)
+	return flagged
+}
+
+// ModerateContent checks content for policy violations
+func (s *AIService) ModerateContent(ctx context.Context, content string) (bool, error) {
+	verdicts, err := s.moderator.Moderate(ctx, []string{content})
+	if err != nil {
+		return false, err
+	}
+	return !verdicts[0].Flagged, nil
+}
+
+// moderationField is one piece of text to check, with where it came from
+type moderationField struct {
+	target string
+	field  string
+	text   string
+}
+
+// moderateFields checks the fields in one batch and returns a flag, without an action, for each flagged field
+func (s *AIService) moderateFields(ctx context.Context, stage string, fields []moderationField) ([]bezzmodels.ModerationFlag, error) {
+	var checked []moderationField
+	var inputs []string
+	for _, f := range fields {
+		if text := strings.TrimSpace(f.text); text != "" {
+			checked = append(checked, f)
+			inputs = append(inputs, text)
+		}
+	}
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	verdicts, err := s.moderator.Moderate(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []bezzmodels.ModerationFlag
+	for i, verdict := range verdicts {
+		if verdict.Flagged {
+			flags = append(flags, newModerationFlag(stage, checked[i].target, checked[i].field, checked[i].text, verdict.Categories, ""))
+		}
+	}
+	return flags, nil
+}
+
+// newModerationFlag records a flagged field with a short excerpt of the offending text
+func newModerationFlag(stage, target, field, text string, categories []string, action string) bezzmodels.ModerationFlag {
+	excerpt := strings.TrimSpace(text)
+	if runes := []rune(excerpt); len(runes) > moderationExcerptLength {
+		excerpt = string(runes[:moderationExcerptLength]) + "…"
+	}
+	return bezzmodels.ModerationFlag{
+		Stage:      stage,
+		Target:     target,
+		Field:      field,
+		Excerpt:    excerpt,
+		Categories: categories,
+		Action:     action,
+		FlaggedAt:  time.Now(),
+	}
+}
+
+// ModerateBriefRequest checks the free-text inputs of a new brief. Any field the moderation API flags blocks the
+// brief; prompt-injection attempts and briefs the API could not check go through but are queued for review.
+func (s *AIService) ModerateBriefRequest(ctx context.Context, req *bezzmodels.BrandBriefRequest) *bezzmodels.ModerationReport {
+	var fields []moderationField
+	for _, f := range briefTextFields(req) {
+		fields = append(fields, moderationField{target: "brief", field: f.name, text: *f.value})
+	}
+
+	report := &bezzmodels.ModerationReport{}
+	suspicious := detectPromptInjection(req)
+	if len(suspicious) > 0 {
+		log.Printf("🛡️ MODERATION: Brief inputs look like a prompt-injection attempt: %v", flaggedCategoryList(suspicious))
+	}
+
+	flags, err := s.moderateFields(ctx, "input", fields)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: Brief input check failed, accepting unchecked: %v", err)
+		recordModeration(report, append(suspicious, newModerationFlag("input", "brief", "", err.Error(), nil, moderationActionUnchecked)))
+		return report
+	}
+	for i := range flags {
+		flags[i].Action = moderationActionBlocked
+	}
+	recordModeration(report, append(flags, suspicious...))
+	return report
+}
+
+// ModerateAdSpecs checks every ad's copy and image prompt before rendering. Flagged ads are rewritten once by
+// Safety-Editor-GPT and checked again; ads that are still flagged are dropped.
+func (s *AIService) ModerateAdSpecs(ctx context.Context, specs []bezzmodels.AdSpec) ([]bezzmodels.AdSpec, []bezzmodels.ModerationFlag) {
+	var fields []moderationField
+	for _, spec := range specs {
+		fields = append(fields, adSpecModerationFields(spec)...)
+	}
+
+	flags, err := s.moderateFields(ctx, "ad", fields)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: Ad check failed, rendering unchecked: %v", err)
+		return specs, []bezzmodels.ModerationFlag{newModerationFlag("ad", "ads", "", err.Error(), nil, moderationActionUnchecked)}
+	}
+	if len(flags) == 0 {
+		return specs, nil
+	}
+
+	var recorded []bezzmodels.ModerationFlag
+	kept := make([]bezzmodels.AdSpec, 0, len(specs))
+	for _, spec := range specs {
+		target := fmt.Sprintf("ad %d", spec.ID)
+		adFlags := flagsForTarget(flags, target)
+		if len(adFlags) == 0 {
+			kept = append(kept, spec)
+			continue
+		}
+
+		log.Printf("🛡️ MODERATION: Ad %d flagged for %v, asking Safety-Editor-GPT to rewrite it", spec.ID, flaggedCategoryList(adFlags))
+		action := moderationActionDropped
+		if rewritten, ok := s.sanitizeAdSpec(ctx, spec, adFlags); ok {
+			kept = append(kept, rewritten)
+			action = moderationActionRegenerated
+		} else {
+			log.Printf("⚠️ MODERATION: Ad %d is still flagged after rewriting, dropping it", spec.ID)
+		}
+		for _, flag := range adFlags {
+			flag.Action = action
+			recorded = append(recorded, flag)
+		}
+	}
+	return kept, recorded
+}
+
+// adSpecModerationFields lists the text of an ad that reaches the audience or the image model
+func adSpecModerationFields(spec bezzmodels.AdSpec) []moderationField {
+	target := fmt.Sprintf("ad %d", spec.ID)
+	return []moderationField{
+		{target: target, field: "headline", text: spec.Headline},
+		{target: target, field: "body", text: spec.Body},
+		{target: target, field: "description", text: spec.Description},
+		{target: target, field: "cta", text: spec.CTA},
+		{target: target, field: "dalle_prompt", text: spec.DallePrompt},
+	}
+}
+
+// sanitizeAdSpec rewrites an ad's flagged fields and reports whether the result passes moderation
+func (s *AIService) sanitizeAdSpec(ctx context.Context, spec bezzmodels.AdSpec, flags []bezzmodels.ModerationFlag) (bezzmodels.AdSpec, bool) {
+	current := map[string]string{}
+	for _, f := range adSpecModerationFields(spec) {
+		current[f.field] = f.text
+	}
+	rewrite, err := s.rewriteFlaggedFields(ctx, current, flags)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: Safety-Editor-GPT failed for ad %d: %v", spec.ID, err)
+		return spec, false
+	}
+
+	spec.Headline = rewrite["headline"]
+	spec.Body = rewrite["body"]
+	spec.Description = rewrite["description"]
+	spec.CTA = rewrite["cta"]
+	spec.DallePrompt = rewrite["dalle_prompt"]
+
+	again, err := s.moderateFields(ctx, "ad", adSpecModerationFields(spec))
+	return spec, err == nil && len(again) == 0
+}
+
+// moderateVideoScript checks a script's voiceover, on-screen text and visual prompts before keyframes are
+// rendered. Flagged scenes are rewritten once and dropped if they are still flagged.
+func (s *AIService) moderateVideoScript(ctx context.Context, spec bezzmodels.VideoScriptSpec) (bezzmodels.VideoScriptSpec, []bezzmodels.ModerationFlag) {
+	var fields []moderationField
+	for i, scene := range spec.Scenes {
+		fields = append(fields, sceneModerationFields(spec.Duration, i, scene)...)
+	}
+
+	flags, err := s.moderateFields(ctx, "video", fields)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: %ds script check failed, rendering unchecked: %v", spec.Duration, err)
+		return spec, []bezzmodels.ModerationFlag{newModerationFlag("video", fmt.Sprintf("video_%ds", spec.Duration), "", err.Error(), nil, moderationActionUnchecked)}
+	}
+	if len(flags) == 0 {
+		return spec, nil
+	}
+
+	var recorded []bezzmodels.ModerationFlag
+	scenes := make([]bezzmodels.VideoSceneSpec, 0, len(spec.Scenes))
+	for i, scene := range spec.Scenes {
+		sceneFlags := flagsForTarget(flags, sceneModerationTarget(spec.Duration, i))
+		if len(sceneFlags) == 0 {
+			scenes = append(scenes, scene)
+			continue
+		}
+
+		action := moderationActionDropped
+		if rewritten, ok := s.sanitizeScene(ctx, spec.Duration, i, scene, sceneFlags); ok {
+			scenes = append(scenes, rewritten)
+			action = moderationActionRegenerated
+		} else {
+			log.Printf("⚠️ MODERATION: %ds scene %d is still flagged after rewriting, dropping it", spec.Duration, i+1)
+		}
+		for _, flag := range sceneFlags {
+			flag.Action = action
+			recorded = append(recorded, flag)
+		}
+	}
+	spec.Scenes = scenes
+	return spec, recorded
+}
+
+// sceneModerationTarget names a scene in moderation flags
+func sceneModerationTarget(duration, index int) string {
+	return fmt.Sprintf("video_%ds scene %d", duration, index+1)
+}
+
+// sceneModerationFields lists the text of a scene that reaches the audience or the image model
+func sceneModerationFields(duration, index int, scene bezzmodels.VideoSceneSpec) []moderationField {
+	target := sceneModerationTarget(duration, index)
+	return []moderationField{
+		{target: target, field: "description", text: scene.Description},
+		{target: target, field: "visual_prompt", text: scene.VisualPrompt},
+		{target: target, field: "voiceover", text: scene.Voiceover},
+		{target: target, field: "on_screen_text", text: scene.OnScreenText},
+	}
+}
+
+// sanitizeScene rewrites a scene's flagged fields and reports whether the result passes moderation
+func (s *AIService) sanitizeScene(ctx context.Context, duration, index int, scene bezzmodels.VideoSceneSpec, flags []bezzmodels.ModerationFlag) (bezzmodels.VideoSceneSpec, bool) {
+	current := map[string]string{}
+	for _, f := range sceneModerationFields(duration, index, scene) {
+		current[f.field] = f.text
+	}
+	rewrite, err := s.rewriteFlaggedFields(ctx, current, flags)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: Safety-Editor-GPT failed for %s: %v", sceneModerationTarget(duration, index), err)
+		return scene, false
+	}
+
+	scene.Description = rewrite["description"]
+	scene.VisualPrompt = rewrite["visual_prompt"]
+	scene.Voiceover = rewrite["voiceover"]
+	scene.OnScreenText = rewrite["on_screen_text"]
+
+	again, err := s.moderateFields(ctx, "video", sceneModerationFields(duration, index, scene))
+	return scene, err == nil && len(again) == 0
+}
+
+// generatedTextField is a piece of generated text that is checked, and rewritten in place if flagged
+type generatedTextField struct {
+	name  string
+	value *string
+}
+
+// generatedModerationFields lists generated text for moderateFields
+func generatedModerationFields(target string, fields []generatedTextField) []moderationField {
+	checked := make([]moderationField, len(fields))
+	for i, f := range fields {
+		checked[i] = moderationField{target: target, field: f.name, text: *f.value}
+	}
+	return checked
+}
+
+// appendListFields adds each entry of a generated list as its own field, e.g. "brand_pillars[0]"
+func appendListFields(fields []generatedTextField, name string, values []string) []generatedTextField {
+	for i := range values {
+		fields = append(fields, generatedTextField{name: fmt.Sprintf("%s[%d]", name, i), value: &values[i]})
+	}
+	return fields
+}
+
+// moderateGenerated checks one piece of generated text that can't be dropped. Flagged text is rewritten once by
+// Safety-Editor-GPT and checked again; it reports whether the text is safe to use, with the flags raised marked
+// regenerated or blocked. If the moderation API fails the text goes through unchecked.
+func (s *AIService) moderateGenerated(ctx context.Context, stage, target string, fields []generatedTextField) (bool, []bezzmodels.ModerationFlag) {
+	flags, err := s.moderateFields(ctx, stage, generatedModerationFields(target, fields))
+	if err != nil {
+		log.Printf("⚠️ MODERATION: %s check failed, continuing unchecked: %v", target, err)
+		return true, []bezzmodels.ModerationFlag{newModerationFlag(stage, target, "", err.Error(), nil, moderationActionUnchecked)}
+	}
+	if len(flags) == 0 {
+		return true, nil
+	}
+
+	log.Printf("🛡️ MODERATION: %s flagged for %v, asking Safety-Editor-GPT to rewrite it", target, flaggedCategoryList(flags))
+	safe := s.sanitizeGenerated(ctx, stage, target, fields, flags)
+	action := moderationActionRegenerated
+	if !safe {
+		log.Printf("⚠️ MODERATION: %s is still flagged after rewriting, blocking it", target)
+		action = moderationActionBlocked
+	}
+	for i := range flags {
+		flags[i].Action = action
+	}
+	return safe, flags
+}
+
+// sanitizeGenerated rewrites flagged generated text in place and reports whether the result passes moderation
+func (s *AIService) sanitizeGenerated(ctx context.Context, stage, target string, fields []generatedTextField, flags []bezzmodels.ModerationFlag) bool {
+	current := map[string]string{}
+	for _, f := range fields {
+		current[f.name] = *f.value
+	}
+	rewrite, err := s.rewriteFlaggedFields(ctx, current, flags)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: Safety-Editor-GPT failed for %s: %v", target, err)
+		return false
+	}
+	for _, f := range fields {
+		*f.value = rewrite[f.name]
+	}
+
+	again, err := s.moderateFields(ctx, stage, generatedModerationFields(target, fields))
+	return err == nil && len(again) == 0
+}
+
+// ModerateStrategy checks the strategy copy before it is shown to the user or fed into the naming, identity and ad
+// stages. Flagged copy is rewritten once; if it is still flagged the strategy is unusable and the brief is blocked.
+func (s *AIService) ModerateStrategy(ctx context.Context, strategy *bezzmodels.BrandStrategy) (bool, []bezzmodels.ModerationFlag) {
+	return s.moderateGenerated(ctx, "strategy", "strategy", strategyTextFields(strategy))
+}
+
+// strategyTextFields lists the strategy's copy
+func strategyTextFields(strategy *bezzmodels.BrandStrategy) []generatedTextField {
+	fields := []generatedTextField{
+		{name: "positioning", value: &strategy.Positioning},
+		{name: "value_proposition", value: &strategy.ValueProposition},
+		{name: "tagline", value: &strategy.Tagline},
+		{name: "primary_message", value: &strategy.MessagingFramework.PrimaryMessage},
+		{name: "voice", value: &strategy.TonalGuidelines.Voice},
+	}
+	fields = appendListFields(fields, "brand_pillars", strategy.BrandPillars)
+	fields = appendListFields(fields, "supporting_messages", strategy.MessagingFramework.SupportingMessages)
+	fields = appendListFields(fields, "personality", strategy.TonalGuidelines.Personality)
+	fields = appendListFields(fields, "do", strategy.TonalGuidelines.DoAndDonts.Do)
+	fields = appendListFields(fields, "dont", strategy.TonalGuidelines.DoAndDonts.Dont)
+	for i := range strategy.TargetSegments {
+		segment := &strategy.TargetSegments[i]
+		prefix := fmt.Sprintf("target_segments[%d].", i)
+		fields = append(fields,
+			generatedTextField{name: prefix + "name", value: &segment.Name},
+			generatedTextField{name: prefix + "demographics", value: &segment.Demographics},
+			generatedTextField{name: prefix + "psychographics", value: &segment.Psychographics},
+		)
+		fields = appendListFields(fields, prefix+"pain_points", segment.PainPoints)
+		fields = appendListFields(fields, prefix+"motivations", segment.Motivations)
+	}
+	return fields
+}
+
+// ModerateBrandNames checks every suggested name and its rationale. Flagged suggestions are rewritten once and
+// dropped if they are still flagged.
+func (s *AIService) ModerateBrandNames(ctx context.Context, names []bezzmodels.BrandNameSuggestion) ([]bezzmodels.BrandNameSuggestion, []bezzmodels.ModerationFlag) {
+	var fields []moderationField
+	for i := range names {
+		fields = append(fields, generatedModerationFields(brandNameModerationTarget(i), brandNameTextFields(&names[i]))...)
+	}
+
+	flags, err := s.moderateFields(ctx, "names", fields)
+	if err != nil {
+		log.Printf("⚠️ MODERATION: Brand name check failed, continuing unchecked: %v", err)
+		return names, []bezzmodels.ModerationFlag{newModerationFlag("names", "brand names", "", err.Error(), nil, moderationActionUnchecked)}
+	}
+	if len(flags) == 0 {
+		return names, nil
+	}
+
+	var recorded []bezzmodels.ModerationFlag
+	kept := make([]bezzmodels.BrandNameSuggestion, 0, len(names))
+	for i, name := range names {
+		target := brandNameModerationTarget(i)
+		nameFlags := flagsForTarget(flags, target)
+		if len(nameFlags) == 0 {
+			kept = append(kept, name)
+			continue
+		}
+
+		action := moderationActionDropped
+		if s.sanitizeGenerated(ctx, "names", target, brandNameTextFields(&name), nameFlags) {
+			kept = append(kept, name)
+			action = moderationActionRegenerated
+		} else {
+			log.Printf("⚠️ MODERATION: %s is still flagged after rewriting, dropping it", target)
+		}
+		for _, flag := range nameFlags {
+			flag.Action = action
+			recorded = append(recorded, flag)
+		}
+	}
+	return kept, recorded
+}
+
+// brandNameModerationTarget names a brand name suggestion in moderation flags
+func brandNameModerationTarget(index int) string {
+	return fmt.Sprintf("brand name %d", index+1)
+}
+
+// brandNameTextFields lists a suggestion's text
+func brandNameTextFields(name *bezzmodels.BrandNameSuggestion) []generatedTextField {
+	return []generatedTextField{
+		{name: "name", value: &name.Name},
+		{name: "rationale", value: &name.Rationale},
+	}
+}
+
+// identityTextFields lists Logo-Designer-GPT's text: what the user sees, what Vector-Logo-GPT is given and,
+// when a logo will be generated, the image prompt
+func identityTextFields(response *bezzmodels.LogoDesignerGPTResponse, generatingLogo bool) []generatedTextField {
+	fields := []generatedTextField{{name: "logo_concept", value: &response.LogoConcept}}
+	if generatingLogo {
+		fields = append(fields, generatedTextField{name: "dalle_prompt", value: &response.DallePrompt})
+	}
+	for i := range response.ColorPalette {
+		color := &response.ColorPalette[i]
+		prefix := fmt.Sprintf("color_palette[%d].", i)
+		fields = append(fields,
+			generatedTextField{name: prefix + "name", value: &color.Name},
+			generatedTextField{name: prefix + "usage", value: &color.Usage},
+			generatedTextField{name: prefix + "psychology", value: &color.Psychology},
+		)
+	}
+	return fields
+}
+
+// rewriteFlaggedFields asks Safety-Editor-GPT to rewrite content; fields it leaves out keep their text
+func (s *AIService) rewriteFlaggedFields(ctx context.Context, current map[string]string, flags []bezzmodels.ModerationFlag) (map[string]string, error) {
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal flagged content: %w", err)
+	}
+
+	prompt := fmt.Sprintf(prompts.SafetyEditorGPTPrompt, strings.Join(flaggedCategoryList(flags), ", "), string(currentJSON))
+
+	temperature := float64(0.4)
+	var response map[string]string
+	if _, _, err := s.chatJSONWithFallback(ctx, []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("You are Safety-Editor-GPT, an expert at making marketing content brand-safe. Always respond with valid JSON only."),
+		openai.UserMessage(prompt),
+	}, 1000, &temperature, &response); err != nil {
+		return nil, fmt.Errorf("Safety-Editor-GPT API call failed: %w", err)
+	}
+
+	rewrite := make(map[string]string, len(current))
+	for field, text := range current {
+		rewrite[field] = text
+		if replacement := strings.TrimSpace(response[field]); replacement != "" {
+			rewrite[field] = replacement
+		}
+	}
+	return rewrite, nil
+}
+
+// flagsForTarget returns the flags raised on one ad or scene
+func flagsForTarget(flags []bezzmodels.ModerationFlag, target string) []bezzmodels.ModerationFlag {
+	var matched []bezzmodels.ModerationFlag
+	for _, flag := range flags {
+		if flag.Target == target {
+			matched = append(matched, flag)
+		}
+	}
+	return matched
+}
+
+// flaggedCategoryList returns the distinct categories across flags, sorted
+func flaggedCategoryList(flags []bezzmodels.ModerationFlag) []string {
+	seen := map[string]bool{}
+	var categories []string
+	for _, flag := range flags {
+		for _, category := range flag.Categories {
+			if !seen[category] {
+				seen[category] = true
+				categories = append(categories, category)
+			}
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// recordModeration adds flags to a report and recomputes its status. Any flag puts the brief in the review queue
+// unless an admin has already decided on it; the decision and review details are never changed here.
+func recordModeration(report *bezzmodels.ModerationReport, flags []bezzmodels.ModerationFlag) {
+	report.Flags = append(report.Flags, flags...)
+	report.CheckedAt = time.Now()
+
+	report.Status = moderationClear
+	for _, flag := range report.Flags {
+		switch {
+		case flag.Action == moderationActionBlocked:
+			report.Status = moderationBlocked
+		case flag.Action == moderationActionUnchecked && report.Status == moderationClear:
+			report.Status = moderationUnchecked
+		case flag.Action != moderationActionUnchecked && report.Status != moderationBlocked:
+			report.Status = moderationFlagged
+		}
+	}
+	if len(flags) > 0 && report.Decision == "" {
+		report.NeedsReview = true
+	}
+}
+
+// reviewedBriefStatus returns a brief's status after a review: approving a blocked brief makes it
+// retryable, and everything else keeps its status
+func reviewedBriefStatus(status, decision string) string {
+	if status == "blocked" && decision == moderationDecisionApproved {
+		return "failed"
+	}
+	return status
+}
+
+// applyModerationReview records an admin's decision on a report and takes it out of the review queue
+func applyModerationReview(report *bezzmodels.ModerationReport, reviewerID string, review *bezzmodels.ModerationReviewRequest, reviewedAt time.Time) {
+	report.NeedsReview = false
+	report.Decision = review.Decision
+	report.ReviewNote = review.Note
+	report.ReviewedBy = reviewerID
+	report.ReviewedAt = &reviewedAt
+}
+
+// ModerationBlockMessage explains a blocked brief to the user without echoing the flagged text
+func ModerationBlockMessage(report *bezzmodels.ModerationReport) string {
+	var fields []string
+	for _, flag := range report.Flags {
+		if flag.Action == moderationActionBlocked {
+			fields = append(fields, flag.Field)
+		}
+	}
+	return fmt.Sprintf("Your brief was flagged by our content policy (%s) in: %s. Please revise it and try again.",
+		strings.Join(flaggedCategoryList(report.Flags), ", "), strings.Join(fields, ", "))
+}
+
+// moderationAllowsSharing reports whether a brief may be published on a share link: an admin approved it, or every
+// check came back clear and nothing awaits review. Briefs from before moderation have no report.
+func moderationAllowsSharing(report *bezzmodels.ModerationReport) bool {
+	if report == nil {
+		return true
+	}
+	if report.Decision != "" {
+		return report.Decision == moderationDecisionApproved
+	}
+	return report.Status == moderationClear && !report.NeedsReview
+}
+
+// IsModerationBlocked reports whether a brief's inputs were blocked
+func IsModerationBlocked(report *bezzmodels.ModerationReport) bool {
+	return report != nil && report.Status == moderationBlocked
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+// keywordModerator flags any input containing one of its words, or fails every request
+type keywordModerator struct {
+	words []string
+	fail  bool
+	calls [][]string
+}
+
+func (m *keywordModerator) Moderate(ctx context.Context, inputs []string) ([]ModerationVerdict, error) {
+	m.calls = append(m.calls, inputs)
+	if m.fail {
+		return nil, fmt.Errorf("moderation unavailable")
+	}
+	verdicts := make([]ModerationVerdict, len(inputs))
+	for i, input := range inputs {
+		for _, word := range m.words {
+			if strings.Contains(strings.ToLower(input), word) {
+				verdicts[i] = ModerationVerdict{Flagged: true, Categories: []string{"violence"}}
+			}
+		}
+	}
+	return verdicts, nil
+}
+
+func TestModerateBriefRequest_BlocksFlaggedInputs(t *testing.T) {
+	s := &AIService{moderator: &keywordModerator{words: []string{"weapon"}}}
+
+	report := s.ModerateBriefRequest(context.Background(), &models.BrandBriefRequest{
+		CompanyName:    "Acme",
+		Tone:           "bold",
+		TargetAudience: "hunters",
+		AdditionalInfo: "Show a weapon aimed at the viewer",
+	})
+	assert.True(t, IsModerationBlocked(report))
+	assert.True(t, report.NeedsReview)
+	require.Len(t, report.Flags, 1)
+	assert.Equal(t, "additionalInfo", report.Flags[0].Field)
+	assert.Equal(t, moderationActionBlocked, report.Flags[0].Action)
+	assert.Contains(t, ModerationBlockMessage(report), "violence")
+	assert.Contains(t, ModerationBlockMessage(report), "additionalInfo")
+
+	clean := s.ModerateBriefRequest(context.Background(), &models.BrandBriefRequest{CompanyName: "Acme", Tone: "warm", TargetAudience: "parents"})
+	assert.Equal(t, moderationClear, clean.Status)
+	assert.False(t, clean.NeedsReview)
+}
+
+func TestModerateBriefRequest_AcceptsUncheckedWhenModerationFails(t *testing.T) {
+	s := &AIService{moderator: &keywordModerator{fail: true}}
+
+	report := s.ModerateBriefRequest(context.Background(), &models.BrandBriefRequest{CompanyName: "Acme"})
+	assert.False(t, IsModerationBlocked(report))
+	assert.Equal(t, moderationUnchecked, report.Status)
+	assert.True(t, report.NeedsReview, "unchecked briefs go to the review queue")
+}
+
+func TestModerateAdSpecs_BatchesCleanAdsInOneCall(t *testing.T) {
+	moderator := &keywordModerator{words: []string{"weapon"}}
+	s := &AIService{moderator: moderator}
+
+	specs := []models.AdSpec{
+		{ID: 1, Headline: "Mornings, sorted", Body: "Fresh coffee", CTA: "Shop Now", DallePrompt: "A sunny kitchen"},
+		{ID: 2, Headline: "Brew better", DallePrompt: "A barista at work"},
+	}
+	kept, flags := s.ModerateAdSpecs(context.Background(), specs)
+	assert.Equal(t, specs, kept)
+	assert.Empty(t, flags)
+	require.Len(t, moderator.calls, 1)
+	assert.Len(t, moderator.calls[0], 6, "empty fields are not sent")
+}
+
+func TestModerateVideoScript_RecordsUncheckedOnFailure(t *testing.T) {
+	s := &AIService{moderator: &keywordModerator{fail: true}}
+
+	spec := models.VideoScriptSpec{Duration: 15, Scenes: []models.VideoSceneSpec{{VisualPrompt: "city at dawn", Voiceover: "Start calm."}}}
+	got, flags := s.moderateVideoScript(context.Background(), spec)
+	assert.Equal(t, spec, got)
+	require.Len(t, flags, 1)
+	assert.Equal(t, moderationActionUnchecked, flags[0].Action)
+	assert.Equal(t, "video_15s", flags[0].Target)
+}
+
+func TestModerateStrategy_ChecksAllCopyInOneCall(t *testing.T) {
+	moderator := &keywordModerator{words: []string{"weapon"}}
+	s := &AIService{moderator: moderator}
+
+	strategy := &models.BrandStrategy{
+		Positioning:  "The neighbourhood roaster",
+		Tagline:      "Mornings, sorted",
+		BrandPillars: []string{"Craft", "Warmth"},
+		TargetSegments: []models.TargetSegment{
+			{Name: "Commuters", PainPoints: []string{"No time"}},
+		},
+	}
+	safe, flags := s.ModerateStrategy(context.Background(), strategy)
+	assert.True(t, safe)
+	assert.Empty(t, flags)
+	require.Len(t, moderator.calls, 1)
+	assert.ElementsMatch(t, []string{"The neighbourhood roaster", "Mornings, sorted", "Craft", "Warmth", "Commuters", "No time"}, moderator.calls[0])
+}
+
+func TestModerateStrategy_GoesThroughUncheckedWhenModerationFails(t *testing.T) {
+	s := &AIService{moderator: &keywordModerator{fail: true}}
+
+	safe, flags := s.ModerateStrategy(context.Background(), &models.BrandStrategy{Positioning: "The neighbourhood roaster"})
+	assert.True(t, safe)
+	require.Len(t, flags, 1)
+	assert.Equal(t, moderationActionUnchecked, flags[0].Action)
+}
+
+func TestModerateBrandNames_KeepsCleanNames(t *testing.T) {
+	moderator := &keywordModerator{words: []string{"weapon"}}
+	s := &AIService{moderator: moderator}
+
+	names := []models.BrandNameSuggestion{{Name: "Brewly", Rationale: "Short and warm"}, {Name: "Roastery"}}
+	kept, flags := s.ModerateBrandNames(context.Background(), names)
+	assert.Equal(t, names, kept)
+	assert.Empty(t, flags)
+	require.Len(t, moderator.calls, 1)
+	assert.Len(t, moderator.calls[0], 3, "empty fields are not sent")
+}
+
+func TestIdentityTextFields_ImagePromptOnlyWhenGeneratingTheLogo(t *testing.T) {
+	response := &models.LogoDesignerGPTResponse{
+		LogoConcept:  "A steaming cup",
+		DallePrompt:  "Minimal cup logo",
+		ColorPalette: []models.Color{{Name: "Espresso", Hex: "#3B2A20", Usage: "primary"}},
+	}
+
+	names := func(fields []generatedTextField) []string {
+		var got []string
+		for _, f := range fields {
+			got = append(got, f.name)
+		}
+		return got
+	}
+	assert.Contains(t, names(identityTextFields(response, true)), "dalle_prompt")
+	assert.NotContains(t, names(identityTextFields(response, false)), "dalle_prompt")
+	assert.Contains(t, names(identityTextFields(response, false)), "color_palette[0].name")
+}
+
+func TestRecordModeration_Status(t *testing.T) {
+	report := &models.ModerationReport{}
+	recordModeration(report, nil)
+	assert.Equal(t, moderationClear, report.Status)
+	assert.False(t, report.NeedsReview)
+
+	recordModeration(report, []models.ModerationFlag{{Action: moderationActionUnchecked}})
+	assert.Equal(t, moderationUnchecked, report.Status)
+
+	recordModeration(report, []models.ModerationFlag{{Action: moderationActionRegenerated}})
+	assert.Equal(t, moderationFlagged, report.Status)
+	assert.True(t, report.NeedsReview)
+	assert.Len(t, report.Flags, 2)
+}
+
+func TestRecordModeration_KeepsReviewSavedDuringProcessing(t *testing.T) {
+	report := &models.ModerationReport{Status: moderationClear}
+	recordModeration(report, []models.ModerationFlag{{Stage: "strategy", Action: moderationActionRegenerated}})
+	require.True(t, report.NeedsReview)
+
+	// An admin approves the brief while later stages are still running
+	reviewedAt := time.Now()
+	applyModerationReview(report, "admin-1", &models.ModerationReviewRequest{Decision: moderationDecisionApproved, Note: "fine"}, reviewedAt)
+
+	recordModeration(report, []models.ModerationFlag{{Stage: "ad", Action: moderationActionRegenerated}})
+	assert.Len(t, report.Flags, 2, "flags from after the review are still recorded")
+	assert.Equal(t, moderationFlagged, report.Status)
+	assert.False(t, report.NeedsReview, "a reviewed brief doesn't return to the queue")
+	assert.Equal(t, moderationDecisionApproved, report.Decision)
+	assert.Equal(t, "admin-1", report.ReviewedBy)
+	require.NotNil(t, report.ReviewedAt)
+	assert.Equal(t, reviewedAt, *report.ReviewedAt)
+	assert.True(t, moderationAllowsSharing(report))
+}
+
+func TestReviewedBriefStatus(t *testing.T) {
+	assert.Equal(t, "failed", reviewedBriefStatus("blocked", moderationDecisionApproved), "approved briefs can be retried")
+	assert.Equal(t, "blocked", reviewedBriefStatus("blocked", moderationDecisionRejected))
+	assert.Equal(t, "completed", reviewedBriefStatus("completed", moderationDecisionApproved))
+	assert.Equal(t, "completed", reviewedBriefStatus("completed", moderationDecisionRejected))
+}
+
+func TestFlaggedCategories(t *testing.T) {
+	raw := `{"harassment":false,"violence":true,"self-harm/intent":true,"sexual":false}`
+	assert.Equal(t, []string{"self-harm/intent", "violence"}, flaggedCategories(raw))
+	assert.Nil(t, flaggedCategories("not json"))
+}
+
+func TestNewModerationFlag_TruncatesExcerpt(t *testing.T) {
+	flag := newModerationFlag("ad", "ad 1", "body", strings.Repeat("é", 300), nil, moderationActionDropped)
+	assert.Equal(t, moderationExcerptLength+1, len([]rune(flag.Excerpt)))
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+const (
+	paletteSampleSize    = 96   // images are sampled on a grid of at most this many pixels per side
+	paletteClusters      = 6    // k for k-means, before small and duplicate clusters are dropped
+	paletteIterations    = 12   // k-means iterations; logos converge quickly
+	paletteMinShare      = 0.02 // clusters covering less than this share of the logo are noise or anti-aliasing
+	paletteMergeDistance = 40.0 // clusters closer than this (weighted RGB distance) are the same brand color
+	backgroundDistance   = 48.0 // pixels this close to the background color are treated as background
+	paletteMaxColors     = 4
+	neutralChroma        = 0.12 // below this chroma (max-min channel) a color is treated as neutral
+)
+
+// namedColor is a reference color used to give extracted colors human-readable names
+type namedColor struct {
+	name string
+	rgb  color.RGBA
+}
+
+// Reference names for extracted colors - nearest match wins
+var colorNames = []namedColor{
+	{"Black", color.RGBA{0x11, 0x11, 0x11, 0xff}},
+	{"Charcoal", color.RGBA{0x36, 0x45, 0x4f, 0xff}},
+	{"Slate Gray", color.RGBA{0x70, 0x80, 0x90, 0xff}},
+	{"Silver", color.RGBA{0xc0, 0xc0, 0xc0, 0xff}},
+	{"Off White", color.RGBA{0xf5, 0xf5, 0xf0, 0xff}},
+	{"White", color.RGBA{0xff, 0xff, 0xff, 0xff}},
+	{"Deep Navy", color.RGBA{0x0a, 0x1f, 0x44, 0xff}},
+	{"Royal Blue", color.RGBA{0x41, 0x69, 0xe1, 0xff}},
+	{"Sky Blue", color.RGBA{0x87, 0xce, 0xeb, 0xff}},
+	{"Teal", color.RGBA{0x00, 0x80, 0x80, 0xff}},
+	{"Turquoise", color.RGBA{0x40, 0xe0, 0xd0, 0xff}},
+	{"Forest Green", color.RGBA{0x22, 0x8b, 0x22, 0xff}},
+	{"Emerald", color.RGBA{0x50, 0xc8, 0x78, 0xff}},
+	{"Olive", color.RGBA{0x80, 0x80, 0x00, 0xff}},
+	{"Lime", color.RGBA{0x9a, 0xcd, 0x32, 0xff}},
+	{"Mint", color.RGBA{0x98, 0xff, 0x98, 0xff}},
+	{"Golden Yellow", color.RGBA{0xff, 0xc3, 0x00, 0xff}},
+	{"Mustard", color.RGBA{0xe1, 0xad, 0x01, 0xff}},
+	{"Cream", color.RGBA{0xff, 0xfd, 0xd0, 0xff}},
+	{"Amber", color.RGBA{0xff, 0xbf, 0x00, 0xff}},
+	{"Orange", color.RGBA{0xff, 0x8c, 0x00, 0xff}},
+	{"Terracotta", color.RGBA{0xe2, 0x72, 0x5b, 0xff}},
+	{"Coral", color.RGBA{0xff, 0x7f, 0x50, 0xff}},
+	{"Crimson", color.RGBA{0xdc, 0x14, 0x3c, 0xff}},
+	{"Red", color.RGBA{0xe5, 0x28, 0x28, 0xff}},
+	{"Burgundy", color.RGBA{0x80, 0x00, 0x20, 0xff}},
+	{"Rose", color.RGBA{0xff, 0x66, 0x99, 0xff}},
+	{"Blush Pink", color.RGBA{0xf4, 0xc2, 0xc2, 0xff}},
+	{"Magenta", color.RGBA{0xd0, 0x20, 0x90, 0xff}},
+	{"Purple", color.RGBA{0x6a, 0x0d, 0xad, 0xff}},
+	{"Lavender", color.RGBA{0xb5, 0x7e, 0xdc, 0xff}},
+	{"Indigo", color.RGBA{0x4b, 0x00, 0x82, 0xff}},
+	{"Chocolate Brown", color.RGBA{0x5c, 0x33, 0x17, 0xff}},
+	{"Tan", color.RGBA{0xd2, 0xb4, 0x8c, 0xff}},
+	{"Beige", color.RGBA{0xe8, 0xdc, 0xc4, 0xff}},
+}
+
+// paletteCluster is one k-means cluster of sampled pixels
+type paletteCluster struct {
+	center [3]float64
+	count  int
+}
+
+// ExtractPalette derives up to four brand colors from a logo with k-means clustering,
+// ignoring transparent pixels and the background color, and assigns usage roles
+func ExtractPalette(img image.Image) []bezzmodels.Color {
+	samples := samplePixels(img)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	clusters := kMeans(samples, paletteClusters)
+	clusters = mergeClusters(clusters, len(samples))
+	if len(clusters) == 0 {
+		return nil
+	}
+
+	return assignPaletteRoles(clusters)
+}
+
+// samplePixels returns opaque pixels on a sampling grid, minus those matching the logo's background
+func samplePixels(img image.Image) [][3]float64 {
+	b := img.Bounds()
+	if b.Empty() {
+		return nil
+	}
+
+	step := max(1, max(b.Dx(), b.Dy())/paletteSampleSize)
+	background, hasBackground := detectBackground(img)
+
+	var samples [][3]float64
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			c, ok := opaqueRGBA(img.At(x, y))
+			if !ok {
+				continue
+			}
+			p := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+			if hasBackground && rgbDistance(p, background) < backgroundDistance {
+				continue
+			}
+			samples = append(samples, p)
+		}
+	}
+	return samples
+}
+
+// detectBackground returns the dominant opaque border color, if most of the border shares it
+func detectBackground(img image.Image) ([3]float64, bool) {
+	b := img.Bounds()
+	counts := make(map[[3]uint8]int)
+	total := 0
+
+	addPixel := func(x, y int) {
+		c, ok := opaqueRGBA(img.At(x, y))
+		total++
+		if !ok {
+			return
+		}
+		// Quantise so compression noise doesn't split the background
+		counts[[3]uint8{c.R &^ 0x0f, c.G &^ 0x0f, c.B &^ 0x0f}]++
+	}
+	for x := b.Min.X; x < b.Max.X; x++ {
+		addPixel(x, b.Min.Y)
+		addPixel(x, b.Max.Y-1)
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		addPixel(b.Min.X, y)
+		addPixel(b.Max.X-1, y)
+	}
+
+	var best [3]uint8
+	bestCount := 0
+	for key, count := range counts {
+		if count > bestCount {
+			best, bestCount = key, count
+		}
+	}
+	if total == 0 || float64(bestCount)/float64(total) < 0.6 {
+		return [3]float64{}, false
+	}
+	return [3]float64{float64(best[0]) + 8, float64(best[1]) + 8, float64(best[2]) + 8}, true
+}
+
+// opaqueRGBA converts a pixel to 8-bit RGBA, reporting false for mostly transparent pixels
+func opaqueRGBA(c color.Color) (color.RGBA, bool) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A < 128 {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{R: n.R, G: n.G, B: n.B, A: 0xff}, true
+}
+
+// kMeans clusters samples with deterministic farthest-point seeding
+func kMeans(samples [][3]float64, k int) []paletteCluster {
+	k = min(k, len(samples))
+	centers := make([][3]float64, 0, k)
+	centers = append(centers, samples[0])
+	for len(centers) < k {
+		farthest, farthestDist := samples[0], -1.0
+		for _, s := range samples {
+			nearest := math.MaxFloat64
+			for _, c := range centers {
+				nearest = math.Min(nearest, rgbDistance(s, c))
+			}
+			if nearest > farthestDist {
+				farthest, farthestDist = s, nearest
+			}
+		}
+		if farthestDist == 0 {
+			break
+		}
+		centers = append(centers, farthest)
+	}
+
+	assignments := make([]int, len(samples))
+	for iter := 0; iter < paletteIterations; iter++ {
+		for i, s := range samples {
+			best, bestDist := 0, math.MaxFloat64
+			for j, c := range centers {
+				if d := rgbDistance(s, c); d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+			assignments[i] = best
+		}
+
+		sums := make([][3]float64, len(centers))
+		counts := make([]int, len(centers))
+		for i, s := range samples {
+			j := assignments[i]
+			sums[j][0] += s[0]
+			sums[j][1] += s[1]
+			sums[j][2] += s[2]
+			counts[j]++
+		}
+		for j := range centers {
+			if counts[j] > 0 {
+				centers[j] = [3]float64{sums[j][0] / float64(counts[j]), sums[j][1] / float64(counts[j]), sums[j][2] / float64(counts[j])}
+			}
+		}
+	}
+
+	clusters := make([]paletteCluster, len(centers))
+	for j := range centers {
+		clusters[j].center = centers[j]
+	}
+	for _, j := range assignments {
+		clusters[j].count++
+	}
+	return clusters
+}
+
+// mergeClusters folds near-identical clusters together and drops clusters too small to matter
+func mergeClusters(clusters []paletteCluster, total int) []paletteCluster {
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].count > clusters[j].count })
+
+	var merged []paletteCluster
+	for _, c := range clusters {
+		if c.count == 0 {
+			continue
+		}
+		folded := false
+		for i := range merged {
+			if rgbDistance(merged[i].center, c.center) < paletteMergeDistance {
+				merged[i].count += c.count
+				folded = true
+				break
+			}
+		}
+		if !folded {
+			merged = append(merged, c)
+		}
+	}
+
+	var significant []paletteCluster
+	for _, c := range merged {
+		if float64(c.count)/float64(total) >= paletteMinShare {
+			significant = append(significant, c)
+		}
+	}
+	return significant
+}
+
+// assignPaletteRoles picks primary, secondary and accent colors from clusters sorted by coverage,
+// keeping one neutral if the logo uses one
+func assignPaletteRoles(clusters []paletteCluster) []bezzmodels.Color {
+	var chromatic, neutral []color.RGBA
+	for _, c := range clusters {
+		rgb := color.RGBA{R: clampByte(c.center[0]), G: clampByte(c.center[1]), B: clampByte(c.center[2]), A: 0xff}
+		if chroma(rgb) >= neutralChroma {
+			chromatic = append(chromatic, rgb)
+		} else {
+			neutral = append(neutral, rgb)
+		}
+	}
+
+	// Monochrome logos: the main neutral becomes the primary color
+	if len(chromatic) == 0 && len(neutral) > 0 {
+		chromatic, neutral = neutral[:1], neutral[1:]
+	}
+
+	// The accent is the most saturated remaining color, not simply the third largest
+	if len(chromatic) > 2 {
+		rest := chromatic[2:]
+		sort.SliceStable(rest, func(i, j int) bool { return saturation(rest[i]) > saturation(rest[j]) })
+	}
+
+	roles := []string{"primary", "secondary", "accent"}
+	var palette []bezzmodels.Color
+	for i, rgb := range chromatic {
+		if i >= len(roles) {
+			break
+		}
+		palette = append(palette, bezzmodels.Color{Name: nearestColorName(rgb), Hex: hexString(rgb), Usage: roles[i]})
+	}
+	if len(neutral) > 0 && len(palette) < paletteMaxColors {
+		palette = append(palette, bezzmodels.Color{Name: nearestColorName(neutral[0]), Hex: hexString(neutral[0]), Usage: "neutral"})
+	}
+	return palette
+}
+
+// nearestColorName returns the reference name closest to c
+func nearestColorName(c color.RGBA) string {
+	p := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+	best, bestDist := colorNames[0].name, math.MaxFloat64
+	for _, named := range colorNames {
+		q := [3]float64{float64(named.rgb.R), float64(named.rgb.G), float64(named.rgb.B)}
+		if d := rgbDistance(p, q); d < bestDist {
+			best, bestDist = named.name, d
+		}
+	}
+	return best
+}
+
+// rgbDistance is a perceptually weighted ("redmean") distance between two RGB colors
+func rgbDistance(a, b [3]float64) float64 {
+	rMean := (a[0] + b[0]) / 2
+	dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return math.Sqrt((2+rMean/256)*dr*dr + 4*dg*dg + (2+(255-rMean)/256)*db*db)
+}
+
+// chroma returns the spread between the strongest and weakest channel in [0, 1]; unlike
+// HSL saturation it stays low for dark grays and off-whites
+func chroma(c color.RGBA) float64 {
+	maxC := math.Max(float64(c.R), math.Max(float64(c.G), float64(c.B)))
+	minC := math.Min(float64(c.R), math.Min(float64(c.G), float64(c.B)))
+	return (maxC - minC) / 255
+}
+
+// saturation returns the HSL saturation of c in [0, 1]
+func saturation(c color.RGBA) float64 {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	maxC, minC := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	if maxC == minC {
+		return 0
+	}
+	l := (maxC + minC) / 2
+	if l > 0.5 {
+		return (maxC - minC) / (2 - maxC - minC)
+	}
+	return (maxC - minC) / (maxC + minC)
+}
+
+// clampByte rounds v into the 0-255 range
+func clampByte(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// WCAG 2.2 contrast thresholds (success criteria 1.4.3, 1.4.6 and 1.4.11)
+const (
+	contrastAA       = 4.5
+	contrastAALarge  = 3.0
+	contrastAAA      = 7.0
+	contrastAAALarge = 4.5
+)
+
+var (
+	paletteWhite   = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	paletteBlack   = color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
+	darkBackground = color.RGBA{R: 0x12, G: 0x12, B: 0x12, A: 0xff} // Material-style dark surface, softer than pure black
+)
+
+// tintSteps are the mix ratios used for tints (toward white) and shades (toward black)
+var tintSteps = []float64{0.2, 0.4, 0.6, 0.8}
+
+// AnalyzePalette validates a generated palette and analyses it. Colors with invalid hex codes are
+// dropped from the returned palette and reported as issues; valid hex codes are normalised to #RRGGBB.
+func AnalyzePalette(palette []bezzmodels.Color) ([]bezzmodels.Color, *bezzmodels.PaletteAnalysis) {
+	analysis := &bezzmodels.PaletteAnalysis{}
+
+	var valid []bezzmodels.Color
+	var rgbs []color.RGBA
+	for _, c := range palette {
+		rgb, err := parseHexColor(c.Hex)
+		if err != nil {
+			analysis.Issues = append(analysis.Issues, fmt.Sprintf("Dropped %s: %q is not a valid hex color", colorLabel(c), c.Hex))
+			continue
+		}
+		c.Hex = hexString(rgb)
+		valid = append(valid, c)
+		rgbs = append(rgbs, rgb)
+		analysis.Colors = append(analysis.Colors, colorSpec(c, rgb))
+	}
+
+	analysis.Contrast, analysis.Issues = contrastChecks(valid, rgbs, analysis.Issues)
+	analysis.Harmony = paletteHarmony(valid, rgbs)
+	analysis.DarkMode = darkModePalette(valid, rgbs)
+	return valid, analysis
+}
+
+// colorSpec converts a palette color into every color space and builds its tints and shades
+func colorSpec(c bezzmodels.Color, rgb color.RGBA) bezzmodels.ColorSpec {
+	h, s, l := rgbToHSL(rgb)
+	cy, m, y, k := rgbToCMYK(rgb)
+
+	spec := bezzmodels.ColorSpec{
+		Name:  c.Name,
+		Hex:   c.Hex,
+		Usage: c.Usage,
+		RGB:   bezzmodels.RGBValue{R: int(rgb.R), G: int(rgb.G), B: int(rgb.B)},
+		HSL:   bezzmodels.HSLValue{H: roundTo(h, 1), S: roundTo(s*100, 1), L: roundTo(l*100, 1)},
+		CMYK:  bezzmodels.CMYKValue{C: math.Round(cy * 100), M: math.Round(m * 100), Y: math.Round(y * 100), K: math.Round(k * 100)},
+	}
+	for _, t := range tintSteps {
+		spec.Tints = append(spec.Tints, hexString(mixColors(rgb, paletteWhite, t)))
+		spec.Shades = append(spec.Shades, hexString(mixColors(rgb, paletteBlack, t)))
+	}
+	return spec
+}
+
+// contrastChecks measures every pair of palette colors, and each color against white and black.
+// Palette pairs that fail even large-text AA are reported as issues.
+func contrastChecks(palette []bezzmodels.Color, rgbs []color.RGBA, issues []string) ([]bezzmodels.ContrastCheck, []string) {
+	var checks []bezzmodels.ContrastCheck
+	for i := range palette {
+		for j := i + 1; j < len(palette); j++ {
+			check := contrastCheck(rgbs[i], rgbs[j])
+			checks = append(checks, check)
+			if !check.Usable {
+				issues = append(issues, fmt.Sprintf("%s and %s have a contrast of only %.2f:1; never set text in one on the other",
+					colorLabel(palette[i]), colorLabel(palette[j]), check.Ratio))
+			}
+		}
+		checks = append(checks, contrastCheck(rgbs[i], paletteWhite), contrastCheck(rgbs[i], paletteBlack))
+	}
+	return checks, issues
+}
+
+// contrastCheck grades one foreground/background pair against WCAG 2.2
+func contrastCheck(fg, bg color.RGBA) bezzmodels.ContrastCheck {
+	ratio := contrastRatio(fg, bg)
+	return bezzmodels.ContrastCheck{
+		Foreground: hexString(fg),
+		Background: hexString(bg),
+		Ratio:      roundTo(ratio, 2),
+		AA:         ratio >= contrastAA,
+		AALarge:    ratio >= contrastAALarge,
+		AAA:        ratio >= contrastAAA,
+		AAALarge:   ratio >= contrastAAALarge,
+		Usable:     ratio >= contrastAALarge,
+	}
+}
+
+// paletteHarmony classifies the hue relationships between the chromatic colors and suggests
+// harmonious companions for the first of them
+func paletteHarmony(palette []bezzmodels.Color, rgbs []color.RGBA) bezzmodels.PaletteHarmony {
+	var hues []float64
+	base := paletteBlack
+	for i, rgb := range rgbs {
+		if chroma(rgb) < neutralChroma {
+			continue
+		}
+		h, _, _ := rgbToHSL(rgb)
+		if len(hues) == 0 {
+			base = rgbs[i]
+		}
+		hues = append(hues, h)
+	}
+	if len(hues) == 0 && len(rgbs) > 0 {
+		base = rgbs[0]
+	}
+
+	h, s, l := rgbToHSL(base)
+	rotate := func(degrees ...float64) []string {
+		hexes := make([]string, len(degrees))
+		for i, d := range degrees {
+			hexes[i] = hexString(hslToRGB(h+d, s, l))
+		}
+		return hexes
+	}
+
+	return bezzmodels.PaletteHarmony{
+		Scheme:             harmonyScheme(hues),
+		Complementary:      rotate(180)[0],
+		Analogous:          rotate(-30, 30),
+		SplitComplementary: rotate(150, 210),
+		Triadic:            rotate(120, 240),
+	}
+}
+
+// harmonyScheme names the relationship between hues, measured from the first
+func harmonyScheme(hues []float64) string {
+	if len(hues) == 0 {
+		return "neutral"
+	}
+
+	var distances []float64
+	for _, h := range hues[1:] {
+		d := math.Abs(h - hues[0])
+		distances = append(distances, math.Min(d, 360-d))
+	}
+
+	within := func(lo, hi float64) int {
+		n := 0
+		for _, d := range distances {
+			if d >= lo && d <= hi {
+				n++
+			}
+		}
+		return n
+	}
+
+	switch {
+	case within(0, 15) == len(distances):
+		return "monochromatic"
+	case within(0, 60) == len(distances):
+		return "analogous"
+	case within(100, 135) >= 2:
+		return "triadic"
+	case within(135, 170) >= 2:
+		return "split_complementary"
+	case within(160, 180) >= 1:
+		return "complementary"
+	default:
+		return "custom"
+	}
+}
+
+// darkModePalette adapts the palette to a dark background: colors are slightly desaturated and
+// lightened until they reach AA contrast, neutrals are inverted
+func darkModePalette(palette []bezzmodels.Color, rgbs []color.RGBA) []bezzmodels.Color {
+	dark := []bezzmodels.Color{{
+		Name:       "Dark Background",
+		Hex:        hexString(darkBackground),
+		Usage:      "background",
+		Psychology: "Dark-mode surface; softer than pure black to reduce glare",
+	}}
+
+	for i, c := range palette {
+		h, s, l := rgbToHSL(rgbs[i])
+		if chroma(rgbs[i]) < neutralChroma {
+			l = 1 - l
+		} else {
+			s *= 0.85
+		}
+
+		adapted := hslToRGB(h, s, l)
+		for contrastRatio(adapted, darkBackground) < contrastAA && l < 0.95 {
+			l = math.Min(0.95, l+0.02)
+			adapted = hslToRGB(h, s, l)
+		}
+
+		c.Hex = hexString(adapted)
+		dark = append(dark, c)
+	}
+	return dark
+}
+
+// colorLabel names a palette color for issue messages
+func colorLabel(c bezzmodels.Color) string {
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		name = "Unnamed color"
+	}
+	if c.Usage != "" {
+		return fmt.Sprintf("%s (%s)", name, c.Usage)
+	}
+	return name
+}
+
+// roundTo rounds v to the given number of decimal places
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestContrastRatio_WCAGReferenceValues(t *testing.T) {
+	assert.InDelta(t, 21.0, contrastRatio(paletteBlack, paletteWhite), 0.01)
+	assert.InDelta(t, 1.0, contrastRatio(paletteWhite, paletteWhite), 0.001)
+	// #767676 is the lightest gray that passes AA on white
+	assert.InDelta(t, 4.54, contrastRatio(color.RGBA{0x76, 0x76, 0x76, 0xff}, paletteWhite), 0.01)
+}
+
+func TestColorConversions(t *testing.T) {
+	navy := color.RGBA{0x0a, 0x25, 0x40, 0xff}
+	h, s, l := rgbToHSL(navy)
+	assert.Equal(t, navy, hslToRGB(h, s, l))
+
+	h, s, l = rgbToHSL(color.RGBA{0xff, 0x00, 0x00, 0xff})
+	assert.Equal(t, []float64{0, 1, 0.5}, []float64{h, s, l})
+
+	c, m, y, k := rgbToCMYK(color.RGBA{0xff, 0x80, 0x00, 0xff})
+	assert.InDelta(t, 0, c, 0.001)
+	assert.InDelta(t, 0.498, m, 0.001)
+	assert.InDelta(t, 1, y, 0.001)
+	assert.InDelta(t, 0, k, 0.001)
+
+	_, _, _, k = rgbToCMYK(paletteBlack)
+	assert.Equal(t, 1.0, k)
+}
+
+func TestAnalyzePalette_DropsInvalidHexAndFlagsContrast(t *testing.T) {
+	palette, analysis := AnalyzePalette([]models.Color{
+		{Name: "Sunshine", Hex: "#ffd60a", Usage: "primary"},
+		{Name: "Cream", Hex: "fff8e7", Usage: "secondary"},
+		{Name: "Mystery", Hex: "#GGHHII", Usage: "accent"},
+	})
+
+	require.Len(t, palette, 2)
+	assert.Equal(t, "#FFD60A", palette[0].Hex)
+	assert.Equal(t, "#FFF8E7", palette[1].Hex)
+
+	require.Len(t, analysis.Colors, 2)
+	assert.Equal(t, models.RGBValue{R: 255, G: 214, B: 10}, analysis.Colors[0].RGB)
+	assert.Len(t, analysis.Colors[0].Tints, len(tintSteps))
+	assert.Len(t, analysis.Colors[0].Shades, len(tintSteps))
+
+	// One palette pair plus white and black for each color
+	require.Len(t, analysis.Contrast, 5)
+	assert.False(t, analysis.Contrast[0].Usable)
+
+	require.Len(t, analysis.Issues, 2)
+	assert.Contains(t, analysis.Issues[0], "Mystery (accent)")
+	assert.Contains(t, analysis.Issues[1], "Sunshine (primary) and Cream (secondary)")
+}
+
+func TestAnalyzePalette_DarkModeMeetsAA(t *testing.T) {
+	_, analysis := AnalyzePalette([]models.Color{
+		{Name: "Deep Navy", Hex: "#0A2540", Usage: "primary"},
+		{Name: "Charcoal", Hex: "#222222", Usage: "neutral"},
+	})
+
+	require.Len(t, analysis.DarkMode, 3)
+	assert.Equal(t, "background", analysis.DarkMode[0].Usage)
+	for _, c := range analysis.DarkMode[1:] {
+		rgb, err := parseHexColor(c.Hex)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, contrastRatio(rgb, darkBackground), contrastAA, c.Name)
+	}
+	assert.Equal(t, "Deep Navy", analysis.DarkMode[1].Name)
+}
+
+func TestHarmonyScheme(t *testing.T) {
+	assert.Equal(t, "neutral", harmonyScheme(nil))
+	assert.Equal(t, "monochromatic", harmonyScheme([]float64{210}))
+	assert.Equal(t, "analogous", harmonyScheme([]float64{210, 180, 250}))
+	assert.Equal(t, "complementary", harmonyScheme([]float64{210, 30}))
+	assert.Equal(t, "triadic", harmonyScheme([]float64{0, 120, 240}))
+	assert.Equal(t, "split_complementary", harmonyScheme([]float64{0, 150, 210}))
+	assert.Equal(t, "custom", harmonyScheme([]float64{0, 90}))
+}
+
+func TestPaletteHarmony_Suggestions(t *testing.T) {
+	harmony := paletteHarmony(
+		[]models.Color{{Hex: "#FF0000"}},
+		[]color.RGBA{{0xff, 0x00, 0x00, 0xff}},
+	)
+	assert.Equal(t, "#00FFFF", harmony.Complementary)
+	assert.Equal(t, []string{"#00FF00", "#0000FF"}, harmony.Triadic)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fillRect(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+func TestExtractPalette_IgnoresBackgroundAndRanksByCoverage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	fillRect(img, img.Bounds(), color.White)
+	fillRect(img, image.Rect(20, 20, 180, 120), color.RGBA{0x0a, 0x25, 0x40, 0xff})   // navy mark
+	fillRect(img, image.Rect(20, 130, 120, 180), color.RGBA{0xf5, 0x8a, 0x07, 0xff})  // orange wordmark
+	fillRect(img, image.Rect(130, 130, 160, 160), color.RGBA{0xe5, 0x28, 0x28, 0xff}) // red dot
+
+	palette := ExtractPalette(img)
+	require.Len(t, palette, 3)
+
+	assert.Equal(t, "primary", palette[0].Usage)
+	assert.Equal(t, "#0A2540", palette[0].Hex)
+	assert.Equal(t, "Deep Navy", palette[0].Name)
+	assert.Equal(t, "secondary", palette[1].Usage)
+	assert.Equal(t, "#F58A07", palette[1].Hex)
+	assert.Equal(t, "accent", palette[2].Usage)
+	assert.Equal(t, "Red", palette[2].Name)
+
+	for _, c := range palette {
+		assert.NotEqual(t, "#FFFFFF", c.Hex, "background must not be part of the palette")
+	}
+}
+
+func TestExtractPalette_TransparentMonochromeLogo(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	fillRect(img, image.Rect(25, 25, 75, 75), color.NRGBA{0x11, 0x11, 0x11, 0xff})
+
+	palette := ExtractPalette(img)
+	require.Len(t, palette, 1)
+	assert.Equal(t, "#111111", palette[0].Hex)
+	assert.Equal(t, "primary", palette[0].Usage)
+	assert.Equal(t, "Black", palette[0].Name)
+}
+
+func TestExtractPalette_KeepsOneNeutral(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	fillRect(img, image.Rect(0, 0, 100, 50), color.NRGBA{0x00, 0x80, 0x80, 0xff})
+	fillRect(img, image.Rect(0, 50, 100, 100), color.NRGBA{0x36, 0x45, 0x4f, 0xff})
+	fillRect(img, image.Rect(40, 0, 60, 10), color.NRGBA{0, 0, 0, 0})
+
+	palette := ExtractPalette(img)
+	require.Len(t, palette, 2)
+	assert.Equal(t, "Teal", palette[0].Name)
+	assert.Equal(t, "primary", palette[0].Usage)
+	assert.Equal(t, "neutral", palette[1].Usage)
+}
+
+func TestExtractPalette_EmptyImage(t *testing.T) {
+	assert.Nil(t, ExtractPalette(image.NewNRGBA(image.Rect(0, 0, 10, 10))))
+}
+
+func TestNearestColorName(t *testing.T) {
+	assert.Equal(t, "White", nearestColorName(color.RGBA{0xfe, 0xfe, 0xfe, 0xff}))
+	assert.Equal(t, "Royal Blue", nearestColorName(color.RGBA{0x40, 0x6a, 0xe0, 0xff}))
+	assert.Equal(t, "Forest Green", nearestColorName(color.RGBA{0x20, 0x88, 0x25, 0xff}))
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math"
+	"strings"
+)
+
+// Minimal PDF 1.4 writer for the brand book: the standard Helvetica fonts with WinAnsi encoding, filled
+// rectangles, lines and embedded images. Coordinates are in points from the top-left corner.
+
+// A4 page size in points
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+)
+
+// pdfDocument collects pages and images and serialises them
+type pdfDocument struct {
+	pages  []*pdfPage
+	images []pdfImage
+}
+
+// pdfPage is one page's content stream
+type pdfPage struct {
+	content bytes.Buffer
+	images  map[int]bool // indexes into pdfDocument.images used on this page
+}
+
+// pdfImage is an encoded image XObject, with an optional alpha mask
+type pdfImage struct {
+	width, height int
+	filter        string // DCTDecode or FlateDecode
+	data          []byte
+	alpha         []byte // Flate-compressed 8-bit alpha, nil for opaque images
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{}
+}
+
+// AddPage starts a new A4 page
+func (d *pdfDocument) AddPage() *pdfPage {
+	page := &pdfPage{images: map[int]bool{}}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// AddImage encodes an image once so it can be drawn on any page. Opaque images are stored as JPEG;
+// images with transparency are stored losslessly with a soft mask.
+func (d *pdfDocument) AddImage(img image.Image) (int, error) {
+	b := img.Bounds()
+	rgba := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+
+	opaque := true
+	for i := 3; i < len(rgba.Pix); i += 4 {
+		if rgba.Pix[i] != 0xff {
+			opaque = false
+			break
+		}
+	}
+
+	encoded := pdfImage{width: b.Dx(), height: b.Dy()}
+	if opaque {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, rgba, &jpeg.Options{Quality: 85}); err != nil {
+			return 0, fmt.Errorf("failed to encode image: %w", err)
+		}
+		encoded.filter, encoded.data = "DCTDecode", buf.Bytes()
+	} else {
+		rgb := make([]byte, 0, b.Dx()*b.Dy()*3)
+		alpha := make([]byte, 0, b.Dx()*b.Dy())
+		for i := 0; i < len(rgba.Pix); i += 4 {
+			rgb = append(rgb, rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2])
+			alpha = append(alpha, rgba.Pix[i+3])
+		}
+		encoded.filter, encoded.data, encoded.alpha = "FlateDecode", deflate(rgb), deflate(alpha)
+	}
+
+	d.images = append(d.images, encoded)
+	return len(d.images) - 1, nil
+}
+
+func deflate(data []byte) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+// pdfColor formats a color as PDF operands
+func pdfColor(c color.RGBA) string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// Rect fills a rectangle
+func (p *pdfPage) Rect(x, y, w, h float64, fill color.RGBA) {
+	fmt.Fprintf(&p.content, "%s rg %.2f %.2f %.2f %.2f re f\n", pdfColor(fill), x, pdfPageHeight-y-h, w, h)
+}
+
+// StrokeRect outlines a rectangle
+func (p *pdfPage) StrokeRect(x, y, w, h, width float64, stroke color.RGBA) {
+	fmt.Fprintf(&p.content, "%s RG %.2f w %.2f %.2f %.2f %.2f re S\n", pdfColor(stroke), width, x, pdfPageHeight-y-h, w, h)
+}
+
+// Line draws a straight line
+func (p *pdfPage) Line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	fmt.Fprintf(&p.content, "%s RG %.2f w %.2f %.2f m %.2f %.2f l S\n", pdfColor(stroke), width, x1, pdfPageHeight-y1, x2, pdfPageHeight-y2)
+}
+
+// Text draws one line of text with its baseline at y
+func (p *pdfPage) Text(x, y, size float64, bold bool, fill color.RGBA, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&p.content, "BT %s rg /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", pdfColor(fill), font, size, x, pdfPageHeight-y, pdfEscape(text))
+}
+
+// Image draws a document image into the box
+func (p *pdfPage) Image(index int, x, y, w, h float64) {
+	p.images[index] = true
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", w, h, x, pdfPageHeight-y-h, index)
+}
+
+// ImageFit draws an image as large as fits in the box, centred, and returns the drawn rectangle
+func (p *pdfPage) ImageFit(index int, img image.Image, x, y, w, h float64) (float64, float64, float64, float64) {
+	b := img.Bounds()
+	scale := math.Min(w/float64(b.Dx()), h/float64(b.Dy()))
+	dw, dh := float64(b.Dx())*scale, float64(b.Dy())*scale
+	dx, dy := x+(w-dw)/2, y+(h-dh)/2
+	p.Image(index, dx, dy, dw, dh)
+	return dx, dy, dw, dh
+}
+
+// Bytes serialises the document
+func (d *pdfDocument) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	stream := func(dict string, data []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dict, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Object numbers: 1 catalog, 2 page tree, 3-4 fonts, then images (with masks), then page/content pairs
+	imageObj := make([]int, len(d.images))
+	next := 5
+	for i, img := range d.images {
+		if img.alpha != nil {
+			next++ // mask precedes the image
+		}
+		imageObj[i] = next
+		next++
+	}
+	firstPage := next
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for _, img := range d.images {
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /%s", img.width, img.height, img.filter)
+		if img.alpha != nil {
+			stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode", img.width, img.height), img.alpha)
+			dict += fmt.Sprintf(" /SMask %d 0 R", len(offsets))
+		}
+		stream(dict, img.data)
+	}
+
+	for i, page := range d.pages {
+		var xobjects []string
+		for index := range d.images {
+			if page.images[index] {
+				xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", index, imageObj[index]))
+			}
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> /XObject << %s >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, strings.Join(xobjects, " "), firstPage+i*2+1))
+		stream("/Filter /FlateDecode", deflate(page.content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// winAnsiSpecials maps the characters WinAnsiEncoding places in 0x80-0x9F
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88, '‰': 0x89,
+	'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95,
+	'–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// winAnsiByte maps a rune to WinAnsiEncoding, with '?' for characters the standard fonts can't show
+func winAnsiByte(r rune) byte {
+	switch {
+	case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+		return byte(r)
+	case r == '\t' || r == '\n':
+		return ' '
+	}
+	if b, ok := winAnsiSpecials[r]; ok {
+		return b
+	}
+	return '?'
+}
+
+// pdfEscape encodes text as the body of a PDF literal string
+func pdfEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		c := winAnsiByte(r)
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c < 0x80 {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "\\%03o", c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Glyph widths for ASCII 32-126 in thousandths of an em, from the Adobe Helvetica AFMs
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfTextWidth measures text in points. Characters outside ASCII are measured as a typical lowercase letter.
+func pdfTextWidth(text string, size float64, bold bool) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfWrapText breaks text into lines no wider than maxWidth, keeping explicit line breaks
+func pdfWrapText(text string, size float64, bold bool, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := words[0]
+		for _, word := range words[1:] {
+			if pdfTextWidth(line+" "+word, size, bold) <= maxWidth {
+				line += " " + word
+				continue
+			}
+			lines = append(lines, line)
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// Pitch deck layout: 60pt side margins on a 960x540pt slide
+const (
+	deckMargin  = 60.0
+	deckContent = pptxSlideWidth - 2*deckMargin
+	deckFont    = "Arial" // available in PowerPoint, Keynote and Google Slides when the brand fonts aren't
+
+	deckPersonasPerSlide = 3
+)
+
+// pitchDeckWriter builds the account-manager deck from a brief's results
+type pitchDeckWriter struct {
+	deck    *pptxDeck
+	colors  brandBookColors
+	company string
+	heading string
+	body    string
+}
+
+// renderPitchDeck lays out the brief's results as a 16:9 PowerPoint deck
+func renderPitchDeck(brief *bezzmodels.BrandBrief, images exportImages) ([]byte, error) {
+	if brief.Results == nil {
+		return nil, fmt.Errorf("brief has no results to export")
+	}
+	results := brief.Results
+
+	heading, body := deckFont, deckFont
+	if identity := results.BrandIdentity; identity != nil && identity.Typography != nil {
+		if family := identity.Typography.Heading.Family; family != "" {
+			heading = family
+		}
+		if family := identity.Typography.Body.Family; family != "" {
+			body = family
+		}
+	}
+
+	colors := brandBookPalette(results.BrandIdentity)
+	w := &pitchDeckWriter{
+		deck: newPPTXDeck(brief.CompanyName+" Brand Strategy", heading, body, pptxTheme{
+			dark: colors.text, light: colors.surface, accent1: colors.primary, accent2: colors.secondary, accent3: colors.accent,
+		}),
+		colors:  colors,
+		company: brief.CompanyName,
+		heading: heading,
+		body:    body,
+	}
+
+	logo := -1
+	if images.logo != nil {
+		index, err := w.deck.AddImage(images.logo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed logo: %w", err)
+		}
+		logo = index
+	}
+
+	w.titleSlide(results.Strategy.Tagline, logo, images)
+	w.positioningSlide(results.Strategy)
+	w.personaSlides(results.Strategy.TargetSegments)
+	w.messagingSlide(results.Strategy)
+	if results.BrandIdentity != nil {
+		w.identitySlide(results.BrandIdentity, logo, images)
+	}
+	for i, ad := range results.Ads {
+		media := -1
+		if i < len(images.ads) && images.ads[i] != nil {
+			index, err := w.deck.AddImage(images.ads[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to embed ad %s: %w", ad.ID, err)
+			}
+			media = index
+		}
+		w.adSlide(ad, i, len(results.Ads), media, images)
+	}
+
+	return w.deck.Bytes()
+}
+
+// fitText truncates text to the lines it can fill at width, using Helvetica metrics as an estimate
+func fitText(text string, size float64, bold bool, width float64, maxLines int) string {
+	return strings.Join(limitLines(pdfWrapText(text, size, bold, width), maxLines), " ")
+}
+
+func (w *pitchDeckWriter) para(text string, size float64, bold bool, col color.RGBA, heading bool) pptxParagraph {
+	font := w.body
+	if heading {
+		font = w.heading
+	}
+	return pptxParagraph{runs: []pptxRun{{text: text, size: size, bold: bold, color: col, font: font}}}
+}
+
+func (w *pitchDeckWriter) bullet(text string, size float64) pptxParagraph {
+	p := w.para(text, size, false, w.colors.text, false)
+	p.bullet = true
+	return p
+}
+
+// contentSlide starts a white slide with a kicker, title and footer
+func (w *pitchDeckWriter) contentSlide(kicker, title string) *pptxSlide {
+	slide := w.deck.AddSlide(paletteWhite)
+	slide.Rect(0, 0, pptxSlideWidth, 8, w.colors.primary, false)
+	slide.Text(deckMargin, 36, deckContent, 18, "t", w.para(strings.ToUpper(kicker), 11, true, w.colors.accentInk(), false))
+	slide.Text(deckMargin, 56, deckContent, 44, "t", w.para(fitText(title, 30, true, deckContent, 1), 30, true, w.colors.primary, true))
+	footer := fmt.Sprintf("%s  ·  %d", w.company, len(w.deck.slides))
+	slide.Text(deckMargin, pptxSlideHeight-34, deckContent, 16, "t", pptxParagraph{runs: []pptxRun{{text: footer, size: 9, color: w.colors.muted, font: w.body}}, align: "r"})
+	return slide
+}
+
+// accentInk is the accent color, darkened when it would be unreadable as text on white
+func (c brandBookColors) accentInk() color.RGBA {
+	if contrastRatio(c.accent, paletteWhite) >= 3 {
+		return c.accent
+	}
+	if contrastRatio(c.primary, paletteWhite) >= 3 {
+		return c.primary
+	}
+	return c.muted
+}
+
+func (w *pitchDeckWriter) titleSlide(tagline string, logo int, images exportImages) {
+	slide := w.deck.AddSlide(w.colors.primary)
+	ink := readableTextColor(w.colors.primary)
+	slide.Rect(0, pptxSlideHeight-14, pptxSlideWidth, 14, w.colors.accent, false)
+
+	textWidth := deckContent
+	if logo >= 0 {
+		textWidth = 480
+		card := 300.0
+		slide.Rect(pptxSlideWidth-deckMargin-card, (pptxSlideHeight-card)/2, card, card, paletteWhite, true)
+		slide.Picture(logo, images.logo, pptxSlideWidth-deckMargin-card+30, (pptxSlideHeight-card)/2+30, card-60, card-60, w.company+" logo")
+	}
+
+	slide.Text(deckMargin, 150, textWidth, 130, "b", w.para(fitText(w.company, 48, true, textWidth, 2), 48, true, ink, true))
+	paragraphs := []pptxParagraph{}
+	if tagline != "" {
+		paragraphs = append(paragraphs, w.para(fitText(tagline, 22, false, textWidth, 3), 22, false, ink, false))
+	}
+	paragraphs = append(paragraphs, w.para("Brand strategy & creative  ·  "+time.Now().Format("January 2, 2006"), 13, false, ink, false))
+	slide.Text(deckMargin, 295, textWidth, 160, "t", paragraphs...)
+}
+
+func (w *pitchDeckWriter) positioningSlide(strategy bezzmodels.BrandStrategy) {
+	slide := w.contentSlide("Strategy", "Positioning & value proposition")
+
+	left := 470.0
+	slide.Text(deckMargin, 130, left, 260, "t", w.para(fitText(strategy.Positioning, 22, false, left, 8), 22, false, w.colors.text, false))
+
+	cardX := deckMargin + left + 30
+	cardW := deckContent - left - 30
+	slide.Rect(cardX, 130, cardW, 260, w.colors.surface, true)
+	slide.Rect(cardX, 130, 6, 260, w.colors.accent, false)
+	slide.Text(cardX+26, 150, cardW-46, 230, "t",
+		w.para("VALUE PROPOSITION", 11, true, w.colors.accentInk(), false),
+		w.para(fitText(strategy.ValueProposition, 17, false, cardW-46, 9), 17, false, w.colors.text, false))
+
+	if strategy.Tagline != "" {
+		slide.Text(deckMargin, 415, deckContent, 50, "t", w.para(fitText("“"+strategy.Tagline+"”", 24, true, deckContent, 1), 24, true, w.colors.primary, true))
+	}
+}
+
+// personaSlides writes up to three persona cards per slide
+func (w *pitchDeckWriter) personaSlides(segments []bezzmodels.TargetSegment) {
+	for start := 0; start < len(segments); start += deckPersonasPerSlide {
+		end := start + deckPersonasPerSlide
+		if end > len(segments) {
+			end = len(segments)
+		}
+		slide := w.contentSlide("Audience", "Personas")
+
+		gap := 20.0
+		cardW := (deckContent - gap*float64(deckPersonasPerSlide-1)) / float64(deckPersonasPerSlide)
+		for i, segment := range segments[start:end] {
+			x := deckMargin + float64(i)*(cardW+gap)
+			slide.Rect(x, 125, cardW, 360, w.colors.surface, true)
+			slide.Rect(x, 125, cardW, 6, w.colors.accent, false)
+
+			inner := cardW - 40
+			paragraphs := []pptxParagraph{
+				w.para(fitText(segment.Name, 20, true, inner, 1), 20, true, w.colors.primary, true),
+				w.para(fitText(segment.Role, 12, false, inner, 1), 12, false, w.colors.muted, false),
+				w.para(fitText(segment.Demographics, 11, false, inner, 3), 11, false, w.colors.text, false),
+			}
+			if len(segment.PainPoints) > 0 {
+				paragraphs = append(paragraphs, w.para("PAIN POINTS", 9, true, w.colors.accentInk(), false))
+				for _, point := range limitItems(segment.PainPoints, 3) {
+					paragraphs = append(paragraphs, w.bullet(fitText(point, 11, false, inner-18, 2), 11))
+				}
+			}
+			if len(segment.PreferredChannels) > 0 {
+				paragraphs = append(paragraphs,
+					w.para("CHANNELS", 9, true, w.colors.accentInk(), false),
+					w.para(fitText(strings.Join(segment.PreferredChannels, ", "), 11, false, inner, 2), 11, false, w.colors.text, false))
+			}
+			slide.Text(x+20, 145, inner, 325, "t", paragraphs...)
+		}
+	}
+}
+
+func (w *pitchDeckWriter) messagingSlide(strategy bezzmodels.BrandStrategy) {
+	framework := strategy.MessagingFramework
+	if framework.PrimaryMessage == "" && len(framework.SupportingMessages) == 0 && len(strategy.BrandPillars) == 0 {
+		return
+	}
+	slide := w.contentSlide("Messaging", "Messaging framework")
+
+	band := w.colors.primary
+	slide.Rect(deckMargin, 120, deckContent, 80, band, true)
+	slide.Text(deckMargin+24, 120, deckContent-48, 80, "ctr", w.para(fitText(framework.PrimaryMessage, 20, true, deckContent-48, 2), 20, true, readableTextColor(band), true))
+
+	left := 480.0
+	supporting := []pptxParagraph{w.para("SUPPORTING MESSAGES", 10, true, w.colors.accentInk(), false)}
+	for _, message := range limitItems(framework.SupportingMessages, 5) {
+		supporting = append(supporting, w.bullet(fitText(message, 14, false, left-18, 2), 14))
+	}
+	slide.Text(deckMargin, 225, left, 250, "t", supporting...)
+
+	if len(strategy.BrandPillars) > 0 {
+		x := deckMargin + left + 30
+		width := deckContent - left - 30
+		slide.Text(x, 225, width, 18, "t", w.para("BRAND PILLARS", 10, true, w.colors.accentInk(), false))
+		y := 250.0
+		for _, pillar := range limitItems(strategy.BrandPillars, 5) {
+			slide.Rect(x, y, width, 36, w.colors.surface, true)
+			slide.Text(x+16, y, width-32, 36, "ctr", w.para(fitText(pillar, 14, true, width-32, 1), 14, true, w.colors.primary, false))
+			y += 44
+		}
+	}
+}
+
+func (w *pitchDeckWriter) identitySlide(identity *bezzmodels.BrandIdentity, logo int, images exportImages) {
+	slide := w.contentSlide("Identity", "Logo & color palette")
+
+	x := deckMargin
+	if logo >= 0 {
+		box := 300.0
+		slide.Rect(deckMargin, 125, box, box, w.colors.surface, true)
+		slide.Picture(logo, images.logo, deckMargin+30, 155, box-60, box-60, w.company+" logo")
+		slide.Text(deckMargin, 435, box, 50, "t", w.para(fitText(identity.LogoConcept, 10, false, box, 3), 10, false, w.colors.muted, false))
+		x += box + 30
+	}
+
+	analysis := paletteAnalysisFor(identity)
+	if analysis == nil || len(analysis.Colors) == 0 {
+		return
+	}
+	specs := limitItems(analysis.Colors, 6)
+	cols := 3
+	if len(specs) <= 2 {
+		cols = len(specs)
+	}
+	gap := 16.0
+	width := pptxSlideWidth - deckMargin - x
+	swatchW := (width - gap*float64(cols-1)) / float64(cols)
+	for i, spec := range specs {
+		rgb, err := parseHexColor(spec.Hex)
+		if err != nil {
+			continue
+		}
+		sx := x + float64(i%cols)*(swatchW+gap)
+		sy := 125 + float64(i/cols)*185
+		slide.Rect(sx, sy, swatchW, 100, rgb, true)
+		if contrastRatio(rgb, paletteWhite) < 1.2 {
+			slide.Rect(sx, sy+100, swatchW, 1, w.colors.muted, false)
+		}
+		slide.Text(sx, sy+108, swatchW, 70, "t",
+			w.para(fitText(spec.Name, 13, true, swatchW, 1), 13, true, w.colors.text, false),
+			w.para(spec.Hex+"  ·  "+titleCase(spec.Usage), 10, false, w.colors.muted, false),
+			w.para(fmt.Sprintf("RGB %d, %d, %d", spec.RGB.R, spec.RGB.G, spec.RGB.B), 10, false, w.colors.muted, false))
+	}
+}
+
+func (w *pitchDeckWriter) adSlide(ad bezzmodels.AdCampaign, index, total, media int, images exportImages) {
+	title := ad.Title
+	if title == "" {
+		title = ad.Copy.Headline
+	}
+	slide := w.contentSlide(fmt.Sprintf("Ad %d of %d", index+1, total), title)
+
+	box := 360.0
+	slide.Rect(deckMargin, 120, box, box, w.colors.surface, false)
+	if media >= 0 {
+		slide.Picture(media, images.ads[index], deckMargin, 120, box, box, ad.Copy.Headline)
+	} else {
+		slide.Text(deckMargin, 120, box, box, "ctr", pptxParagraph{runs: []pptxRun{{text: "Image unavailable", size: 12, color: w.colors.muted, font: w.body}}, align: "ctr"})
+	}
+
+	x := deckMargin + box + 40
+	width := pptxSlideWidth - deckMargin - x
+	meta := strings.Trim(strings.Join([]string{ad.Platform, ad.Placement, ad.AspectRatio}, "  ·  "), " ·")
+	paragraphs := []pptxParagraph{
+		w.para(strings.ToUpper(meta), 10, true, w.colors.accentInk(), false),
+		w.para(fitText(ad.Copy.Headline, 26, true, width, 3), 26, true, w.colors.text, true),
+		w.para(fitText(ad.Copy.Body, 15, false, width, 6), 15, false, w.colors.text, false),
+	}
+	if ad.Copy.Description != "" {
+		paragraphs = append(paragraphs, w.para(fitText(ad.Copy.Description, 12, false, width, 2), 12, false, w.colors.muted, false))
+	}
+	slide.Text(x, 120, width, 290, "t", paragraphs...)
+
+	if ad.Copy.CTA != "" {
+		buttonW := minFloat(pdfTextWidth(ad.Copy.CTA, 14, true)+48, width)
+		slide.Rect(x, 430, buttonW, 44, w.colors.accent, true)
+		slide.Text(x, 430, buttonW, 44, "ctr", pptxParagraph{runs: []pptxRun{{text: ad.Copy.CTA, size: 14, bold: true, color: readableTextColor(w.colors.accent), font: w.body}}, align: "ctr"})
+	}
+}
+
+// limitItems keeps the first n items
+func limitItems[T any](items []T, n int) []T {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
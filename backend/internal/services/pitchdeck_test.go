@@ -0,0 +1,134 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+// readPackage unzips a deck into part name -> content
+func readPackage(t *testing.T, data []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	parts := map[string][]byte{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		parts[f.Name] = content
+	}
+	require.Equal(t, "[Content_Types].xml", zr.File[0].Name)
+	return parts
+}
+
+func TestRenderPitchDeck_WritesConsistentPackage(t *testing.T) {
+	logo := solidImage(200, 100, color.NRGBA{R: 0x3B, G: 0x24, B: 0x16, A: 0x80})
+	ad := solidImage(300, 300, color.NRGBA{R: 0xF2, G: 0x8C, B: 0x28, A: 0xff})
+
+	brief := sampleBrandBookBrief()
+	brief.Results.Strategy.MessagingFramework = models.MessagingFramework{PrimaryMessage: "Great coffee <without> the hassle & fuss"}
+	data, err := renderPitchDeck(brief, exportImages{logo: logo, ads: []image.Image{ad, nil}})
+	require.NoError(t, err)
+	parts := readPackage(t, data)
+
+	// Every XML part is well formed
+	for name, content := range parts {
+		if strings.HasSuffix(name, ".xml") || strings.HasSuffix(name, ".rels") {
+			decoder := xml.NewDecoder(bytes.NewReader(content))
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err, name)
+			}
+		}
+	}
+
+	// Every relationship resolves to a part, and every part has a content type
+	var types struct {
+		Defaults []struct {
+			Extension string `xml:"Extension,attr"`
+		} `xml:"Default"`
+		Overrides []struct {
+			PartName string `xml:"PartName,attr"`
+		} `xml:"Override"`
+	}
+	require.NoError(t, xml.Unmarshal(parts["[Content_Types].xml"], &types))
+	typed := map[string]bool{}
+	for _, d := range types.Defaults {
+		typed["ext:"+d.Extension] = true
+	}
+	for _, o := range types.Overrides {
+		typed[o.PartName] = true
+		assert.Contains(t, parts, strings.TrimPrefix(o.PartName, "/"), "override for a missing part")
+	}
+	for name, content := range parts {
+		ext := strings.TrimPrefix(path.Ext(name), ".")
+		assert.True(t, typed["/"+name] || typed["ext:"+ext], "no content type for %s", name)
+
+		if !strings.HasSuffix(name, ".rels") {
+			continue
+		}
+		var rels struct {
+			Relationships []struct {
+				Target string `xml:"Target,attr"`
+			} `xml:"Relationship"`
+		}
+		require.NoError(t, xml.Unmarshal(content, &rels))
+		base := path.Dir(path.Dir(name)) // rels in a/_rels/b.xml.rels are relative to a/
+		for _, rel := range rels.Relationships {
+			target := path.Join(base, rel.Target)
+			assert.Contains(t, parts, target, "%s points at a missing part", name)
+		}
+	}
+
+	// Title, positioning, personas, messaging, identity, then one slide per ad
+	slides := 0
+	for name := range parts {
+		if strings.HasPrefix(name, "ppt/slides/slide") {
+			slides++
+		}
+	}
+	assert.Equal(t, 7, slides)
+	assert.Contains(t, parts, "ppt/media/image1.png", "the translucent logo keeps its alpha")
+	assert.Contains(t, parts, "ppt/media/image2.jpeg")
+	assert.Contains(t, string(parts["ppt/presentation.xml"]), `<p:sldSz cx="12192000" cy="6858000"/>`)
+
+	text := string(parts["ppt/slides/slide1.xml"]) + string(parts["ppt/slides/slide4.xml"]) + string(parts["ppt/slides/slide6.xml"])
+	for _, want := range []string{"Acme Coffee", "Mornings, sorted", "Great coffee &lt;without&gt; the hassle &amp; fuss", "Brew better", "Shop Now", `typeface="Playfair Display"`, `val="3B2416"`} {
+		assert.Contains(t, text, want)
+	}
+	assert.Contains(t, string(parts["ppt/slides/slide7.xml"]), "Image unavailable")
+	assert.Contains(t, string(parts["ppt/theme/theme1.xml"]), `<a:accent1><a:srgbClr val="3B2416"/></a:accent1>`)
+}
+
+func TestRenderPitchDeck_SplitsPersonasAcrossSlides(t *testing.T) {
+	brief := sampleBrandBookBrief()
+	brief.Results.BrandIdentity = nil
+	brief.Results.Ads = nil
+	for i := 0; i < 4; i++ {
+		brief.Results.Strategy.TargetSegments = append(brief.Results.Strategy.TargetSegments, models.TargetSegment{Name: "Persona"})
+	}
+	data, err := renderPitchDeck(brief, exportImages{})
+	require.NoError(t, err)
+	parts := readPackage(t, data)
+
+	// Title, positioning, two persona slides, messaging (pillars only)
+	assert.Contains(t, parts, "ppt/slides/slide5.xml")
+	assert.NotContains(t, parts, "ppt/slides/slide6.xml")
+	assert.NotContains(t, string(parts["ppt/slides/slide1.xml"]), "<p:pic>")
+	assert.Contains(t, string(parts["ppt/theme/theme1.xml"]), `<a:latin typeface="Arial"/>`)
+}
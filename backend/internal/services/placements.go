@@ -0,0 +1,251 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// imageSize pairs a gpt-image-1 size with the closest DALL-E 3 size for the same aspect ratio
+type imageSize struct {
+	gptImage1 string
+	dalle3    string
+}
+
+var (
+	imageSizeSquare    = imageSize{gptImage1: "1024x1024", dalle3: "1024x1024"}
+	imageSizeLandscape = imageSize{gptImage1: "1536x1024", dalle3: "1792x1024"}
+	imageSizePortrait  = imageSize{gptImage1: "1024x1536", dalle3: "1024x1792"}
+)
+
+// AdPlacement describes where an ad will run and the constraints its copy and image must respect
+type AdPlacement struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Platform    string `json:"platform"`    // instagram, linkedin, google, x, print
+	Format      string `json:"format"`      // social, display, print
+	AspectRatio string `json:"aspectRatio"` // e.g. 1:1, 9:16, 1.91:1; generated images are cropped to it
+
+	imageSize     imageSize
+	layout        string         // default creative layout template
+	creativeSizes []CreativeSize // finished creative sizes rendered by the compositor
+	cleanSizes    []CreativeSize // text-free crops for networks that lay out their own copy
+}
+
+// Placement registry - copy limits and allowed CTAs live in the versioned adCopyRules table
+var adPlacements = map[string]AdPlacement{
+	"instagram_feed": {
+		ID: "instagram_feed", Name: "Instagram Feed", Platform: "instagram", Format: "social",
+		AspectRatio:   "1:1",
+		imageSize:     imageSizeSquare,
+		layout:        "bottom_band",
+		creativeSizes: []CreativeSize{{Width: 1080, Height: 1080}, {Width: 1080, Height: 1350}},
+	},
+	"instagram_story": {
+		ID: "instagram_story", Name: "Instagram Story", Platform: "instagram", Format: "social",
+		AspectRatio:   "9:16",
+		imageSize:     imageSizePortrait,
+		layout:        "top_headline",
+		creativeSizes: []CreativeSize{{Width: 1080, Height: 1920}},
+	},
+	"linkedin_sponsored": {
+		ID: "linkedin_sponsored", Name: "LinkedIn Sponsored Content", Platform: "linkedin", Format: "social",
+		AspectRatio:   "1.91:1",
+		imageSize:     imageSizeLandscape,
+		layout:        "side_panel",
+		creativeSizes: []CreativeSize{{Width: 1200, Height: 627}, {Width: 1080, Height: 1080}},
+	},
+	"google_display": {
+		ID: "google_display", Name: "Google Display Banner", Platform: "google", Format: "display",
+		AspectRatio:   "1.91:1",
+		imageSize:     imageSizeLandscape,
+		layout:        "side_panel",
+		creativeSizes: []CreativeSize{{Width: 1200, Height: 628}, {Width: 300, Height: 250}, {Width: 336, Height: 280}},
+		cleanSizes:    []CreativeSize{{Width: 1200, Height: 628}, {Width: 1200, Height: 1200}},
+	},
+	"x_post": {
+		ID: "x_post", Name: "X (Twitter) Promoted Post", Platform: "x", Format: "social",
+		AspectRatio:   "16:9",
+		imageSize:     imageSizeLandscape,
+		layout:        "bottom_band",
+		creativeSizes: []CreativeSize{{Width: 1600, Height: 900}},
+	},
+	"print": {
+		ID: "print", Name: "Print Ad", Platform: "print", Format: "print",
+		AspectRatio:   "2:3",
+		imageSize:     imageSizePortrait,
+		layout:        "top_headline",
+		creativeSizes: []CreativeSize{{Width: 1240, Height: 1754}},
+	},
+}
+
+// defaultPlacementIDs are used when a brief does not request any placements
+var defaultPlacementIDs = []string{"instagram_feed", "linkedin_sponsored", "google_display"}
+
+// ValidatePlacements checks that every requested placement ID is known
+func ValidatePlacements(ids []string) error {
+	for _, id := range ids {
+		if _, ok := adPlacements[id]; !ok {
+			return fmt.Errorf("unsupported placement: %s", id)
+		}
+	}
+	return nil
+}
+
+// resolvePlacements returns the placements for the given IDs, de-duplicated and in request order
+func resolvePlacements(ids []string) []AdPlacement {
+	if len(ids) == 0 {
+		ids = defaultPlacementIDs
+	}
+
+	seen := make(map[string]bool, len(ids))
+	placements := make([]AdPlacement, 0, len(ids))
+	for _, id := range ids {
+		placement, ok := adPlacements[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		placements = append(placements, placement)
+	}
+
+	if len(placements) == 0 {
+		return resolvePlacements(defaultPlacementIDs)
+	}
+	return placements
+}
+
+// placementForSpec looks up the placement an ad spec was written for, falling back to the first default
+func placementForSpec(spec bezzmodels.AdSpec) AdPlacement {
+	if placement, ok := adPlacements[spec.Placement]; ok {
+		return placement
+	}
+	return adPlacements[defaultPlacementIDs[0]]
+}
+
+// matchCTA returns the allowed CTA that matches cta, or the first allowed CTA when none does
+func matchCTA(cta string, allowed []string) string {
+	for _, option := range allowed {
+		if strings.EqualFold(strings.TrimSpace(cta), option) {
+			return option
+		}
+	}
+	return allowed[0]
+}
+
+// matchTargetSegment returns the strategy segment named by name, rotating through segments when it is unknown
+func matchTargetSegment(name string, segments []bezzmodels.TargetSegment, index int) string {
+	if len(segments) == 0 {
+		return name
+	}
+	for _, segment := range segments {
+		if strings.EqualFold(strings.TrimSpace(name), segment.Name) {
+			return segment.Name
+		}
+	}
+	return segments[index%len(segments)].Name
+}
+
+// normalizeAdSpecs ties each spec to a requested placement, an allowed CTA and a real target segment. Specs
+// for unknown or repeated placements are moved to requested placements nobody wrote for, so the model skipping
+// or doubling up a placement doesn't silently drop one the user asked for. It returns the placements still
+// without an ad
+func normalizeAdSpecs(specs []bezzmodels.AdSpec, placements []AdPlacement, segments []bezzmodels.TargetSegment) []AdPlacement {
+	requested := make(map[string]bool, len(placements))
+	for _, placement := range placements {
+		requested[placement.ID] = true
+	}
+
+	covered := make(map[string]bool, len(placements))
+	var spare []int
+	for i := range specs {
+		if requested[specs[i].Placement] && !covered[specs[i].Placement] {
+			covered[specs[i].Placement] = true
+			continue
+		}
+		spare = append(spare, i)
+	}
+
+	var missing []AdPlacement
+	for _, placement := range placements {
+		if covered[placement.ID] {
+			continue
+		}
+		if len(spare) > 0 {
+			specs[spare[0]].Placement = placement.ID
+			spare = spare[1:]
+			continue
+		}
+		missing = append(missing, placement)
+	}
+
+	// Ads beyond one per placement stay, but only on requested placements
+	for n, i := range spare {
+		if !requested[specs[i].Placement] {
+			specs[i].Placement = placements[n%len(placements)].ID
+		}
+	}
+
+	for i := range specs {
+		specs[i].CTA = matchCTA(specs[i].CTA, copyRulesFor(specs[i].Placement).CTAs)
+		specs[i].TargetSegment = matchTargetSegment(specs[i].TargetSegment, segments, i)
+	}
+	return missing
+}
+
+// fillMissingPlacements gives each placement still without an ad a copy of an existing spec, the last resort
+// when Creative-Director-GPT won't write for it; copy that breaks the placement's rules is shortened later
+func fillMissingPlacements(specs []bezzmodels.AdSpec, missing []AdPlacement) []bezzmodels.AdSpec {
+	if len(specs) == 0 {
+		return specs
+	}
+	for n, placement := range missing {
+		spec := specs[n%len(specs)]
+		spec.ID = len(specs) + 1
+		spec.Placement = placement.ID
+		spec.CTA = matchCTA(spec.CTA, copyRulesFor(placement.ID).CTAs)
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// placementPromptContext lists the requested placements, their limits and visual styles for Creative-Director-GPT
+func placementPromptContext(placements []AdPlacement, styles StyleSelection) string {
+	var b strings.Builder
+	for _, p := range placements {
+		rules := copyRulesFor(p.ID)
+		fmt.Fprintf(&b, "- placement: %q (%s, aspect ratio %s) | %s | cta must be one of: %s\n",
+			p.ID, p.Name, p.AspectRatio, rules.promptSummary(), strings.Join(rules.CTAs, ", "))
+		fmt.Fprintf(&b, "  visual style: %s\n", styles.presetFor(p.ID).promptGuidance())
+	}
+	return b.String()
+}
+
+// newAdCampaign builds an AdCampaign from a normalized spec and its placement
+func newAdCampaign(spec bezzmodels.AdSpec, placement AdPlacement) *bezzmodels.AdCampaign {
+	rules := copyRulesFor(placement.ID)
+	adCopy := bezzmodels.AdCopy{
+		Headline:    spec.Headline,
+		Body:        spec.Body,
+		Description: spec.Description,
+		CTA:         matchCTA(spec.CTA, rules.CTAs),
+	}
+
+	return &bezzmodels.AdCampaign{
+		ID:               fmt.Sprintf("ad_%d_%d", spec.ID, time.Now().Unix()),
+		Title:            fmt.Sprintf("%s Ad %d", placement.Name, spec.ID),
+		Format:           placement.Format,
+		Platform:         placement.Platform,
+		Placement:        placement.ID,
+		AspectRatio:      placement.AspectRatio,
+		TargetSegment:    spec.TargetSegment,
+		Copy:             adCopy,
+		ImagePrompt:      spec.DallePrompt,
+		Style:            stylePresetFor(spec.Style).ID,
+		Objectives:       []string{"Brand Awareness", "Engagement"},
+		CopyRulesVersion: rules.Version,
+		CopyViolations:   ValidateAdCopy(rules.Version, placement.ID, adCopy),
+	}
+}
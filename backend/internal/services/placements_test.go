@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestValidatePlacements(t *testing.T) {
+	assert.NoError(t, ValidatePlacements(nil))
+	assert.NoError(t, ValidatePlacements([]string{"instagram_story", "print"}))
+	assert.Error(t, ValidatePlacements([]string{"instagram_feed", "tiktok"}))
+}
+
+func TestResolvePlacements_DefaultsAndDeduplicates(t *testing.T) {
+	defaults := resolvePlacements(nil)
+	assert.Len(t, defaults, len(defaultPlacementIDs))
+	assert.Equal(t, "instagram_feed", defaults[0].ID)
+
+	placements := resolvePlacements([]string{"x_post", "x_post", "unknown", "print"})
+	assert.Len(t, placements, 2)
+	assert.Equal(t, "x_post", placements[0].ID)
+	assert.Equal(t, "print", placements[1].ID)
+}
+
+func TestNormalizeAdSpecs(t *testing.T) {
+	placements := resolvePlacements([]string{"instagram_story", "linkedin_sponsored"})
+	segments := []models.TargetSegment{{Name: "Busy Parents"}, {Name: "Young Professionals"}}
+
+	specs := []models.AdSpec{
+		{ID: 1, Placement: "linkedin_sponsored", CTA: "request demo", TargetSegment: "young professionals"},
+		{ID: 2, Placement: "facebook_feed", CTA: "Click here!", TargetSegment: "Everyone"},
+	}
+
+	missing := normalizeAdSpecs(specs, placements, segments)
+	assert.Empty(t, missing)
+
+	assert.Equal(t, "linkedin_sponsored", specs[0].Placement)
+	assert.Equal(t, "Request Demo", specs[0].CTA)
+	assert.Equal(t, "Young Professionals", specs[0].TargetSegment)
+
+	// Unknown placement, CTA and segment fall back to real values; the placement is the one left uncovered
+	assert.Equal(t, "instagram_story", specs[1].Placement)
+	assert.Equal(t, "Learn More", specs[1].CTA)
+	assert.Equal(t, "Young Professionals", specs[1].TargetSegment)
+}
+
+func TestNormalizeAdSpecs_CoversEveryRequestedPlacement(t *testing.T) {
+	placements := resolvePlacements([]string{"instagram_feed", "instagram_story", "linkedin_sponsored"})
+
+	// A repeated placement moves to one nobody wrote for
+	specs := []models.AdSpec{
+		{ID: 1, Placement: "instagram_feed"},
+		{ID: 2, Placement: "instagram_feed"},
+		{ID: 3, Placement: "linkedin_sponsored"},
+	}
+	assert.Empty(t, normalizeAdSpecs(specs, placements, nil))
+	assert.Equal(t, []string{"instagram_feed", "instagram_story", "linkedin_sponsored"},
+		[]string{specs[0].Placement, specs[1].Placement, specs[2].Placement})
+
+	// Too few ads: the uncovered placements are reported, then filled from existing copy
+	specs = []models.AdSpec{{ID: 1, Placement: "linkedin_sponsored", Headline: "Fuel your team", CTA: "Request Demo"}}
+	missing := normalizeAdSpecs(specs, placements, nil)
+	require.Len(t, missing, 2)
+	assert.Equal(t, "instagram_feed", missing[0].ID)
+
+	specs = fillMissingPlacements(specs, missing)
+	require.Len(t, specs, 3)
+	assert.Equal(t, "instagram_story", specs[2].Placement)
+	assert.Equal(t, "Fuel your team", specs[2].Headline)
+	assert.Equal(t, "Learn More", specs[2].CTA, "the copied CTA is swapped for one the placement allows")
+	assert.Equal(t, 3, specs[2].ID)
+}
+
+func TestNewAdCampaign_UsesPlacement(t *testing.T) {
+	spec := models.AdSpec{ID: 3, Placement: "google_display", Headline: "Hi", Body: "There", CTA: "shop now", TargetSegment: "Busy Parents"}
+
+	campaign := newAdCampaign(spec, placementForSpec(spec))
+
+	assert.Equal(t, "google", campaign.Platform)
+	assert.Equal(t, "display", campaign.Format)
+	assert.Equal(t, "1.91:1", campaign.AspectRatio)
+	assert.Equal(t, "Shop now", campaign.Copy.CTA)
+	assert.Equal(t, "Busy Parents", campaign.TargetSegment)
+}
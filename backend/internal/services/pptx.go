@@ -0,0 +1,366 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Minimal PresentationML writer for the pitch deck: one blank layout and a theme carrying the brand colors and
+// fonts, with slides built from filled shapes, text boxes and pictures. Positions are in points on a 16:9 slide.
+
+// 16:9 slide size in points
+const (
+	pptxSlideWidth  = 960.0
+	pptxSlideHeight = 540.0
+)
+
+const (
+	pptxNamespaces = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`
+	pptxRelsNS     = "http://schemas.openxmlformats.org/package/2006/relationships"
+	pptxRelBase    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/"
+	pptxGroupShape = `<p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr><p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr>`
+)
+
+// pptxDeck collects slides and media and serialises them as a .pptx package
+type pptxDeck struct {
+	title       string
+	theme       pptxTheme
+	slides      []*pptxSlide
+	media       []pptxMedia
+	headingFont string
+	bodyFont    string
+}
+
+// pptxTheme is the color scheme written into the deck's theme, so PowerPoint's pickers offer the brand colors
+type pptxTheme struct {
+	dark, light, accent1, accent2, accent3 color.RGBA
+}
+
+// pptxMedia is an encoded picture in ppt/media
+type pptxMedia struct {
+	ext  string // png or jpeg
+	data []byte
+}
+
+// pptxSlide is one slide's shape tree
+type pptxSlide struct {
+	background color.RGBA
+	shapes     bytes.Buffer
+	media      []int // deck media indexes, related as rId2 onwards
+	nextID     int
+}
+
+// pptxRun is a span of text in one style
+type pptxRun struct {
+	text  string
+	size  float64 // points
+	bold  bool
+	color color.RGBA
+	font  string // empty for the body font
+}
+
+// pptxParagraph is a line of runs with its alignment
+type pptxParagraph struct {
+	runs   []pptxRun
+	align  string // l, ctr, r
+	bullet bool
+}
+
+func newPPTXDeck(title, headingFont, bodyFont string, theme pptxTheme) *pptxDeck {
+	return &pptxDeck{title: title, theme: theme, headingFont: headingFont, bodyFont: bodyFont}
+}
+
+// AddSlide starts a slide with a solid background
+func (d *pptxDeck) AddSlide(background color.RGBA) *pptxSlide {
+	slide := &pptxSlide{background: background, nextID: 2}
+	d.slides = append(d.slides, slide)
+	return slide
+}
+
+// AddImage encodes an image for use on any slide: PNG when it has transparency, JPEG otherwise
+func (d *pptxDeck) AddImage(img image.Image) (int, error) {
+	b := img.Bounds()
+	rgba := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+
+	media := pptxMedia{ext: "jpeg"}
+	if rgba.Opaque() {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, rgba, &jpeg.Options{Quality: 88}); err != nil {
+			return 0, fmt.Errorf("failed to encode image: %w", err)
+		}
+		media.data = buf.Bytes()
+	} else {
+		data, err := encodePNG(rgba)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode image: %w", err)
+		}
+		media.ext, media.data = "png", data
+	}
+
+	d.media = append(d.media, media)
+	return len(d.media) - 1, nil
+}
+
+// emu converts points to English Metric Units
+func emu(pt float64) int64 {
+	return int64(pt * 12700)
+}
+
+func pptxColor(c color.RGBA) string {
+	return fmt.Sprintf("%02X%02X%02X", c.R, c.G, c.B)
+}
+
+func pptxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func pptxXfrm(x, y, w, h float64) string {
+	return fmt.Sprintf(`<a:xfrm><a:off x="%d" y="%d"/><a:ext cx="%d" cy="%d"/></a:xfrm>`, emu(x), emu(y), emu(w), emu(h))
+}
+
+func (s *pptxSlide) id() int {
+	s.nextID++
+	return s.nextID - 1
+}
+
+// Rect adds a filled rectangle, or a rounded one when rounded is set
+func (s *pptxSlide) Rect(x, y, w, h float64, fill color.RGBA, rounded bool) {
+	geometry := "rect"
+	if rounded {
+		geometry = "roundRect"
+	}
+	id := s.id()
+	fmt.Fprintf(&s.shapes, `<p:sp><p:nvSpPr><p:cNvPr id="%d" name="Shape %d"/><p:cNvSpPr/><p:nvPr/></p:nvSpPr><p:spPr>%s<a:prstGeom prst="%s"><a:avLst/></a:prstGeom><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:ln><a:noFill/></a:ln></p:spPr></p:sp>`,
+		id, id, pptxXfrm(x, y, w, h), geometry, pptxColor(fill))
+}
+
+// Text adds a text box; anchor is t, ctr or b
+func (s *pptxSlide) Text(x, y, w, h float64, anchor string, paragraphs ...pptxParagraph) {
+	id := s.id()
+	fmt.Fprintf(&s.shapes, `<p:sp><p:nvSpPr><p:cNvPr id="%d" name="Text %d"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr><p:spPr>%s<a:prstGeom prst="rect"><a:avLst/></a:prstGeom><a:noFill/></p:spPr><p:txBody><a:bodyPr wrap="square" lIns="0" tIns="0" rIns="0" bIns="0" anchor="%s"><a:normAutofit/></a:bodyPr><a:lstStyle/>`,
+		id, id, pptxXfrm(x, y, w, h), anchor)
+	for _, p := range paragraphs {
+		align := p.align
+		if align == "" {
+			align = "l"
+		}
+		if p.bullet {
+			fmt.Fprintf(&s.shapes, `<a:p><a:pPr marL="228600" indent="-228600" algn="%s"><a:spcBef><a:spcPts val="600"/></a:spcBef><a:buFont typeface="Arial"/><a:buChar char="•"/></a:pPr>`, align)
+		} else {
+			fmt.Fprintf(&s.shapes, `<a:p><a:pPr algn="%s"><a:spcBef><a:spcPts val="400"/></a:spcBef><a:buNone/></a:pPr>`, align)
+		}
+		for _, run := range p.runs {
+			bold := 0
+			if run.bold {
+				bold = 1
+			}
+			typeface := "+mn-lt"
+			if run.font != "" {
+				typeface = run.font
+			}
+			fmt.Fprintf(&s.shapes, `<a:r><a:rPr lang="en-US" sz="%d" b="%d" dirty="0"><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:latin typeface="%s"/></a:rPr><a:t>%s</a:t></a:r>`,
+				int(run.size*100), bold, pptxColor(run.color), pptxEscape(typeface), pptxEscape(run.text))
+		}
+		s.shapes.WriteString(`</a:p>`)
+	}
+	s.shapes.WriteString(`</p:txBody></p:sp>`)
+}
+
+// Picture places deck media in the box, scaled to fit and centred
+func (s *pptxSlide) Picture(media int, img image.Image, x, y, w, h float64, description string) {
+	b := img.Bounds()
+	scale := minFloat(w/float64(b.Dx()), h/float64(b.Dy()))
+	pw, ph := float64(b.Dx())*scale, float64(b.Dy())*scale
+
+	s.media = append(s.media, media)
+	id := s.id()
+	fmt.Fprintf(&s.shapes, `<p:pic><p:nvPicPr><p:cNvPr id="%d" name="Picture %d" descr="%s"/><p:cNvPicPr><a:picLocks noChangeAspect="1"/></p:cNvPicPr><p:nvPr/></p:nvPicPr><p:blipFill><a:blip r:embed="rId%d"/><a:stretch><a:fillRect/></a:stretch></p:blipFill><p:spPr>%s<a:prstGeom prst="rect"><a:avLst/></a:prstGeom></p:spPr></p:pic>`,
+		id, id, pptxEscape(description), len(s.media)+1, pptxXfrm(x+(w-pw)/2, y+(h-ph)/2, pw, ph))
+}
+
+// Bytes writes the .pptx package
+func (d *pptxDeck) Bytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	write := func(name, content string) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + content))
+		return err
+	}
+
+	parts := map[string]string{
+		"[Content_Types].xml":                          d.contentTypes(),
+		"_rels/.rels":                                  pptxRels([]pptxRel{{"officeDocument", "ppt/presentation.xml"}, {"metadata/core-properties", "docProps/core.xml"}, {"extended-properties", "docProps/app.xml"}}),
+		"docProps/core.xml":                            d.coreProps(),
+		"docProps/app.xml":                             d.appProps(),
+		"ppt/presentation.xml":                         d.presentation(),
+		"ppt/_rels/presentation.xml.rels":              d.presentationRels(),
+		"ppt/presProps.xml":                            `<p:presentationPr ` + pptxNamespaces + `/>`,
+		"ppt/viewProps.xml":                            `<p:viewPr ` + pptxNamespaces + `><p:normalViewPr/><p:gridSpacing cx="76200" cy="76200"/></p:viewPr>`,
+		"ppt/tableStyles.xml":                          `<a:tblStyleLst xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" def="{5C22544A-7EE6-4342-B048-85BDC9FD1C3A}"/>`,
+		"ppt/theme/theme1.xml":                         d.themeXML(),
+		"ppt/slideMasters/slideMaster1.xml":            pptxSlideMaster,
+		"ppt/slideMasters/_rels/slideMaster1.xml.rels": pptxRels([]pptxRel{{"slideLayout", "../slideLayouts/slideLayout1.xml"}, {"theme", "../theme/theme1.xml"}}),
+		"ppt/slideLayouts/slideLayout1.xml":            `<p:sldLayout ` + pptxNamespaces + ` type="blank" preserve="1"><p:cSld name="Blank"><p:spTree>` + pptxGroupShape + `</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sldLayout>`,
+		"ppt/slideLayouts/_rels/slideLayout1.xml.rels": pptxRels([]pptxRel{{"slideMaster", "../slideMasters/slideMaster1.xml"}}),
+	}
+	for i, slide := range d.slides {
+		parts[fmt.Sprintf("ppt/slides/slide%d.xml", i+1)] = slide.xml()
+		rels := []pptxRel{{"slideLayout", "../slideLayouts/slideLayout1.xml"}}
+		for _, media := range slide.media {
+			rels = append(rels, pptxRel{"image", fmt.Sprintf("../media/image%d.%s", media+1, d.media[media].ext)})
+		}
+		parts[fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", i+1)] = pptxRels(rels)
+	}
+
+	// [Content_Types].xml goes first, as some readers expect
+	names := []string{"[Content_Types].xml"}
+	for name := range parts {
+		if name != names[0] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	for _, name := range names {
+		if err := write(name, parts[name]); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	for i, media := range d.media {
+		f, err := zw.Create(fmt.Sprintf("ppt/media/image%d.%s", i+1, media.ext))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(media.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize deck: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *pptxSlide) xml() string {
+	return fmt.Sprintf(`<p:sld %s><p:cSld><p:bg><p:bgPr><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:effectLst/></p:bgPr></p:bg><p:spTree>%s%s</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sld>`,
+		pptxNamespaces, pptxColor(s.background), pptxGroupShape, s.shapes.String())
+}
+
+// pptxRel is a relationship type (relative to the officeDocument namespace) and target
+type pptxRel struct {
+	kind, target string
+}
+
+func pptxRels(rels []pptxRel) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<Relationships xmlns="%s">`, pptxRelsNS)
+	for i, rel := range rels {
+		kind := pptxRelBase + rel.kind
+		if rel.kind == "metadata/core-properties" {
+			kind = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+		}
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s" Target="%s"/>`, i+1, kind, rel.target)
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func (d *pptxDeck) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Default Extension="png" ContentType="image/png"/><Default Extension="jpeg" ContentType="image/jpeg"/>`)
+	overrides := [][2]string{
+		{"/ppt/presentation.xml", "application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"},
+		{"/ppt/slideMasters/slideMaster1.xml", "application/vnd.openxmlformats-officedocument.presentationml.slideMaster+xml"},
+		{"/ppt/slideLayouts/slideLayout1.xml", "application/vnd.openxmlformats-officedocument.presentationml.slideLayout+xml"},
+		{"/ppt/theme/theme1.xml", "application/vnd.openxmlformats-officedocument.theme+xml"},
+		{"/ppt/presProps.xml", "application/vnd.openxmlformats-officedocument.presentationml.presProps+xml"},
+		{"/ppt/viewProps.xml", "application/vnd.openxmlformats-officedocument.presentationml.viewProps+xml"},
+		{"/ppt/tableStyles.xml", "application/vnd.openxmlformats-officedocument.presentationml.tableStyles+xml"},
+		{"/docProps/core.xml", "application/vnd.openxmlformats-package.core-properties+xml"},
+		{"/docProps/app.xml", "application/vnd.openxmlformats-officedocument.extended-properties+xml"},
+	}
+	for i := range d.slides {
+		overrides = append(overrides, [2]string{fmt.Sprintf("/ppt/slides/slide%d.xml", i+1), "application/vnd.openxmlformats-officedocument.presentationml.slide+xml"})
+	}
+	for _, o := range overrides {
+		fmt.Fprintf(&b, `<Override PartName="%s" ContentType="%s"/>`, o[0], o[1])
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func (d *pptxDeck) coreProps() string {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return fmt.Sprintf(`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><dc:title>%s</dc:title><dc:creator>Bezz AI</dc:creator><dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created><dcterms:modified xsi:type="dcterms:W3CDTF">%s</dcterms:modified></cp:coreProperties>`,
+		pptxEscape(d.title), now, now)
+}
+
+func (d *pptxDeck) appProps() string {
+	return fmt.Sprintf(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"><Application>Bezz AI</Application><Slides>%d</Slides><PresentationFormat>Widescreen</PresentationFormat></Properties>`, len(d.slides))
+}
+
+func (d *pptxDeck) presentation() string {
+	var slides strings.Builder
+	for i := range d.slides {
+		fmt.Fprintf(&slides, `<p:sldId id="%d" r:id="rId%d"/>`, 256+i, i+3)
+	}
+	return fmt.Sprintf(`<p:presentation %s saveSubsetFonts="1"><p:sldMasterIdLst><p:sldMasterId id="2147483648" r:id="rId1"/></p:sldMasterIdLst><p:sldIdLst>%s</p:sldIdLst><p:sldSz cx="%d" cy="%d"/><p:notesSz cx="6858000" cy="9144000"/></p:presentation>`,
+		pptxNamespaces, slides.String(), emu(pptxSlideWidth), emu(pptxSlideHeight))
+}
+
+// presentationRels relates the master (rId1), theme (rId2), slides (rId3 onwards) and the property parts
+func (d *pptxDeck) presentationRels() string {
+	rels := []pptxRel{{"slideMaster", "slideMasters/slideMaster1.xml"}, {"theme", "theme/theme1.xml"}}
+	for i := range d.slides {
+		rels = append(rels, pptxRel{"slide", fmt.Sprintf("slides/slide%d.xml", i+1)})
+	}
+	rels = append(rels, pptxRel{"presProps", "presProps.xml"}, pptxRel{"viewProps", "viewProps.xml"}, pptxRel{"tableStyles", "tableStyles.xml"})
+	return pptxRels(rels)
+}
+
+func (d *pptxDeck) themeXML() string {
+	t := d.theme
+	solid := `<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>`
+	line := `<a:ln w="6350">` + solid + `</a:ln>`
+	effect := `<a:effectStyle><a:effectLst/></a:effectStyle>`
+	font := func(typeface string) string {
+		return fmt.Sprintf(`<a:latin typeface="%s"/><a:ea typeface=""/><a:cs typeface=""/>`, pptxEscape(typeface))
+	}
+	return fmt.Sprintf(`<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Brand"><a:themeElements>`+
+		`<a:clrScheme name="Brand"><a:dk1><a:srgbClr val="000000"/></a:dk1><a:lt1><a:srgbClr val="FFFFFF"/></a:lt1><a:dk2><a:srgbClr val="%s"/></a:dk2><a:lt2><a:srgbClr val="%s"/></a:lt2>`+
+		`<a:accent1><a:srgbClr val="%s"/></a:accent1><a:accent2><a:srgbClr val="%s"/></a:accent2><a:accent3><a:srgbClr val="%s"/></a:accent3><a:accent4><a:srgbClr val="%s"/></a:accent4><a:accent5><a:srgbClr val="%s"/></a:accent5><a:accent6><a:srgbClr val="%s"/></a:accent6>`+
+		`<a:hlink><a:srgbClr val="%s"/></a:hlink><a:folHlink><a:srgbClr val="%s"/></a:folHlink></a:clrScheme>`+
+		`<a:fontScheme name="Brand"><a:majorFont>%s</a:majorFont><a:minorFont>%s</a:minorFont></a:fontScheme>`+
+		`<a:fmtScheme name="Brand"><a:fillStyleLst>%s%s%s</a:fillStyleLst><a:lnStyleLst>%s%s%s</a:lnStyleLst><a:effectStyleLst>%s%s%s</a:effectStyleLst><a:bgFillStyleLst>%s%s%s</a:bgFillStyleLst></a:fmtScheme>`+
+		`</a:themeElements><a:objectDefaults/><a:extraClrSchemeLst/></a:theme>`,
+		pptxColor(t.dark), pptxColor(t.light),
+		pptxColor(t.accent1), pptxColor(t.accent2), pptxColor(t.accent3),
+		pptxColor(mixColors(t.accent1, paletteWhite, 0.4)), pptxColor(mixColors(t.accent2, paletteBlack, 0.3)), pptxColor(mixColors(t.accent3, paletteBlack, 0.3)),
+		pptxColor(t.accent1), pptxColor(mixColors(t.accent1, paletteBlack, 0.3)),
+		font(d.headingFont), font(d.bodyFont),
+		solid, solid, solid, line, line, line, effect, effect, effect, solid, solid, solid)
+}
+
+// pptxSlideMaster has no placeholders: every slide draws its own shapes on the blank layout
+const pptxSlideMaster = `<p:sldMaster ` + pptxNamespaces + `><p:cSld><p:bg><p:bgRef idx="1001"><a:schemeClr val="bg1"/></p:bgRef></p:bg><p:spTree>` + pptxGroupShape + `</p:spTree></p:cSld>` +
+	`<p:clrMap bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"/>` +
+	`<p:sldLayoutIdLst><p:sldLayoutId id="2147483649" r:id="rId1"/></p:sldLayoutIdLst>` +
+	`<p:txStyles><p:titleStyle><a:lvl1pPr><a:defRPr sz="4000"><a:latin typeface="+mj-lt"/></a:defRPr></a:lvl1pPr></p:titleStyle><p:bodyStyle><a:lvl1pPr><a:defRPr sz="1800"><a:latin typeface="+mn-lt"/></a:defRPr></a:lvl1pPr></p:bodyStyle><p:otherStyle><a:lvl1pPr><a:defRPr sz="1800"/></a:lvl1pPr></p:otherStyle></p:txStyles></p:sldMaster>`
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// sectorCacheTTL bounds how long admin edits take to reach every instance
+const sectorCacheTTL = time.Minute
+
+// defaultSectorPhotography is used by photographic styles when a sector has no photography style
+const defaultSectorPhotography = "professional commercial photography, Canon EOS R5, 50mm f/1.8 lens, soft natural lighting from large windows, shallow depth of field with blurred modern office background"
+
+var sectorIDPattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// ErrUnknownSector is returned when a brief's sector is missing or matches no enabled sector
+var ErrUnknownSector = errors.New("unknown sector")
+
+// Built-in sector taxonomy - Firestore documents in "sectors" override or extend these by ID
+var defaultSectors = []bezzmodels.Sector{
+	{
+		ID: "technology", Icon: "💻", Description: "Software, SaaS, Hardware, AI",
+		Labels:           map[string]string{"en": "Technology", "fr": "Technologie"},
+		Synonyms:         []string{"tech", "software", "saas", "it", "ai", "hardware", "tech startup"},
+		PhotographyStyle: "professional tech photography, Canon EOS R5, 85mm f/1.4 lens, soft natural lighting from floor-to-ceiling windows, shallow depth of field with blurred contemporary workspace, polished surfaces and reflections",
+		PromptHints:      "modern devices and screens, collaborative workspaces, clean minimal environments",
+	},
+	{
+		ID: "healthcare", Icon: "🏥", Description: "Medical, Wellness, Pharma, Biotech",
+		Labels:           map[string]string{"en": "Healthcare", "fr": "Santé"},
+		Synonyms:         []string{"health", "medical", "wellness", "pharma", "biotech", "clinic", "medtech"},
+		PhotographyStyle: "clinical photography, professional medical photography, 100mm macro lens f/2.8, bright even lighting with soft shadows, sterile white environment, authentic medical setting",
+		PromptHints:      "caring human interaction, calm and hygienic settings, reassuring tones",
+	},
+	{
+		ID: "finance", Icon: "💰", Description: "Banking, Fintech, Insurance, Investment",
+		Labels:           map[string]string{"en": "Finance", "fr": "Finance"},
+		Synonyms:         []string{"fintech", "banking", "bank", "insurance", "investment", "payments", "mobile money"},
+		PhotographyStyle: "corporate lifestyle photography, 50mm f/1.8 lens, warm professional lighting, polished marble surfaces and modern glass architecture, upscale business environment",
+		PromptHints:      "trust and security, everyday people managing money on mobile, growth and stability",
+	},
+	{
+		ID: "e_commerce", Icon: "🛒", Description: "Online Retail, Marketplace, Dropshipping",
+		Labels:           map[string]string{"en": "E-commerce", "fr": "E-commerce"},
+		Synonyms:         []string{"ecommerce", "online retail", "retail", "marketplace", "online store", "dropshipping", "commerce en ligne"},
+		PhotographyStyle: "product photography, 100mm macro lens f/8, studio lighting with large softbox setup, white seamless background, perfect even lighting, commercial product photography",
+		PromptHints:      "hero products, unboxing and delivery moments, convenient shopping",
+	},
+	{
+		ID: "education", Icon: "📚", Description: "EdTech, Schools, Training, Courses",
+		Labels:           map[string]string{"en": "Education", "fr": "Éducation"},
+		Synonyms:         []string{"edtech", "school", "schools", "training", "courses", "e-learning", "elearning", "university", "enseignement"},
+		PhotographyStyle: "education lifestyle photography, 35mm f/2 lens, bright natural classroom lighting, engaged learners with books and laptops, authentic learning environment",
+		PromptHints:      "curious learners, mentoring moments, progress and achievement",
+	},
+	{
+		ID: "food_beverage", Icon: "🍽️", Description: "Restaurants, CPG, Delivery, Catering",
+		Labels:           map[string]string{"en": "Food & Beverage", "fr": "Alimentation et boissons"},
+		Synonyms:         []string{"food", "beverage", "restaurant", "restaurants", "catering", "food delivery", "drinks", "cpg", "restauration"},
+		PhotographyStyle: "food photography, 100mm macro lens f/2.8, warm golden hour lighting through café window, rustic wooden surfaces, visible steam and texture details, authentic restaurant setting",
+		PromptHints:      "appetizing close-ups, fresh ingredients, shared meals",
+	},
+	{
+		ID: "fashion", Icon: "👗", Description: "Apparel, Accessories, Beauty, Cosmetics",
+		Labels:           map[string]string{"en": "Fashion", "fr": "Mode"},
+		Synonyms:         []string{"apparel", "clothing", "accessories", "beauty", "cosmetics", "jewelry", "beauté"},
+		PhotographyStyle: "high-end fashion photography, 85mm f/1.4 lens, dramatic studio lighting with key light and rim light, textured fabrics and materials, professional fashion studio",
+		PromptHints:      "confident models, fabric and material detail, editorial poses",
+	},
+	{
+		ID: "travel", Icon: "✈️", Description: "Tourism, Hospitality, Transport, Hotels",
+		Labels:           map[string]string{"en": "Travel", "fr": "Voyage"},
+		Synonyms:         []string{"tourism", "hospitality", "hotel", "hotels", "airline", "transport", "tourisme"},
+		PhotographyStyle: "travel lifestyle photography, 35mm f/2 lens, natural outdoor lighting, authentic cultural settings, candid documentary style, real locations",
+		PromptHints:      "memorable destinations, local culture, relaxed travellers",
+	},
+	{
+		ID: "real_estate", Icon: "🏠", Description: "Property, Construction, Architecture",
+		Labels:           map[string]string{"en": "Real Estate", "fr": "Immobilier"},
+		Synonyms:         []string{"property", "properties", "construction", "architecture", "realty", "housing", "proptech"},
+		PhotographyStyle: "architectural photography, 24mm f/8 lens, bright natural lighting through large windows, clean modern interiors, professional real estate photography",
+		PromptHints:      "welcoming interiors, attractive exteriors, families at home",
+	},
+	{
+		ID: "automotive", Icon: "🚗", Description: "Vehicles, Auto Parts, Car Services",
+		Labels:           map[string]string{"en": "Automotive", "fr": "Automobile"},
+		Synonyms:         []string{"auto", "cars", "car", "vehicles", "auto parts", "mobility", "car services"},
+		PhotographyStyle: "automotive photography, 50mm f/2.8 lens, dynamic lighting with reflections on polished metal, urban setting, professional car photography",
+		PromptHints:      "vehicles in motion, polished details, open roads and city streets",
+	},
+	{
+		ID: "entertainment", Icon: "🎬", Description: "Media, Gaming, Events, Streaming",
+		Labels:           map[string]string{"en": "Entertainment", "fr": "Divertissement"},
+		Synonyms:         []string{"media", "gaming", "games", "events", "streaming", "music", "film"},
+		PhotographyStyle: "event photography, 50mm f/1.8 lens, dynamic stage lighting, authentic crowd atmosphere, professional concert photography, candid moments",
+		PromptHints:      "energy and excitement, audiences, vibrant stages and screens",
+	},
+	{
+		ID: "sports_fitness", Icon: "⚽", Description: "Gyms, Equipment, Sports Teams",
+		Labels:           map[string]string{"en": "Sports & Fitness", "fr": "Sport et fitness"},
+		Synonyms:         []string{"sports", "sport", "fitness", "gym", "gyms", "sports equipment", "wellbeing"},
+		PhotographyStyle: "sports photography, 70-200mm f/2.8 lens, energetic gym lighting, authentic workout environment, action photography with motion blur",
+		PromptHints:      "athletes in action, determination and effort, team spirit",
+	},
+	{
+		ID: "manufacturing", Icon: "🏭", Description: "Industrial, Production, Machinery",
+		Labels:           map[string]string{"en": "Manufacturing", "fr": "Industrie manufacturière"},
+		Synonyms:         []string{"industrial", "industry", "production", "machinery", "factory", "industrie"},
+		PhotographyStyle: "industrial photography, 24mm f/5.6 lens, clean professional lighting, modern machinery and equipment, authentic factory setting",
+		PromptHints:      "precision machinery, skilled workers, quality control",
+	},
+	{
+		ID: "agriculture", Icon: "🌾", Description: "Farming, Food Production, Agtech",
+		Labels:           map[string]string{"en": "Agriculture", "fr": "Agriculture"},
+		Synonyms:         []string{"farming", "farm", "agtech", "agribusiness", "agro", "food production"},
+		PhotographyStyle: "agricultural photography, 35mm f/4 lens, natural outdoor lighting, authentic farm environment, documentary style, real agricultural setting",
+		PromptHints:      "fields and harvests, farmers at work, sustainable growth",
+	},
+	{
+		ID: "energy", Icon: "⚡", Description: "Renewable, Oil & Gas, Utilities",
+		Labels:           map[string]string{"en": "Energy", "fr": "Énergie"},
+		Synonyms:         []string{"renewable", "renewables", "solar", "oil and gas", "utilities", "power", "cleantech"},
+		PhotographyStyle: "industrial energy photography, 24mm f/8 lens, dramatic lighting with industrial structures, authentic power plant or renewable energy site",
+		PromptHints:      "solar panels and infrastructure, homes and businesses powered up, clean skies",
+	},
+	{
+		ID: "telecommunications", Icon: "📡", Description: "Internet, Mobile, Networking",
+		Labels:           map[string]string{"en": "Telecommunications", "fr": "Télécommunications"},
+		Synonyms:         []string{"telecom", "telco", "internet", "mobile", "networking", "isp", "connectivity"},
+		PhotographyStyle: "technology photography, 85mm f/2.8 lens, modern clean lighting, contemporary office with communication devices, professional business setting",
+		PromptHints:      "people connecting across distance, phones and networks, seamless coverage",
+	},
+	{
+		ID: "legal_services", Icon: "⚖️", Description: "Law Firms, Legal Tech, Consulting",
+		Labels:           map[string]string{"en": "Legal Services", "fr": "Services juridiques"},
+		Synonyms:         []string{"legal", "law", "law firm", "lawyers", "legal tech", "legaltech", "juridique"},
+		PhotographyStyle: "professional corporate photography, 50mm f/2.8 lens, formal professional lighting, upscale law office environment with legal books and documents",
+		PromptHints:      "trusted advisors, calm consultations, clarity and protection",
+	},
+	{
+		ID: "marketing_advertising", Icon: "📢", Description: "Agencies, Digital Marketing, PR",
+		Labels:           map[string]string{"en": "Marketing & Advertising", "fr": "Marketing et publicité"},
+		Synonyms:         []string{"marketing", "advertising", "agency", "digital marketing", "pr", "public relations", "publicité"},
+		PhotographyStyle: "creative studio photography, 50mm f/1.8 lens, artistic lighting setup, modern creative workspace with design elements, professional commercial photography",
+		PromptHints:      "creative teams at work, bold ideas, campaign results",
+	},
+	{
+		ID: "consulting", Icon: "💼", Description: "Business, Strategy, Management",
+		Labels:           map[string]string{"en": "Consulting", "fr": "Conseil"},
+		Synonyms:         []string{"consultancy", "business consulting", "strategy", "management consulting", "advisory"},
+		PhotographyStyle: "business photography, 35mm f/2.8 lens, professional meeting lighting, upscale conference room environment, authentic business interaction",
+		PromptHints:      "workshops and whiteboards, confident advisors, clients succeeding",
+	},
+	{
+		ID: "logistics", Icon: "📦", Description: "Shipping, Supply Chain, Warehousing",
+		Labels:           map[string]string{"en": "Logistics", "fr": "Logistique"},
+		Synonyms:         []string{"shipping", "supply chain", "warehousing", "delivery", "freight", "courier"},
+		PhotographyStyle: "logistics photography, 24mm f/5.6 lens, industrial warehouse lighting, modern distribution center, authentic logistics operation",
+		PromptHints:      "parcels on the move, organised warehouses, on-time delivery",
+	},
+	{
+		ID: "pet_care", Icon: "🐕", Description: "Veterinary, Pet Products, Services",
+		Labels:           map[string]string{"en": "Pet Care", "fr": "Soins pour animaux"},
+		Synonyms:         []string{"pets", "pet", "veterinary", "vet", "pet products", "animal care"},
+		PhotographyStyle: "pet lifestyle photography, 85mm f/1.8 lens, warm natural lighting in home setting, authentic pet-owner interaction, candid moments",
+		PromptHints:      "happy pets with their owners, playful moments, gentle care",
+	},
+	{
+		ID: "home_garden", Icon: "🏡", Description: "Home Improvement, Landscaping, Decor",
+		Labels:           map[string]string{"en": "Home & Garden", "fr": "Maison et jardin"},
+		Synonyms:         []string{"home", "garden", "home improvement", "landscaping", "decor", "interior design", "diy"},
+		PhotographyStyle: "lifestyle photography, 35mm f/2.8 lens, bright natural lighting through windows, beautiful modern home interior, authentic living space",
+		PromptHints:      "inviting rooms, lush gardens, before-and-after transformations",
+	},
+	{
+		ID: "arts_crafts", Icon: "🎨", Description: "Art Supplies, Handmade, Creative",
+		Labels:           map[string]string{"en": "Arts & Crafts", "fr": "Arts et artisanat"},
+		Synonyms:         []string{"arts", "crafts", "art", "handmade", "art supplies", "artisan", "artisanat"},
+		PhotographyStyle: "artistic photography, 100mm macro f/2.8 lens, creative lighting setup, handmade items with visible textures and craftsmanship details",
+		PromptHints:      "hands at work, materials and textures, finished pieces",
+	},
+	{
+		ID: "non_profit", Icon: "🤝", Description: "NGO, Charity, Social Impact, Foundations",
+		Labels:           map[string]string{"en": "Non-profit", "fr": "Organisation à but non lucratif"},
+		Synonyms:         []string{"nonprofit", "ngo", "charity", "foundation", "social impact", "ong", "association"},
+		PhotographyStyle: "documentary photography, 35mm f/2 lens, natural authentic lighting, real community settings, photojournalistic style, genuine human moments",
+		PromptHints:      "communities supported, volunteers, hope and dignity",
+	},
+	{
+		ID: "government", Icon: "🏛️", Description: "Public Sector, Municipal, Federal",
+		Labels:           map[string]string{"en": "Government", "fr": "Secteur public"},
+		Synonyms:         []string{"public sector", "municipal", "federal", "civic", "public administration", "gouvernement"},
+		PhotographyStyle: "formal institutional photography, 50mm f/4 lens, professional government building lighting, official environment with architectural details",
+		PromptHints:      "citizens served, public spaces, accessible services",
+	},
+	{
+		ID: "other", Icon: "🔧", Description: "Other industries not listed above",
+		Labels:   map[string]string{"en": "Other", "fr": "Autre"},
+		Synonyms: []string{"misc", "general", "autre"},
+	},
+}
+
+// SectorService serves the sector taxonomy, merging built-in sectors with admin edits in Firestore
+type SectorService struct {
+	db *firestore.Client
+
+	mu       sync.RWMutex
+	cache    []bezzmodels.Sector
+	cachedAt time.Time
+}
+
+// NewSectorService creates a new sector service
+func NewSectorService(db *firestore.Client) *SectorService {
+	return &SectorService{
+		db: db,
+	}
+}
+
+// ListSectors returns the taxonomy in display order, optionally including disabled sectors
+func (s *SectorService) ListSectors(ctx context.Context, includeDisabled bool) ([]bezzmodels.Sector, error) {
+	sectors, err := s.loadSectors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bezzmodels.Sector, 0, len(sectors))
+	for _, sector := range sectors {
+		if sector.Disabled && !includeDisabled {
+			continue
+		}
+		result = append(result, sector)
+	}
+	return result, nil
+}
+
+// ResolveSector finds the enabled sector matching an ID, a label in any language or a synonym
+func (s *SectorService) ResolveSector(ctx context.Context, value string) (*bezzmodels.Sector, error) {
+	key := normalizeSectorKey(value)
+	if key == "" {
+		return nil, fmt.Errorf("%w: sector is required", ErrUnknownSector)
+	}
+
+	sectors, err := s.ListSectors(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// IDs and labels win over synonyms so an admin synonym cannot shadow another sector
+	for i := range sectors {
+		if normalizeSectorKey(sectors[i].ID) == key {
+			return &sectors[i], nil
+		}
+		for _, label := range sectors[i].Labels {
+			if normalizeSectorKey(label) == key {
+				return &sectors[i], nil
+			}
+		}
+	}
+	for i := range sectors {
+		for _, synonym := range sectors[i].Synonyms {
+			if normalizeSectorKey(synonym) == key {
+				return &sectors[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSector, value)
+}
+
+// UpsertSector creates or replaces a sector, overriding the built-in entry with the same ID
+func (s *SectorService) UpsertSector(ctx context.Context, sector *bezzmodels.Sector) (*bezzmodels.Sector, error) {
+	if err := ValidateSector(sector); err != nil {
+		return nil, err
+	}
+
+	sector.UpdatedAt = time.Now()
+	if _, err := s.db.Collection("sectors").Doc(sector.ID).Set(ctx, sector); err != nil {
+		return nil, fmt.Errorf("failed to save sector: %w", err)
+	}
+
+	s.invalidate()
+	log.Printf("✅ SECTORS: Saved sector %s", sector.ID)
+	return sector, nil
+}
+
+// DeleteSector removes an admin-defined sector; built-in sectors revert to their defaults
+func (s *SectorService) DeleteSector(ctx context.Context, sectorID string) error {
+	if _, err := s.db.Collection("sectors").Doc(sectorID).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete sector: %w", err)
+	}
+
+	s.invalidate()
+	log.Printf("🗑️ SECTORS: Deleted sector override %s", sectorID)
+	return nil
+}
+
+// loadSectors returns the cached taxonomy, reloading it from Firestore once the cache expires
+func (s *SectorService) loadSectors(ctx context.Context) ([]bezzmodels.Sector, error) {
+	s.mu.RLock()
+	if s.cache != nil && time.Since(s.cachedAt) < sectorCacheTTL {
+		sectors := s.cache
+		s.mu.RUnlock()
+		return sectors, nil
+	}
+	s.mu.RUnlock()
+
+	overrides, err := s.fetchOverrides(ctx)
+	if err != nil {
+		// Serve the built-in taxonomy rather than blocking brief creation
+		log.Printf("⚠️ SECTORS: Failed to load sector overrides, using built-in sectors: %v", err)
+		return mergeSectors(defaultSectors, nil), nil
+	}
+
+	sectors := mergeSectors(defaultSectors, overrides)
+
+	s.mu.Lock()
+	s.cache = sectors
+	s.cachedAt = time.Now()
+	s.mu.Unlock()
+
+	return sectors, nil
+}
+
+// fetchOverrides reads every admin-edited sector from Firestore
+func (s *SectorService) fetchOverrides(ctx context.Context) ([]bezzmodels.Sector, error) {
+	if s.db == nil {
+		return nil, nil
+	}
+
+	iter := s.db.Collection("sectors").Documents(ctx)
+	defer iter.Stop()
+
+	var overrides []bezzmodels.Sector
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var sector bezzmodels.Sector
+		if err := doc.DataTo(&sector); err != nil {
+			log.Printf("⚠️ SECTORS: Skipping malformed sector %s: %v", doc.Ref.ID, err)
+			continue
+		}
+		sector.ID = doc.Ref.ID
+		overrides = append(overrides, sector)
+	}
+	return overrides, nil
+}
+
+// invalidate drops the cached taxonomy so the next read reloads it
+func (s *SectorService) invalidate() {
+	s.mu.Lock()
+	s.cache = nil
+	s.mu.Unlock()
+}
+
+// mergeSectors replaces built-in sectors by ID and appends new ones sorted by English label
+func mergeSectors(defaults, overrides []bezzmodels.Sector) []bezzmodels.Sector {
+	byID := make(map[string]bezzmodels.Sector, len(overrides))
+	for _, sector := range overrides {
+		byID[sector.ID] = sector
+	}
+
+	merged := make([]bezzmodels.Sector, 0, len(defaults)+len(overrides))
+	for _, sector := range defaults {
+		if override, ok := byID[sector.ID]; ok {
+			sector = override
+			delete(byID, sector.ID)
+		}
+		merged = append(merged, sector)
+	}
+
+	added := make([]bezzmodels.Sector, 0, len(byID))
+	for _, sector := range byID {
+		added = append(added, sector)
+	}
+	sort.Slice(added, func(i, j int) bool {
+		return added[i].Labels["en"] < added[j].Labels["en"]
+	})
+
+	// Keep "Other" last
+	if n := len(merged); n > 0 && merged[n-1].ID == "other" {
+		other := merged[n-1]
+		return append(append(merged[:n-1], added...), other)
+	}
+	return append(merged, added...)
+}
+
+// ValidateSector checks the fields an admin must provide
+func ValidateSector(sector *bezzmodels.Sector) error {
+	if !sectorIDPattern.MatchString(sector.ID) {
+		return fmt.Errorf("sector id must be lowercase letters, digits and underscores")
+	}
+	if strings.TrimSpace(sector.Labels["en"]) == "" {
+		return fmt.Errorf("sector must have an English (en) label")
+	}
+	return nil
+}
+
+// normalizeSectorKey folds case, "&"/"and" and punctuation so "Food & Beverage", "food_beverage" and "food and beverage" match
+func normalizeSectorKey(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.ReplaceAll(value, "&", " and ")
+
+	var words []string
+	for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}) {
+		if word != "and" {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// sectorPhotography returns the sector's photography style, or the generic one
+func sectorPhotography(sector *bezzmodels.Sector) string {
+	if sector != nil && sector.PhotographyStyle != "" {
+		return sector.PhotographyStyle
+	}
+	return defaultSectorPhotography
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestResolveSector_MatchesIDsLabelsAndSynonyms(t *testing.T) {
+	service := NewSectorService(nil)
+	ctx := context.Background()
+
+	for _, input := range []string{"food_beverage", "Food & Beverage", "food and beverage", "Alimentation et boissons", "restaurant"} {
+		sector, err := service.ResolveSector(ctx, input)
+		require.NoError(t, err, input)
+		assert.Equal(t, "food_beverage", sector.ID, input)
+	}
+
+	sector, err := service.ResolveSector(ctx, "tech")
+	require.NoError(t, err)
+	assert.Equal(t, "technology", sector.ID)
+
+	_, err = service.ResolveSector(ctx, "underwater basket weaving")
+	assert.ErrorIs(t, err, ErrUnknownSector)
+	_, err = service.ResolveSector(ctx, "  ")
+	assert.ErrorIs(t, err, ErrUnknownSector)
+}
+
+func TestListSectors_DefaultsWithOtherLast(t *testing.T) {
+	sectors, err := NewSectorService(nil).ListSectors(context.Background(), false)
+	require.NoError(t, err)
+	assert.Len(t, sectors, len(defaultSectors))
+	assert.Equal(t, "other", sectors[len(sectors)-1].ID)
+}
+
+func TestMergeSectors_OverridesAndExtends(t *testing.T) {
+	overrides := []models.Sector{
+		{ID: "technology", Labels: map[string]string{"en": "Tech & Software"}, Disabled: true},
+		{ID: "mining", Labels: map[string]string{"en": "Mining"}},
+	}
+
+	merged := mergeSectors(defaultSectors, overrides)
+	assert.Len(t, merged, len(defaultSectors)+1)
+	assert.Equal(t, "Tech & Software", merged[0].Labels["en"])
+	assert.True(t, merged[0].Disabled)
+	assert.Equal(t, "mining", merged[len(merged)-2].ID)
+	assert.Equal(t, "other", merged[len(merged)-1].ID)
+
+	// The shared defaults are not modified by merging
+	assert.Equal(t, "Technology", defaultSectors[0].Labels["en"])
+	assert.Equal(t, "other", defaultSectors[len(defaultSectors)-1].ID)
+}
+
+func TestValidateSector(t *testing.T) {
+	assert.NoError(t, ValidateSector(&models.Sector{ID: "pet_care", Labels: map[string]string{"en": "Pet Care"}}))
+	assert.Error(t, ValidateSector(&models.Sector{ID: "Pet Care", Labels: map[string]string{"en": "Pet Care"}}))
+	assert.Error(t, ValidateSector(&models.Sector{ID: "pet_care", Labels: map[string]string{"fr": "Animaux"}}))
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// StylePreset describes a visual style ad images can be rendered in
+type StylePreset struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	guidance          string     // what Creative-Director-GPT should write into each dalle_prompt
+	prefix            string     // opening words prepended to the image prompt
+	modifiers         []string   // style terms appended to the image prompt
+	sectorPhotography bool       // prepend the sector's photography style to the modifiers
+	negativeTerms     []string   // looks the style must avoid
+	styledMarkers     []string   // if the prompt already contains one of these, it is not prefixed again
+	requiredTerms     [][]string // each group must be matched by at least one term for the prompt to validate
+}
+
+// defaultStyleID is used when a brief does not choose a style
+const defaultStyleID = "photorealistic"
+
+// avoidMarker separates the style's negative terms from the rest of the image prompt
+const avoidMarker = ". Avoid: "
+
+// Style preset registry - photorealism is the default, not the only option
+var stylePresets = map[string]StylePreset{
+	"photorealistic": {
+		ID:          "photorealistic",
+		Name:        "Photorealistic",
+		Description: "Commercial DSLR photography with real lighting, lenses and textures",
+		guidance: "Start with \"Professional DSLR photo of\"; include camera details (e.g. \"shot with 85mm lens, f/1.8 aperture\"), " +
+			"a specific lighting setup, real textures, environmental context and shallow depth of field. It must look like a real photograph.",
+		prefix:            "Professional DSLR photo of",
+		modifiers:         []string{"high resolution commercial photography", "sharp focus", "photojournalistic style", "authentic realistic photography"},
+		sectorPhotography: true,
+		negativeTerms:     []string{"illustration", "cartoon", "painting", "drawing", "sketch", "3D render"},
+		styledMarkers:     []string{"professional dslr photo", "professional photo", "dslr photo", "canon eos", "85mm"},
+		requiredTerms: [][]string{
+			{"photo of", "dslr", "photography"},
+			{"lighting"},
+			{"lens", "aperture", "focus"},
+		},
+	},
+	"flat_illustration": {
+		ID:          "flat_illustration",
+		Name:        "Flat illustration",
+		Description: "Clean vector illustration with flat color fields and simple shapes",
+		guidance: "Start with \"Flat vector illustration of\"; describe simple geometric shapes, bold flat color fields built from the brand palette, " +
+			"minimal shading and generous negative space. Do not mention cameras, lenses or photography.",
+		prefix:        "Flat vector illustration of",
+		modifiers:     []string{"flat design", "bold solid colors", "simple geometric shapes", "clean vector lines", "minimal shading"},
+		negativeTerms: []string{"photograph", "photorealistic", "3D render", "gradients", "text or lettering"},
+		styledMarkers: []string{"flat vector illustration", "flat illustration"},
+		requiredTerms: [][]string{{"illustration", "vector"}},
+	},
+	"3d_render": {
+		ID:          "3d_render",
+		Name:        "3D render",
+		Description: "Soft, polished 3D scenes with studio lighting and tactile materials",
+		guidance: "Start with \"3D render of\"; describe rounded forms, tactile materials (clay, matte plastic, glass), " +
+			"soft global illumination and a simple studio backdrop in brand colors. Do not mention cameras or photography.",
+		prefix:        "3D render of",
+		modifiers:     []string{"soft global illumination", "smooth rounded forms", "matte and glossy materials", "studio backdrop", "high detail octane-style render"},
+		negativeTerms: []string{"photograph", "flat illustration", "sketch", "text or lettering"},
+		styledMarkers: []string{"3d render", "3d illustration"},
+		requiredTerms: [][]string{{"3d", "render"}},
+	},
+	"isometric": {
+		ID:          "isometric",
+		Name:        "Isometric",
+		Description: "Isometric scenes and diagrams that explain products and processes",
+		guidance: "Start with \"Isometric illustration of\"; describe a tidy isometric scene viewed from a 30-degree angle, " +
+			"clear building blocks or devices, consistent brand-colored surfaces and crisp edges.",
+		prefix:        "Isometric illustration of",
+		modifiers:     []string{"isometric perspective", "30-degree angle", "crisp clean edges", "consistent light direction", "tidy composition"},
+		negativeTerms: []string{"photograph", "perspective distortion", "sketch", "text or lettering"},
+		styledMarkers: []string{"isometric"},
+		requiredTerms: [][]string{{"isometric"}},
+	},
+	"hand_drawn": {
+		ID:          "hand_drawn",
+		Name:        "Hand-drawn",
+		Description: "Warm, hand-drawn ink and watercolor artwork with visible strokes",
+		guidance: "Start with \"Hand-drawn illustration of\"; describe ink linework, watercolor or gouache washes in brand colors, " +
+			"visible brush strokes and paper texture. Do not mention cameras or photography.",
+		prefix:        "Hand-drawn illustration of",
+		modifiers:     []string{"ink linework", "watercolor washes", "visible brush strokes", "subtle paper texture", "warm handmade feel"},
+		negativeTerms: []string{"photograph", "3D render", "vector", "text or lettering"},
+		styledMarkers: []string{"hand-drawn", "hand drawn"},
+		requiredTerms: [][]string{{"hand-drawn", "hand drawn", "ink", "watercolor"}},
+	},
+}
+
+// ListStylePresets returns every style preset, default first and the rest by name
+func ListStylePresets() []StylePreset {
+	presets := make([]StylePreset, 0, len(stylePresets))
+	for _, preset := range stylePresets {
+		presets = append(presets, preset)
+	}
+	sort.Slice(presets, func(i, j int) bool {
+		if (presets[i].ID == defaultStyleID) != (presets[j].ID == defaultStyleID) {
+			return presets[i].ID == defaultStyleID
+		}
+		return presets[i].Name < presets[j].Name
+	})
+	return presets
+}
+
+// ValidateStyles checks the brief-wide style and per-placement overrides
+func ValidateStyles(style string, adStyles map[string]string) error {
+	if _, ok := stylePresets[style]; style != "" && !ok {
+		return fmt.Errorf("unknown style: %s", style)
+	}
+	for placementID, styleID := range adStyles {
+		if _, ok := adPlacements[placementID]; !ok {
+			return fmt.Errorf("unknown placement in ad styles: %s", placementID)
+		}
+		if _, ok := stylePresets[styleID]; !ok {
+			return fmt.Errorf("unknown style for %s: %s", placementID, styleID)
+		}
+	}
+	return nil
+}
+
+// stylePresetFor returns the preset with the given id, or the default preset
+func stylePresetFor(id string) StylePreset {
+	if preset, ok := stylePresets[id]; ok {
+		return preset
+	}
+	return stylePresets[defaultStyleID]
+}
+
+// StyleSelection is the style a brief asked for, with optional per-placement overrides
+type StyleSelection struct {
+	Default      string            // brief-wide preset id, empty for the default preset
+	PerPlacement map[string]string // placement id -> preset id
+}
+
+// presetFor returns the preset chosen for a placement
+func (s StyleSelection) presetFor(placementID string) StylePreset {
+	if id, ok := s.PerPlacement[placementID]; ok {
+		return stylePresetFor(id)
+	}
+	return stylePresetFor(s.Default)
+}
+
+// apply turns a Creative-Director-GPT image prompt into a full prompt in this style, steered by the sector when known
+func (p StylePreset) apply(basePrompt string, sector *bezzmodels.Sector) string {
+	body := strings.TrimSpace(basePrompt)
+
+	// Prompts that already open in the style are kept as written
+	lowerPrompt := strings.ToLower(body)
+	styled := false
+	for _, marker := range p.styledMarkers {
+		if strings.Contains(lowerPrompt, marker) {
+			styled = true
+			break
+		}
+	}
+
+	if !styled {
+		var modifiers []string
+		if p.sectorPhotography {
+			modifiers = append(modifiers, sectorPhotography(sector))
+		}
+		modifiers = append(modifiers, p.modifiers...)
+		if sector != nil && sector.PromptHints != "" {
+			modifiers = append(modifiers, sector.PromptHints)
+		}
+		body = fmt.Sprintf("%s %s, %s", p.prefix, body, strings.Join(modifiers, ", "))
+	}
+
+	if len(p.negativeTerms) == 0 {
+		return body
+	}
+	return body + avoidMarker + strings.Join(p.negativeTerms, ", ")
+}
+
+// validate reports whether a prompt follows the preset: every required term group matched
+// and no negative term used outside the trailing avoid clause
+func (p StylePreset) validate(prompt string) bool {
+	body, _, _ := strings.Cut(prompt, avoidMarker)
+	lowerBody := strings.ToLower(body)
+
+	for _, group := range p.requiredTerms {
+		matched := false
+		for _, term := range group {
+			if strings.Contains(lowerBody, strings.ToLower(term)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, term := range p.negativeTerms {
+		if strings.Contains(lowerBody, strings.ToLower(term)) {
+			return false
+		}
+	}
+	return true
+}
+
+// promptGuidance describes the preset for Creative-Director-GPT
+func (p StylePreset) promptGuidance() string {
+	return fmt.Sprintf("%s — %s Avoid: %s.", p.Name, p.guidance, strings.Join(p.negativeTerms, ", "))
+}
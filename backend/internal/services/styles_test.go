@@ -0,0 +1,51 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStyles(t *testing.T) {
+	assert.NoError(t, ValidateStyles("", nil))
+	assert.NoError(t, ValidateStyles("flat_illustration", map[string]string{"instagram_story": "3d_render"}))
+	assert.Error(t, ValidateStyles("oil_painting", nil))
+	assert.Error(t, ValidateStyles("", map[string]string{"tiktok": "isometric"}))
+	assert.Error(t, ValidateStyles("", map[string]string{"print": "oil_painting"}))
+}
+
+func TestListStylePresets_DefaultFirst(t *testing.T) {
+	presets := ListStylePresets()
+	assert.Len(t, presets, len(stylePresets))
+	assert.Equal(t, defaultStyleID, presets[0].ID)
+}
+
+func TestStyleSelection_PresetFor(t *testing.T) {
+	selection := StyleSelection{Default: "hand_drawn", PerPlacement: map[string]string{"print": "isometric"}}
+	assert.Equal(t, "isometric", selection.presetFor("print").ID)
+	assert.Equal(t, "hand_drawn", selection.presetFor("instagram_feed").ID)
+	assert.Equal(t, defaultStyleID, StyleSelection{}.presetFor("instagram_feed").ID)
+}
+
+func TestStylePreset_ApplyAndValidate(t *testing.T) {
+	food := &defaultSectors[5]
+	realism := stylePresetFor("photorealistic")
+	prompt := realism.apply("a chef plating a dish", food)
+	assert.True(t, strings.HasPrefix(prompt, "Professional DSLR photo of a chef plating a dish, food photography"))
+	assert.True(t, realism.validate(prompt))
+
+	// Prompts already written in the style are not prefixed twice
+	styled := realism.apply("Professional DSLR photo of a desk, 85mm lens", nil)
+	assert.Equal(t, 1, strings.Count(styled, "Professional DSLR photo"))
+
+	flat := stylePresetFor("flat_illustration")
+	prompt = flat.apply("a family ordering dinner on a phone", food)
+	assert.True(t, strings.HasPrefix(prompt, "Flat vector illustration of a family"))
+	assert.NotContains(t, prompt, "food photography")
+	assert.Contains(t, prompt, food.PromptHints)
+	assert.True(t, flat.validate(prompt))
+
+	// A photographic prompt is not a valid flat illustration
+	assert.False(t, flat.validate("Professional photograph of a vector icon"))
+}
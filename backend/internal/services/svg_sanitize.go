@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxSVGBytes  = 200 << 10 // 200 KB; hand-written logos are a few KB, traced ones rarely exceed 100 KB
+	svgNamespace = "http://www.w3.org/2000/svg"
+)
+
+// Elements allowed in a logo SVG. Anything else (script, style, foreignObject, image, a, animate...)
+// is dropped together with its children.
+var svgAllowedElements = map[string]bool{
+	"svg": true, "g": true, "path": true, "rect": true, "circle": true, "ellipse": true, "line": true,
+	"polyline": true, "polygon": true, "text": true, "tspan": true, "defs": true, "linearGradient": true,
+	"radialGradient": true, "stop": true, "clipPath": true, "title": true, "desc": true,
+}
+
+// Elements that put ink on the page
+var svgDrawableElements = map[string]bool{
+	"path": true, "rect": true, "circle": true, "ellipse": true, "line": true, "polyline": true, "polygon": true, "text": true,
+}
+
+// Presentation and geometry attributes allowed on any element; event handlers, href and style are not
+var svgAllowedAttributes = map[string]bool{
+	"id": true, "viewBox": true, "preserveAspectRatio": true, "version": true,
+	"d": true, "x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true, "cx": true, "cy": true,
+	"r": true, "rx": true, "ry": true, "fx": true, "fy": true, "width": true, "height": true, "points": true,
+	"transform": true, "fill": true, "fill-rule": true, "fill-opacity": true, "stroke": true, "stroke-width": true,
+	"stroke-linecap": true, "stroke-linejoin": true, "stroke-miterlimit": true, "stroke-opacity": true,
+	"stroke-dasharray": true, "clip-rule": true, "clip-path": true, "opacity": true,
+	"offset": true, "stop-color": true, "stop-opacity": true, "gradientUnits": true, "gradientTransform": true,
+	"font-family": true, "font-size": true, "font-weight": true, "font-style": true, "text-anchor": true,
+	"letter-spacing": true, "dominant-baseline": true, "dx": true, "dy": true,
+}
+
+// url() references are only allowed to point inside the document
+var (
+	svgURLPattern      = regexp.MustCompile(`(?i)url\s*\(`)
+	svgLocalURLPattern = regexp.MustCompile(`^url\(#[A-Za-z][\w.-]*\)$`)
+)
+
+// SanitizeSVG parses an SVG and rebuilds it from allowed elements and attributes only, removing scripts,
+// event handlers, external references, DOCTYPEs and processing instructions. It then validates that the
+// result is a renderable logo: an <svg> root with a positive viewBox and at least one drawable element.
+func SanitizeSVG(data []byte) ([]byte, error) {
+	if len(data) > maxSVGBytes {
+		return nil, fmt.Errorf("SVG is larger than %d KB", maxSVGBytes>>10)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+	decoder.Entity = map[string]string{} // no custom entities, so no entity expansion attacks
+
+	var out bytes.Buffer
+	depth, skipDepth := 0, 0
+	sawRoot, drawable := false, 0
+	var viewBox string
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid SVG: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			name := t.Name.Local
+			if skipDepth > 0 {
+				continue
+			}
+			if depth == 1 {
+				if sawRoot {
+					return nil, fmt.Errorf("SVG must have a single root element")
+				}
+				if name != "svg" {
+					return nil, fmt.Errorf("root element must be <svg>, got <%s>", name)
+				}
+				sawRoot = true
+			} else if !sawRoot {
+				return nil, fmt.Errorf("content outside the <svg> root")
+			}
+			foreign := t.Name.Space != "" && t.Name.Space != svgNamespace
+			if foreign || !svgAllowedElements[name] || (name == "svg" && depth > 1) {
+				skipDepth = depth
+				continue
+			}
+			if svgDrawableElements[name] {
+				drawable++
+			}
+
+			out.WriteString("<" + name)
+			if depth == 1 {
+				out.WriteString(` xmlns="` + svgNamespace + `"`)
+			}
+			for _, attr := range t.Attr {
+				value, ok := sanitizeSVGAttribute(attr)
+				if !ok {
+					continue
+				}
+				if depth == 1 && attr.Name.Local == "viewBox" {
+					viewBox = value
+				}
+				out.WriteString(" " + attr.Name.Local + `="`)
+				xml.EscapeText(&out, []byte(value))
+				out.WriteString(`"`)
+			}
+			out.WriteString(">")
+
+		case xml.EndElement:
+			if skipDepth > 0 {
+				if depth == skipDepth {
+					skipDepth = 0
+				}
+				depth--
+				continue
+			}
+			out.WriteString("</" + t.Name.Local + ">")
+			depth--
+
+		case xml.CharData:
+			if skipDepth == 0 && depth > 0 {
+				xml.EscapeText(&out, t)
+			}
+
+		case xml.Directive:
+			return nil, fmt.Errorf("SVG must not contain DOCTYPE or other directives")
+		}
+		// Comments and processing instructions are dropped
+	}
+
+	if !sawRoot {
+		return nil, fmt.Errorf("no <svg> element found")
+	}
+	if err := validateViewBox(viewBox); err != nil {
+		return nil, err
+	}
+	if drawable == 0 {
+		return nil, fmt.Errorf("SVG has no drawable elements")
+	}
+	return out.Bytes(), nil
+}
+
+// sanitizeSVGAttribute returns the attribute's value if it is allowed and safe
+func sanitizeSVGAttribute(attr xml.Attr) (string, bool) {
+	if attr.Name.Space != "" || !svgAllowedAttributes[attr.Name.Local] {
+		return "", false
+	}
+	value := strings.TrimSpace(attr.Value)
+	lower := strings.ToLower(value)
+	if strings.Contains(lower, "javascript:") || strings.Contains(lower, "data:") || strings.Contains(lower, "expression(") {
+		return "", false
+	}
+	if svgURLPattern.MatchString(value) && !svgLocalURLPattern.MatchString(value) {
+		return "", false
+	}
+	return value, true
+}
+
+// validateViewBox checks for "min-x min-y width height" with a positive width and height
+func validateViewBox(viewBox string) error {
+	fields := strings.FieldsFunc(viewBox, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(fields) != 4 {
+		return fmt.Errorf("SVG must have a viewBox")
+	}
+	var values [4]float64
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return fmt.Errorf("invalid viewBox %q", viewBox)
+		}
+		values[i] = v
+	}
+	if values[2] <= 0 || values[3] <= 0 {
+		return fmt.Errorf("viewBox %q has no area", viewBox)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSVG_StripsActiveContent(t *testing.T) {
+	dirty := `<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 100 40" onload="alert(1)">
+  <!-- comment -->
+  <script>alert(1)</script>
+  <style>@import url(https://evil.example/x.css);</style>
+  <defs><linearGradient id="g"><stop offset="0" stop-color="#0A2540"/></linearGradient></defs>
+  <rect x="0" y="0" width="40" height="40" fill="url(#g)" onclick="steal()"/>
+  <circle cx="70" cy="20" r="10" fill="url(https://evil.example/p.svg#x)"/>
+  <image xlink:href="https://evil.example/track.png" width="1" height="1"/>
+  <foreignObject><div xmlns="http://www.w3.org/1999/xhtml">hi</div></foreignObject>
+  <a href="javascript:alert(1)"><path d="M0 0L1 1"/></a>
+  <text x="50" y="30" font-family="Inter, sans-serif">Acme &amp; Co</text>
+</svg>`
+
+	clean, err := SanitizeSVG([]byte(dirty))
+	require.NoError(t, err)
+
+	out := string(clean)
+	for _, banned := range []string{"script", "style", "onload", "onclick", "evil.example", "image", "foreignObject", "javascript", "<a", "<!--", "<?xml", "xlink"} {
+		assert.NotContains(t, out, banned)
+	}
+	assert.Contains(t, out, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 40">`)
+	assert.Contains(t, out, `fill="url(#g)"`)
+	assert.Contains(t, out, `<circle cx="70" cy="20" r="10">`)
+	assert.Contains(t, out, "Acme &amp; Co")
+}
+
+func TestSanitizeSVG_RejectsInvalidLogos(t *testing.T) {
+	cases := map[string]string{
+		"not xml":      `<svg viewBox="0 0 10 10"><rect`,
+		"wrong root":   `<html><svg viewBox="0 0 10 10"><rect width="1" height="1"/></svg></html>`,
+		"no viewBox":   `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1" height="1"/></svg>`,
+		"empty area":   `<svg viewBox="0 0 0 10"><rect width="1" height="1"/></svg>`,
+		"nothing":      `<svg viewBox="0 0 10 10"><g></g></svg>`,
+		"only scripts": `<svg viewBox="0 0 10 10"><script><![CDATA[alert(1)]]></script></svg>`,
+		"doctype":      `<!DOCTYPE svg [<!ENTITY x "boom">]><svg viewBox="0 0 10 10"><text>&x;</text></svg>`,
+		"second root":  `<svg viewBox="0 0 10 10"><rect width="1" height="1"/></svg><svg><script>alert(1)</script></svg>`,
+	}
+	for name, svg := range cases {
+		_, err := SanitizeSVG([]byte(svg))
+		assert.Error(t, err, name)
+	}
+}
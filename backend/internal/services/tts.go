@@ -0,0 +1,207 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	openai "github.com/openai/openai-go/v2"
+)
+
+// SpeechProvider turns voiceover lines into audio. Providers return WAV so durations can be measured
+// without a decoder and the audio can be mixed by ffmpeg as is.
+type SpeechProvider interface {
+	Name() string
+	Synthesize(ctx context.Context, req SpeechRequest) (*SpeechAudio, error)
+}
+
+// SpeechRequest is one line of voiceover to synthesise
+type SpeechRequest struct {
+	Text         string
+	Voice        string  // provider voice id
+	Instructions string  // delivery direction, for providers that support it
+	Speed        float64 // 1.0 is normal pace; 0 means default
+}
+
+// SpeechAudio is synthesised speech
+type SpeechAudio struct {
+	Data     []byte // WAV
+	Duration time.Duration
+}
+
+const (
+	speechContentType = "audio/wav"
+	maxSpeechSpeed    = 1.25 // faster than this sounds rushed
+)
+
+// OpenAISpeechProvider synthesises speech with OpenAI's text-to-speech API
+type OpenAISpeechProvider struct {
+	client *openai.Client
+	model  openai.SpeechModel
+}
+
+// NewOpenAISpeechProvider creates an OpenAI TTS provider; gpt-4o-mini-tts follows delivery instructions
+func NewOpenAISpeechProvider(client *openai.Client) *OpenAISpeechProvider {
+	return &OpenAISpeechProvider{client: client, model: openai.SpeechModelGPT4oMiniTTS}
+}
+
+// Name identifies the provider
+func (p *OpenAISpeechProvider) Name() string {
+	return "openai"
+}
+
+// Synthesize renders the line as WAV and measures its length
+func (p *OpenAISpeechProvider) Synthesize(ctx context.Context, req SpeechRequest) (*SpeechAudio, error) {
+	params := openai.AudioSpeechNewParams{
+		Input:          req.Text,
+		Model:          p.model,
+		Voice:          openai.AudioSpeechNewParamsVoice(req.Voice),
+		ResponseFormat: openai.AudioSpeechNewParamsResponseFormatWAV,
+	}
+	if req.Instructions != "" {
+		params.Instructions = openai.String(req.Instructions)
+	}
+	if req.Speed > 0 {
+		params.Speed = openai.Float(req.Speed)
+	}
+
+	resp, err := p.client.Audio.Speech.New(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI TTS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TTS audio: %w", err)
+	}
+	duration, err := wavDuration(data)
+	if err != nil {
+		return nil, err
+	}
+	return &SpeechAudio{Data: data, Duration: duration}, nil
+}
+
+// SilentSpeechProvider returns silence lasting as long as the line would take to read aloud.
+// It stands in for a real provider in tests and local development.
+type SilentSpeechProvider struct{}
+
+// Name identifies the provider
+func (SilentSpeechProvider) Name() string {
+	return "silent"
+}
+
+// Synthesize returns a silent WAV timed at voiceoverWordsPerSecond, adjusted for speed
+func (SilentSpeechProvider) Synthesize(ctx context.Context, req SpeechRequest) (*SpeechAudio, error) {
+	words := len(strings.Fields(req.Text))
+	if words == 0 {
+		return nil, fmt.Errorf("nothing to synthesise")
+	}
+	speed := req.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	seconds := float64(words) / voiceoverWordsPerSecond / speed
+	duration := time.Duration(seconds * float64(time.Second))
+	return &SpeechAudio{Data: silentWAV(duration, 24000), Duration: duration}, nil
+}
+
+// voiceProfile maps brand voice adjectives to an OpenAI voice
+type voiceProfile struct {
+	voice    string
+	keywords []string
+}
+
+// Ordered from most to least specific; the profile matching the most keywords wins
+var voiceProfiles = []voiceProfile{
+	{voice: "onyx", keywords: []string{"authoritative", "premium", "luxury", "serious", "expert", "trusted", "trustworthy", "sophisticated", "established"}},
+	{voice: "nova", keywords: []string{"energetic", "playful", "bold", "youthful", "vibrant", "fun", "dynamic", "exciting", "upbeat"}},
+	{voice: "coral", keywords: []string{"warm", "friendly", "caring", "empathetic", "welcoming", "approachable", "community", "supportive"}},
+	{voice: "sage", keywords: []string{"calm", "thoughtful", "wise", "reassuring", "gentle", "mindful", "balanced"}},
+	{voice: "ash", keywords: []string{"confident", "professional", "clear", "direct", "modern", "innovative", "smart"}},
+}
+
+const defaultSpeechVoice = "alloy"
+
+// speechVoiceFor picks a voice and delivery direction from the brand's tonal guidelines
+func speechVoiceFor(voice string, personality []string) (string, string) {
+	text := strings.ToLower(voice + " " + strings.Join(personality, " "))
+
+	chosen, best := defaultSpeechVoice, 0
+	for _, profile := range voiceProfiles {
+		score := 0
+		for _, keyword := range profile.keywords {
+			if strings.Contains(text, keyword) {
+				score++
+			}
+		}
+		if score > best {
+			chosen, best = profile.voice, score
+		}
+	}
+
+	instructions := "Read as a voiceover for a short video ad, with natural pacing and clear diction."
+	if tone := strings.TrimSpace(voice); tone != "" {
+		instructions = fmt.Sprintf("Read as a voiceover for a short video ad. Brand voice: %s. Natural pacing and clear diction.", tone)
+	}
+	return chosen, instructions
+}
+
+// wavDuration reads the length of a PCM WAV file from its fmt and data chunks
+func wavDuration(data []byte) (time.Duration, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return 0, fmt.Errorf("audio is not a WAV file")
+	}
+
+	byteRate := 0
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		body := offset + 8
+		switch id {
+		case "fmt ":
+			if body+12 > len(data) {
+				return 0, fmt.Errorf("truncated WAV fmt chunk")
+			}
+			byteRate = int(binary.LittleEndian.Uint32(data[body+8:]))
+		case "data":
+			if byteRate == 0 {
+				return 0, fmt.Errorf("WAV data chunk before fmt chunk")
+			}
+			// Streamed WAVs leave the size unset (0 or 0xFFFFFFFF); use what was actually received
+			if size == 0 || body+size > len(data) {
+				size = len(data) - body
+			}
+			return time.Duration(float64(size) / float64(byteRate) * float64(time.Second)), nil
+		}
+		offset = body + size + size%2
+	}
+	return 0, fmt.Errorf("WAV has no data chunk")
+}
+
+// silentWAV encodes d of 16-bit mono silence
+func silentWAV(d time.Duration, sampleRate int) []byte {
+	samples := int(math.Round(d.Seconds() * float64(sampleRate)))
+	dataSize := samples * 2
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // mono
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // sample rate
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2)) // byte rate
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // block align
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // bits per sample
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	buf.Write(make([]byte, dataSize))
+	return buf.Bytes()
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestSilentSpeechProvider_TimesLinesByWordCount(t *testing.T) {
+	audio, err := SilentSpeechProvider{}.Synthesize(context.Background(), SpeechRequest{Text: "one two three four five"})
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, audio.Duration)
+
+	measured, err := wavDuration(audio.Data)
+	require.NoError(t, err)
+	assert.Equal(t, audio.Duration, measured)
+
+	faster, err := SilentSpeechProvider{}.Synthesize(context.Background(), SpeechRequest{Text: "one two three four five", Speed: 1.25})
+	require.NoError(t, err)
+	assert.Equal(t, 1600*time.Millisecond, faster.Duration)
+
+	_, err = SilentSpeechProvider{}.Synthesize(context.Background(), SpeechRequest{Text: "  "})
+	assert.Error(t, err)
+}
+
+func TestWAVDuration(t *testing.T) {
+	data := silentWAV(1500*time.Millisecond, 24000)
+	d, err := wavDuration(data)
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	// Streamed WAVs leave the data size unset
+	streamed := append([]byte(nil), data...)
+	copy(streamed[40:44], []byte{0xff, 0xff, 0xff, 0xff})
+	d, err = wavDuration(streamed)
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	_, err = wavDuration([]byte("ID3 not a wav file"))
+	assert.Error(t, err)
+}
+
+func TestSpeechVoiceFor(t *testing.T) {
+	voice, instructions := speechVoiceFor("Warm, friendly and approachable", []string{"caring"})
+	assert.Equal(t, "coral", voice)
+	assert.Contains(t, instructions, "Warm, friendly and approachable")
+
+	voice, _ = speechVoiceFor("Authoritative expert", []string{"premium", "trusted"})
+	assert.Equal(t, "onyx", voice)
+
+	voice, _ = speechVoiceFor("", nil)
+	assert.Equal(t, defaultSpeechVoice, voice)
+}
+
+// flakySpeechProvider fails for one line and delegates the rest to the silent provider
+type flakySpeechProvider struct {
+	failOn   string
+	requests []SpeechRequest
+}
+
+func (p *flakySpeechProvider) Name() string { return "flaky" }
+
+func (p *flakySpeechProvider) Synthesize(ctx context.Context, req SpeechRequest) (*SpeechAudio, error) {
+	p.requests = append(p.requests, req)
+	if req.Text == p.failOn {
+		return nil, fmt.Errorf("provider error")
+	}
+	return SilentSpeechProvider{}.Synthesize(ctx, req)
+}
+
+func TestSynthesizeVoiceover_SpeedsUpOverlongLinesAndSkipsFailures(t *testing.T) {
+	scenes := []models.VideoScene{
+		{ID: "scene_1", Duration: 2, Voiceover: "one two three four five six seven eight"}, // 3.2s at normal pace
+		{ID: "scene_2", Duration: 5},
+		{ID: "scene_3", Duration: 8, Voiceover: "broken line"},
+	}
+	provider := &flakySpeechProvider{failOn: "broken line"}
+
+	audio := synthesizeVoiceover(context.Background(), provider, scenes, "coral", "")
+	require.Len(t, audio, 3)
+	require.NotNil(t, audio[0])
+	assert.Nil(t, audio[1], "silent scenes are not synthesised")
+	assert.Nil(t, audio[2], "failed lines are skipped")
+
+	require.Len(t, provider.requests, 3)
+	assert.Equal(t, maxSpeechSpeed, provider.requests[1].Speed, "the overlong line is re-read at the fastest pace")
+	assert.Equal(t, "coral", provider.requests[0].Voice)
+}
+
+func TestReconcileSceneTimings(t *testing.T) {
+	clip := func(d time.Duration) *SpeechAudio { return &SpeechAudio{Duration: d} }
+
+	// Scene 1 needs 4s, the silent scene 2 gives two seconds back
+	scenes := []models.VideoScene{{Duration: 2}, {Duration: 5}, {Duration: 8}}
+	runtime := reconcileSceneTimings(scenes, []*SpeechAudio{clip(3200 * time.Millisecond), nil, clip(6 * time.Second)})
+	assert.Equal(t, 15, runtime)
+	assert.Equal(t, []int{4, 3, 8}, []int{scenes[0].Duration, scenes[1].Duration, scenes[2].Duration})
+	assert.Equal(t, []int{0, 4, 7}, []int{scenes[0].StartTime, scenes[1].StartTime, scenes[2].StartTime})
+
+	// When every scene is full the video runs long rather than cutting speech off
+	scenes = []models.VideoScene{{Duration: 3}, {Duration: 3}}
+	runtime = reconcileSceneTimings(scenes, []*SpeechAudio{clip(4 * time.Second), clip(2500 * time.Millisecond)})
+	assert.Equal(t, 8, runtime)
+	assert.Equal(t, 5, scenes[1].StartTime)
+}
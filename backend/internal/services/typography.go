@@ -0,0 +1,253 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// fontFamily is an open-licensed font Logo-Designer-GPT may choose from
+type fontFamily struct {
+	Family   string
+	Category string // serif, sans-serif, display, monospace, handwriting
+	Weights  []int
+	License  string
+	Fallback string
+	Traits   string // short character description shown to the model
+}
+
+// fontCatalogue lists fonts that are free to use commercially and available on Google Fonts,
+// so every name we deliver is real and licensable
+var fontCatalogue = []fontFamily{
+	{"Inter", "sans-serif", []int{300, 400, 500, 600, 700, 800}, "OFL-1.1", "Helvetica, Arial, sans-serif", "neutral, highly legible UI workhorse"},
+	{"Manrope", "sans-serif", []int{300, 400, 500, 600, 700, 800}, "OFL-1.1", "Helvetica, Arial, sans-serif", "modern geometric-grotesque, friendly tech"},
+	{"Montserrat", "sans-serif", []int{300, 400, 500, 600, 700, 800, 900}, "OFL-1.1", "Helvetica, Arial, sans-serif", "geometric, confident, urban"},
+	{"Poppins", "sans-serif", []int{300, 400, 500, 600, 700, 800}, "OFL-1.1", "Helvetica, Arial, sans-serif", "geometric, round, approachable"},
+	{"DM Sans", "sans-serif", []int{400, 500, 700}, "OFL-1.1", "Helvetica, Arial, sans-serif", "low-contrast geometric, clean"},
+	{"Work Sans", "sans-serif", []int{300, 400, 500, 600, 700}, "OFL-1.1", "Helvetica, Arial, sans-serif", "grotesque, practical, slightly quirky"},
+	{"Source Sans 3", "sans-serif", []int{300, 400, 600, 700}, "OFL-1.1", "Helvetica, Arial, sans-serif", "humanist, calm, long-form reading"},
+	{"Nunito Sans", "sans-serif", []int{300, 400, 600, 700, 800}, "OFL-1.1", "Helvetica, Arial, sans-serif", "soft humanist, warm"},
+	{"IBM Plex Sans", "sans-serif", []int{300, 400, 500, 600, 700}, "OFL-1.1", "Helvetica, Arial, sans-serif", "engineered, corporate, trustworthy"},
+	{"Space Grotesk", "sans-serif", []int{300, 400, 500, 600, 700}, "OFL-1.1", "Helvetica, Arial, sans-serif", "technical, distinctive, startup"},
+	{"Outfit", "sans-serif", []int{300, 400, 500, 600, 700, 800}, "OFL-1.1", "Helvetica, Arial, sans-serif", "geometric display-friendly, contemporary"},
+	{"Open Sans", "sans-serif", []int{300, 400, 500, 600, 700, 800}, "OFL-1.1", "Helvetica, Arial, sans-serif", "humanist, universal, very legible"},
+	{"Roboto", "sans-serif", []int{300, 400, 500, 700, 900}, "OFL-1.1", "Helvetica, Arial, sans-serif", "mechanical yet friendly, ubiquitous"},
+	{"Lato", "sans-serif", []int{300, 400, 700, 900}, "OFL-1.1", "Helvetica, Arial, sans-serif", "semi-rounded, serious but warm"},
+	{"Playfair Display", "serif", []int{400, 500, 600, 700, 800, 900}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "high-contrast didone, luxury and editorial"},
+	{"Lora", "serif", []int{400, 500, 600, 700}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "calligraphic serif, literary, warm"},
+	{"Merriweather", "serif", []int{300, 400, 700, 900}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "sturdy screen serif, trustworthy"},
+	{"Libre Baskerville", "serif", []int{400, 700}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "classic transitional, heritage"},
+	{"EB Garamond", "serif", []int{400, 500, 600, 700, 800}, "OFL-1.1", "Garamond, Georgia, serif", "old-style, refined, timeless"},
+	{"DM Serif Display", "serif", []int{400}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "bold display serif, fashion and food"},
+	{"Fraunces", "serif", []int{300, 400, 500, 600, 700, 800, 900}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "soft wonky old-style, characterful"},
+	{"Source Serif 4", "serif", []int{300, 400, 600, 700}, "OFL-1.1", "Georgia, 'Times New Roman', serif", "neutral reading serif"},
+	{"Bebas Neue", "display", []int{400}, "OFL-1.1", "Impact, 'Arial Narrow', sans-serif", "condensed all-caps, bold headlines only"},
+	{"Oswald", "display", []int{300, 400, 500, 600, 700}, "OFL-1.1", "'Arial Narrow', sans-serif", "condensed gothic, sporty and loud"},
+	{"Archivo Black", "display", []int{400}, "OFL-1.1", "Impact, Arial, sans-serif", "heavy grotesque, punchy"},
+	{"Caveat", "handwriting", []int{400, 500, 600, 700}, "OFL-1.1", "'Comic Sans MS', cursive", "casual handwriting, accents only"},
+	{"JetBrains Mono", "monospace", []int{400, 500, 700}, "OFL-1.1", "Menlo, Consolas, monospace", "developer monospace, tech accents"},
+}
+
+// Body fonts must be comfortable at paragraph sizes
+var bodyFontCategories = map[string]bool{"sans-serif": true, "serif": true}
+
+// Modular scale ratios designers recognise, by name
+var typeScaleRatios = []struct {
+	name  string
+	ratio float64
+}{
+	{"major second", 1.125},
+	{"minor third", 1.2},
+	{"major third", 1.25},
+	{"perfect fourth", 1.333},
+	{"augmented fourth", 1.414},
+	{"perfect fifth", 1.5},
+	{"golden ratio", 1.618},
+}
+
+const (
+	typeScaleBasePx   = 16.0
+	defaultScaleRatio = 1.25
+	defaultHeading    = "Montserrat"
+	defaultBody       = "Inter"
+)
+
+// typeScaleSteps are the text styles in the scale, as powers of the ratio from the 16px body size
+var typeScaleSteps = []struct {
+	name       string
+	power      int
+	font       string // heading, body
+	weight     string // heading, body, bold
+	lineHeight float64
+}{
+	{"display", 5, "heading", "heading", 1.1},
+	{"h1", 4, "heading", "heading", 1.15},
+	{"h2", 3, "heading", "heading", 1.2},
+	{"h3", 2, "heading", "heading", 1.25},
+	{"h4", 1, "heading", "heading", 1.3},
+	{"body", 0, "body", "body", 1.5},
+	{"small", -1, "body", "body", 1.5},
+	{"caption", -2, "body", "bold", 1.4},
+}
+
+// fontCataloguePrompt lists the catalogue for Logo-Designer-GPT
+func fontCataloguePrompt() string {
+	lines := make([]string, len(fontCatalogue))
+	for i, f := range fontCatalogue {
+		weights := make([]string, len(f.Weights))
+		for j, w := range f.Weights {
+			weights[j] = fmt.Sprint(w)
+		}
+		lines[i] = fmt.Sprintf("- %s (%s; weights %s) - %s", f.Family, f.Category, strings.Join(weights, "/"), f.Traits)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findFont looks a family up in the catalogue, ignoring case and surrounding whitespace
+func findFont(family string) (fontFamily, bool) {
+	family = strings.TrimSpace(family)
+	for _, f := range fontCatalogue {
+		if strings.EqualFold(f.Family, family) {
+			return f, true
+		}
+	}
+	return fontFamily{}, false
+}
+
+// BuildTypography validates Logo-Designer-GPT's typography choice against the font catalogue.
+// Unknown fonts, unsuitable body fonts and unavailable weights are replaced with safe defaults,
+// and the type scale is computed from the chosen ratio.
+func BuildTypography(choice bezzmodels.TypographyChoice) *bezzmodels.Typography {
+	heading, ok := findFont(choice.HeadingFont)
+	if !ok {
+		if choice.HeadingFont != "" {
+			log.Printf("⚠️ AI PIPELINE: Heading font %q is not in the catalogue, using %s", choice.HeadingFont, defaultHeading)
+		}
+		heading, _ = findFont(defaultHeading)
+	}
+
+	body, ok := findFont(choice.BodyFont)
+	if !ok || !bodyFontCategories[body.Category] {
+		if choice.BodyFont != "" {
+			log.Printf("⚠️ AI PIPELINE: Body font %q is not a catalogue text face, using %s", choice.BodyFont, defaultBody)
+		}
+		body, _ = findFont(defaultBody)
+	}
+
+	headingWeights := availableWeights(heading, choice.HeadingWeights, []int{700})
+	bodyWeights := availableWeights(body, choice.BodyWeights, []int{400, 700})
+
+	ratioName, ratio := nearestScaleRatio(choice.ScaleRatio)
+	typography := &bezzmodels.Typography{
+		Heading:    fontSelection(heading, headingWeights),
+		Body:       fontSelection(body, bodyWeights),
+		ScaleRatio: ratio,
+		ScaleName:  ratioName,
+		Rationale:  strings.TrimSpace(choice.Rationale),
+	}
+
+	for _, step := range typeScaleSteps {
+		family, weight := body.Family, bodyWeights[0]
+		if step.font == "heading" {
+			family = heading.Family
+		}
+		switch step.weight {
+		case "heading":
+			weight = headingWeights[len(headingWeights)-1]
+		case "bold":
+			weight = bodyWeights[len(bodyWeights)-1]
+		}
+		size := typeScaleBasePx * math.Pow(ratio, float64(step.power))
+		typography.Scale = append(typography.Scale, bezzmodels.TypeScaleStep{
+			Name:       step.name,
+			Family:     family,
+			Weight:     weight,
+			SizePx:     math.Round(size),
+			SizeRem:    roundTo(size/typeScaleBasePx, 3),
+			LineHeight: step.lineHeight,
+		})
+	}
+
+	for _, rule := range choice.UsageRules {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			typography.UsageRules = append(typography.UsageRules, rule)
+		}
+	}
+	if len(typography.UsageRules) == 0 {
+		typography.UsageRules = []string{
+			fmt.Sprintf("Use %s for headlines and %s for body copy; don't introduce other typefaces.", heading.Family, body.Family),
+			"Keep body text at 16px or larger on screen and lines to 60-75 characters.",
+			"Use sentence case for headlines; reserve all caps for short labels.",
+		}
+	}
+	return typography
+}
+
+// availableWeights keeps the requested weights the font actually ships, sorted, or falls back
+// to the nearest available versions of the defaults
+func availableWeights(font fontFamily, requested, defaults []int) []int {
+	var weights []int
+	for _, w := range requested {
+		for _, available := range font.Weights {
+			if w == available {
+				weights = append(weights, w)
+				break
+			}
+		}
+	}
+	if len(weights) == 0 {
+		for _, w := range defaults {
+			weights = append(weights, nearestWeight(font.Weights, w))
+		}
+	}
+
+	sort.Ints(weights)
+	unique := weights[:0]
+	for i, w := range weights {
+		if i == 0 || w != weights[i-1] {
+			unique = append(unique, w)
+		}
+	}
+	return unique
+}
+
+// nearestWeight returns the available weight closest to want
+func nearestWeight(available []int, want int) int {
+	best := available[0]
+	for _, w := range available {
+		if math.Abs(float64(w-want)) < math.Abs(float64(best-want)) {
+			best = w
+		}
+	}
+	return best
+}
+
+// nearestScaleRatio snaps a ratio to the closest named modular scale
+func nearestScaleRatio(ratio float64) (string, float64) {
+	if ratio <= 1 {
+		ratio = defaultScaleRatio
+	}
+	best := typeScaleRatios[0]
+	for _, r := range typeScaleRatios {
+		if math.Abs(r.ratio-ratio) < math.Abs(best.ratio-ratio) {
+			best = r
+		}
+	}
+	return best.name, best.ratio
+}
+
+// fontSelection describes a catalogue font with the weights the brand uses
+func fontSelection(font fontFamily, weights []int) bezzmodels.FontSelection {
+	return bezzmodels.FontSelection{
+		Family:    font.Family,
+		Category:  font.Category,
+		Weights:   weights,
+		License:   font.License,
+		SourceURL: "https://fonts.google.com/specimen/" + strings.ReplaceAll(font.Family, " ", "+"),
+		Fallback:  font.Fallback,
+	}
+}
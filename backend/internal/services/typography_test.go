@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+	"bezz-backend/internal/prompts"
+)
+
+func TestBuildTypography_UsesCatalogueFonts(t *testing.T) {
+	typography := BuildTypography(models.TypographyChoice{
+		HeadingFont:    "playfair display",
+		HeadingWeights: []int{700, 900, 950},
+		BodyFont:       "Source Sans 3",
+		BodyWeights:    []int{400},
+		ScaleRatio:     1.3,
+		UsageRules:     []string{"  Headlines in sentence case ", ""},
+		Rationale:      "Editorial elegance with a calm reading face",
+	})
+
+	assert.Equal(t, "Playfair Display", typography.Heading.Family)
+	assert.Equal(t, []int{700, 900}, typography.Heading.Weights)
+	assert.Equal(t, "OFL-1.1", typography.Heading.License)
+	assert.Equal(t, "https://fonts.google.com/specimen/Playfair+Display", typography.Heading.SourceURL)
+	assert.Equal(t, "Source Sans 3", typography.Body.Family)
+	assert.Equal(t, []int{400}, typography.Body.Weights)
+
+	assert.Equal(t, "perfect fourth", typography.ScaleName)
+	assert.Equal(t, 1.333, typography.ScaleRatio)
+	assert.Equal(t, []string{"Headlines in sentence case"}, typography.UsageRules)
+
+	require.Len(t, typography.Scale, len(typeScaleSteps))
+	h1 := typography.Scale[1]
+	assert.Equal(t, "h1", h1.Name)
+	assert.Equal(t, "Playfair Display", h1.Family)
+	assert.Equal(t, 900, h1.Weight)
+	assert.Equal(t, 51.0, h1.SizePx)
+	body := typography.Scale[5]
+	assert.Equal(t, "body", body.Name)
+	assert.Equal(t, 16.0, body.SizePx)
+	assert.Equal(t, 1.0, body.SizeRem)
+}
+
+func TestBuildTypography_ReplacesInvalidChoices(t *testing.T) {
+	typography := BuildTypography(models.TypographyChoice{
+		HeadingFont: "Helvetica Neue",
+		BodyFont:    "Bebas Neue", // display face, unsuitable for paragraphs
+	})
+
+	assert.Equal(t, defaultHeading, typography.Heading.Family)
+	assert.Equal(t, []int{700}, typography.Heading.Weights)
+	assert.Equal(t, defaultBody, typography.Body.Family)
+	assert.Equal(t, []int{400, 700}, typography.Body.Weights)
+	assert.Equal(t, defaultScaleRatio, typography.ScaleRatio)
+	assert.NotEmpty(t, typography.UsageRules)
+}
+
+func TestAvailableWeights_FallsBackToNearest(t *testing.T) {
+	font, ok := findFont("DM Serif Display")
+	require.True(t, ok)
+	assert.Equal(t, []int{400}, availableWeights(font, []int{700}, []int{700}))
+}
+
+func TestLogoDesignerPrompt_ListsFontCatalogue(t *testing.T) {
+	prompt := fmt.Sprintf(prompts.LogoDesignerGPTPrompt, "Acme", "Retail", "positioning", "value", "pillars", "audience", "tagline", "none", fontCataloguePrompt())
+	assert.NotContains(t, prompt, "%!")
+	assert.Contains(t, prompt, "- Inter (sans-serif; weights 300/400/500/600/700/800)")
+	assert.True(t, strings.Contains(prompt, `"heading_font"`))
+}
@@ -0,0 +1,591 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+
+	bezzmodels "bezz-backend/internal/models"
+	"bezz-backend/internal/prompts"
+)
+
+// videoFormat is a storyboard length with the frame it is composed for
+type videoFormat struct {
+	duration    int // seconds
+	aspectRatio string
+	imageSize   imageSize
+	minScenes   int
+	maxScenes   int
+	usage       string
+}
+
+// Storyboard lengths - short cuts are vertical for Reels, Stories, TikTok and Shorts, the long cut is widescreen
+var videoFormats = map[int]videoFormat{
+	15: {duration: 15, aspectRatio: "9:16", imageSize: imageSizePortrait, minScenes: 3, maxScenes: 5, usage: "Reels, Stories, TikTok and Shorts"},
+	30: {duration: 30, aspectRatio: "9:16", imageSize: imageSizePortrait, minScenes: 5, maxScenes: 8, usage: "in-feed video and Stories"},
+	60: {duration: 60, aspectRatio: "16:9", imageSize: imageSizeLandscape, minScenes: 8, maxScenes: 12, usage: "YouTube, connected TV and website hero video"},
+}
+
+// videoPlanDurations lists the storyboard lengths each subscription plan includes; starter has none
+var videoPlanDurations = map[string][]int{
+	"pro":        {15, 30},
+	"enterprise": {15, 30, 60},
+}
+
+const (
+	voiceoverWordsPerSecond = 2.5
+	keyframeConcurrency     = 4
+	srtMaxLineChars         = 42 // common broadcast subtitle limit per line
+	srtMaxCueLines          = 2
+)
+
+// ValidateVideoDurations checks that every requested storyboard length is supported
+func ValidateVideoDurations(durations []int) error {
+	for _, d := range durations {
+		if _, ok := videoFormats[d]; !ok {
+			return fmt.Errorf("unsupported video duration: %ds (choose 15, 30 or 60)", d)
+		}
+	}
+	return nil
+}
+
+// CheckVideoPlan reports an error when the user's plan does not include every requested storyboard length
+func CheckVideoPlan(user *bezzmodels.User, durations []int) error {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	plan := activePlan(user)
+	allowed := make(map[int]bool)
+	for _, d := range videoPlanDurations[plan] {
+		allowed[d] = true
+	}
+
+	for _, d := range durations {
+		if !allowed[d] {
+			if len(allowed) == 0 {
+				return fmt.Errorf("video storyboards are available on the Pro and Enterprise plans")
+			}
+			return fmt.Errorf("%ds video storyboards are not included in the %s plan", d, plan)
+		}
+	}
+	return nil
+}
+
+// activePlan returns the user's plan while the subscription is active, or "" otherwise
+func activePlan(user *bezzmodels.User) string {
+	if user == nil || user.Subscription == nil || user.Subscription.Status != "active" {
+		return ""
+	}
+	return user.Subscription.Plan
+}
+
+// resolveVideoFormats returns the formats for the given lengths, de-duplicated and shortest first
+func resolveVideoFormats(durations []int) []videoFormat {
+	seen := make(map[int]bool, len(durations))
+	var formats []videoFormat
+	for _, d := range durations {
+		format, ok := videoFormats[d]
+		if !ok || seen[d] {
+			continue
+		}
+		seen[d] = true
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i].duration < formats[j].duration })
+	return formats
+}
+
+// videoPromptContext lists the requested videos with their frame, scene count and voiceover budget
+func videoPromptContext(formats []videoFormat) string {
+	var b strings.Builder
+	for _, f := range formats {
+		fmt.Fprintf(&b, "- %ds video, %s aspect ratio, for %s: %d-%d scenes, at most %d voiceover words in total\n",
+			f.duration, f.aspectRatio, f.usage, f.minScenes, f.maxScenes, int(float64(f.duration)*voiceoverWordsPerSecond))
+	}
+	return b.String()
+}
+
+// GenerateVideoStoryboards calls Video-Director-GPT for the requested lengths, then renders a keyframe per scene,
+// an SRT subtitle file and a contact sheet for each video. Keyframe failures are tolerated.
+func (s *AIService) GenerateVideoStoryboards(ctx context.Context, strategy *bezzmodels.BrandStrategy, identity *bezzmodels.BrandIdentity, companyName string, sector *bezzmodels.Sector, style string, durations []int) ([]bezzmodels.VideoAd, []bezzmodels.ModerationFlag, error) {
+	formats := resolveVideoFormats(durations)
+	if len(formats) == 0 {
+		return nil, nil, nil
+	}
+	log.Printf("🎬 AI PIPELINE: Starting Video-Director-GPT for %d storyboards", len(formats))
+
+	strategyJSON, err := json.Marshal(strategy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal strategy: %w", err)
+	}
+
+	identityJSON := "{}"
+	var palette []bezzmodels.Color
+	if identity != nil {
+		palette = identity.ColorPalette
+		if b, err := json.Marshal(struct {
+			LogoConcept  string             `json:"logoConcept"`
+			ColorPalette []bezzmodels.Color `json:"colorPalette"`
+		}{identity.LogoConcept, identity.ColorPalette}); err == nil {
+			identityJSON = string(b)
+		}
+	}
+
+	preset := stylePresetFor(style)
+	prompt := fmt.Sprintf(prompts.VideoDirectorGPTPrompt, string(strategyJSON), identityJSON, preset.promptGuidance(), videoPromptContext(formats))
+
+	temperature := float64(0.7)
+
+	var response bezzmodels.VideoDirectorGPTResponse
+	modelUsed, content, err := s.chatJSONWithFallback(ctx, []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage("You are Video-Director-GPT, an expert at storyboarding short video ads. Always respond with valid JSON only."),
+		openai.UserMessage(prompt),
+	}, 4000, &temperature, &response)
+	if err != nil {
+		log.Printf("❌ AI PIPELINE: Video-Director-GPT API call failed: %v", err)
+		return nil, nil, fmt.Errorf("Video-Director-GPT API call failed: %w", err)
+	}
+
+	log.Printf("🎬 AI PIPELINE: Video-Director-GPT raw response: %s", content)
+
+	videos := make([]bezzmodels.VideoAd, 0, len(formats))
+	var flags []bezzmodels.ModerationFlag
+	for i, format := range formats {
+		spec, ok := videoSpecFor(response.Videos, format.duration, i)
+		if !ok {
+			log.Printf("⚠️ AI PIPELINE: Video-Director-GPT returned no %ds script, skipping", format.duration)
+			continue
+		}
+		spec.Duration = format.duration
+
+		// Check the script before any keyframe, voiceover or animatic is rendered from it
+		spec, specFlags := s.moderateVideoScript(ctx, spec)
+		flags = append(flags, specFlags...)
+
+		video := buildVideoAd(spec, format)
+		if len(video.Scenes) == 0 {
+			log.Printf("⚠️ AI PIPELINE: %ds script has no usable scenes, skipping", format.duration)
+			continue
+		}
+		s.renderStoryboard(ctx, &video, format, preset, sector, companyName, palette, strategy.TonalGuidelines)
+		videos = append(videos, video)
+	}
+
+	log.Printf("✅ AI PIPELINE: Generated %d video storyboards using %s", len(videos), modelUsed)
+	return videos, flags, nil
+}
+
+// videoSpecFor finds the script for a duration, falling back to the script at the same position
+func videoSpecFor(specs []bezzmodels.VideoScriptSpec, duration, index int) (bezzmodels.VideoScriptSpec, bool) {
+	for _, spec := range specs {
+		if spec.Duration == duration {
+			return spec, true
+		}
+	}
+	if index < len(specs) && specs[index].Duration == 0 {
+		return specs[index], true
+	}
+	return bezzmodels.VideoScriptSpec{}, false
+}
+
+// buildVideoAd turns a script into a video ad whose scene timings add up exactly to the format's duration
+func buildVideoAd(spec bezzmodels.VideoScriptSpec, format videoFormat) bezzmodels.VideoAd {
+	var scenes []bezzmodels.VideoSceneSpec
+	for _, scene := range spec.Scenes {
+		if strings.TrimSpace(scene.VisualPrompt) == "" && strings.TrimSpace(scene.Voiceover) == "" {
+			continue
+		}
+		scenes = append(scenes, scene)
+	}
+	// Keep the closing call-to-action scene when trimming an over-long script
+	if len(scenes) > format.maxScenes {
+		scenes = append(scenes[:format.maxScenes-1], scenes[len(scenes)-1])
+	}
+
+	weights := make([]int, len(scenes))
+	for i, scene := range scenes {
+		weights[i] = scene.Duration
+	}
+	timings := normalizeSceneDurations(weights, format.duration)
+
+	title := strings.TrimSpace(spec.Title)
+	if title == "" {
+		title = fmt.Sprintf("%d-second spot", format.duration)
+	}
+
+	video := bezzmodels.VideoAd{
+		ID:          fmt.Sprintf("video_%ds", format.duration),
+		Title:       title,
+		Duration:    format.duration,
+		AspectRatio: format.aspectRatio,
+	}
+
+	var voiceover []string
+	start := 0
+	for i, scene := range scenes {
+		video.Scenes = append(video.Scenes, bezzmodels.VideoScene{
+			ID:           fmt.Sprintf("scene_%d", i+1),
+			StartTime:    start,
+			Duration:     timings[i],
+			Description:  strings.TrimSpace(scene.Description),
+			VisualPrompt: strings.TrimSpace(scene.VisualPrompt),
+			Voiceover:    strings.TrimSpace(scene.Voiceover),
+			OnScreenText: strings.TrimSpace(scene.OnScreenText),
+		})
+		start += timings[i]
+		if line := strings.TrimSpace(scene.Voiceover); line != "" {
+			voiceover = append(voiceover, line)
+		}
+	}
+	video.Script = strings.Join(voiceover, " ")
+	return video
+}
+
+// normalizeSceneDurations scales the requested scene lengths to whole seconds summing to total,
+// giving every scene at least one second. Lengths that already add up are kept as written.
+func normalizeSceneDurations(requested []int, total int) []int {
+	n := len(requested)
+	if n == 0 {
+		return nil
+	}
+
+	sum, valid := 0, true
+	weights := make([]float64, n)
+	for i, d := range requested {
+		if d < 1 {
+			valid = false
+			d = 1
+		}
+		sum += d
+		weights[i] = float64(d)
+	}
+	if valid && sum == total {
+		return append([]int(nil), requested...)
+	}
+
+	// Largest-remainder apportionment
+	durations := make([]int, n)
+	remainders := make([]float64, n)
+	assigned := 0
+	for i, w := range weights {
+		exact := float64(total) * w / float64(sum)
+		durations[i] = int(exact)
+		remainders[i] = exact - float64(durations[i])
+		assigned += durations[i]
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for i := 0; assigned < total; i = (i + 1) % n {
+		durations[order[i]]++
+		assigned++
+	}
+
+	// Scenes rounded down to nothing borrow a second from the longest scene
+	for i := range durations {
+		if durations[i] > 0 {
+			continue
+		}
+		longest := 0
+		for j := range durations {
+			if durations[j] > durations[longest] {
+				longest = j
+			}
+		}
+		if durations[longest] <= 1 {
+			break
+		}
+		durations[longest]--
+		durations[i] = 1
+	}
+	return durations
+}
+
+// renderStoryboard renders the keyframes, voiceover, subtitles, contact sheet and animatic for a video and stores
+// them in GCS. Voiceover comes before subtitles because fitting the audio can move scene timings.
+func (s *AIService) renderStoryboard(ctx context.Context, video *bezzmodels.VideoAd, format videoFormat, preset StylePreset, sector *bezzmodels.Sector, companyName string, palette []bezzmodels.Color, tone bezzmodels.TonalGuidelines) {
+	baseName := fmt.Sprintf("videos/%s_%ds_%d", companyName, format.duration, time.Now().Unix())
+
+	frames := s.renderKeyframes(ctx, video, format, preset, sector, baseName)
+	audio := s.renderVoiceover(ctx, video, baseName, tone)
+
+	subtitles := BuildSRT(video.Scenes)
+	srtName := baseName + "_subtitles"
+	if srtURL, err := s.uploadBytesToGCS(ctx, subtitles, srtName+".srt", "application/x-subrip"); err != nil {
+		log.Printf("⚠️ AI PIPELINE: Uploading subtitles for %s failed: %v", video.ID, err)
+	} else {
+		video.SubtitlesURL = srtURL
+		video.SubtitlesObjectName = srtName
+	}
+
+	s.storeContactSheet(ctx, video, frames, companyName, palette, baseName)
+	s.renderAnimatic(ctx, video, frames, audio, subtitles, palette, baseName)
+}
+
+// storeContactSheet renders the storyboard contact sheet and stores it in GCS
+func (s *AIService) storeContactSheet(ctx context.Context, video *bezzmodels.VideoAd, frames []image.Image, companyName string, palette []bezzmodels.Color, baseName string) {
+	sheet, err := RenderContactSheet(*video, frames, companyName, palette)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Rendering contact sheet for %s failed: %v", video.ID, err)
+		return
+	}
+	data, err := encodePNG(sheet)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: %v", err)
+		return
+	}
+	sheetName := baseName + "_storyboard"
+	sheetURL, err := s.uploadImageBytesToGCS(ctx, data, sheetName)
+	if err != nil {
+		log.Printf("⚠️ AI PIPELINE: Uploading contact sheet for %s failed: %v", video.ID, err)
+		return
+	}
+	video.StoryboardURL = sheetURL
+	video.StoryboardObjectName = sheetName
+}
+
+// renderKeyframes generates one image per scene and returns the decoded frames (nil where generation failed)
+func (s *AIService) renderKeyframes(ctx context.Context, video *bezzmodels.VideoAd, format videoFormat, preset StylePreset, sector *bezzmodels.Sector, baseName string) []image.Image {
+	frames := make([]image.Image, len(video.Scenes))
+	sem := make(chan struct{}, keyframeConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range video.Scenes {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			scene := &video.Scenes[index]
+			imageURL, err := s.generateImage(ctx, preset.apply(scene.VisualPrompt, sector), format.imageSize)
+			if err != nil {
+				log.Printf("⚠️ AI PIPELINE: Keyframe for %s %s failed: %v", video.ID, scene.ID, err)
+				return
+			}
+
+			objectName := fmt.Sprintf("%s_%s", baseName, scene.ID)
+			gcsURL, err := s.uploadImageToGCS(ctx, imageURL, objectName)
+			if err != nil {
+				log.Printf("⚠️ AI PIPELINE: GCS upload failed for %s %s, using direct URL: %v", video.ID, scene.ID, err)
+				scene.KeyframeURL = imageURL
+				return
+			}
+			scene.KeyframeURL = gcsURL
+			scene.KeyframeObjectName = objectName
+
+			if frame, err := s.readImageFromGCS(ctx, objectName+".png"); err != nil {
+				log.Printf("⚠️ AI PIPELINE: Could not reload keyframe %s for the contact sheet: %v", objectName, err)
+			} else {
+				frames[index] = frame
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	rendered := 0
+	for _, scene := range video.Scenes {
+		if scene.KeyframeURL != "" {
+			rendered++
+		}
+	}
+	log.Printf("🎬 AI PIPELINE: Rendered %d/%d keyframes for %s", rendered, len(video.Scenes), video.ID)
+	return frames
+}
+
+// BuildSRT writes the scenes' voiceover as SubRip subtitles, splitting long lines into timed cues
+func BuildSRT(scenes []bezzmodels.VideoScene) []byte {
+	var b strings.Builder
+	cue := 1
+	for _, scene := range scenes {
+		chunks := subtitleChunks(scene.Voiceover)
+		if len(chunks) == 0 {
+			continue
+		}
+
+		// Share the scene's time between its cues in proportion to their length
+		total := 0
+		for _, chunk := range chunks {
+			total += len(chunk)
+		}
+		start := scene.StartTime * 1000
+		end := (scene.StartTime + scene.Duration) * 1000
+		elapsed := 0
+		for i, chunk := range chunks {
+			from := start + (end-start)*elapsed/total
+			elapsed += len(chunk)
+			to := start + (end-start)*elapsed/total
+			if i == len(chunks)-1 {
+				to = end
+			}
+			fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", cue, srtTimestamp(from), srtTimestamp(to), strings.Join(subtitleLines(chunk), "\n"))
+			cue++
+		}
+	}
+	return []byte(b.String())
+}
+
+// subtitleChunks splits a voiceover line into cues that fit on two subtitle lines
+func subtitleChunks(text string) []string {
+	maxChars := srtMaxLineChars * srtMaxCueLines
+	var chunks []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if len(candidate) <= maxChars || current == "" {
+			current = candidate
+			continue
+		}
+		chunks = append(chunks, current)
+		current = word
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// subtitleLines wraps a cue onto lines of at most srtMaxLineChars
+func subtitleLines(cue string) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(cue) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if len(candidate) <= srtMaxLineChars || current == "" {
+			current = candidate
+			continue
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// srtTimestamp formats milliseconds as HH:MM:SS,mmm
+func srtTimestamp(ms int) string {
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// timecode formats seconds as M:SS for storyboard labels
+func timecode(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+const (
+	contactSheetWidth = 2000
+	contactSheetPad   = 40
+)
+
+// RenderContactSheet lays out a video's keyframes in a grid with each scene's timing, voiceover and on-screen text.
+// frames is indexed like video.Scenes; missing frames are drawn as a placeholder with the scene description.
+func RenderContactSheet(video bezzmodels.VideoAd, frames []image.Image, companyName string, palette []bezzmodels.Color) (*image.RGBA, error) {
+	if len(video.Scenes) == 0 {
+		return nil, fmt.Errorf("video %s has no scenes", video.ID)
+	}
+	fonts, err := loadCreativeFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	widescreen := video.AspectRatio == "16:9"
+	columns := 4
+	if widescreen {
+		columns = 3
+	}
+	rows := (len(video.Scenes) + columns - 1) / columns
+
+	pad := contactSheetPad
+	cellW := (contactSheetWidth - pad*(columns+1)) / columns
+	frameH := cellW * 16 / 9
+	if widescreen {
+		frameH = cellW * 9 / 16
+	}
+	captionH := 230
+	headerH := 160
+	height := headerH + rows*(frameH+captionH+pad) + pad
+
+	primary := paletteColor(palette, "primary", color.RGBA{R: 0x1f, G: 0x29, B: 0x37, A: 0xff})
+	c := &creativeCanvas{
+		img:     image.NewRGBA(image.Rect(0, 0, contactSheetWidth, height)),
+		fonts:   fonts,
+		primary: primary,
+		accent:  paletteColor(palette, "accent", primary),
+		unit:    float64(contactSheetWidth),
+	}
+	defer c.closeFaces()
+	c.fill(c.img.Bounds(), color.White)
+
+	// Header band with the company, title and format
+	header := image.Rect(0, 0, contactSheetWidth, headerH)
+	c.fill(header, primary)
+	headerText := readableTextColor(primary)
+	c.drawText(fmt.Sprintf("%s — %s", companyName, video.Title), image.Rect(pad, 30, contactSheetWidth-pad, 100), c.face(fonts.bold, 0.024), headerText, 1)
+	c.drawText(fmt.Sprintf("%ds · %s · %d scenes", video.Duration, video.AspectRatio, len(video.Scenes)), image.Rect(pad, 100, contactSheetWidth-pad, headerH), c.face(fonts.regular, 0.014), headerText, 1)
+
+	labelFace := c.face(fonts.bold, 0.011)
+	bodyFace := c.face(fonts.regular, 0.0095)
+	muted := color.RGBA{R: 0x4b, G: 0x55, B: 0x63, A: 0xff}
+	for i, scene := range video.Scenes {
+		col, row := i%columns, i/columns
+		x := pad + col*(cellW+pad)
+		y := headerH + pad + row*(frameH+captionH+pad)
+		frameRect := image.Rect(x, y, x+cellW, y+frameH)
+
+		var frame image.Image
+		if i < len(frames) {
+			frame = frames[i]
+		}
+		if frame != nil {
+			drawImageCover(c.img, frameRect, frame)
+		} else {
+			c.fill(frameRect, withAlpha(primary, 0x30))
+			inset := image.Rect(frameRect.Min.X+20, frameRect.Min.Y+20, frameRect.Max.X-20, frameRect.Max.Y-20)
+			c.drawText(scene.Description, inset, bodyFace, muted, 12)
+		}
+		outlineRect(c.img, frameRect, color.RGBA{R: 0xd1, G: 0xd5, B: 0xdb, A: 0xff})
+
+		caption := image.Rect(x, frameRect.Max.Y+14, x+cellW, frameRect.Max.Y+captionH)
+		label := fmt.Sprintf("%d  ·  %s–%s", i+1, timecode(scene.StartTime), timecode(scene.StartTime+scene.Duration))
+		next := c.drawText(label, caption, labelFace, primary, 1)
+		if scene.Voiceover != "" {
+			next = c.drawText("VO: "+scene.Voiceover, image.Rect(x, next+6, x+cellW, caption.Max.Y), bodyFace, color.Black, 5)
+		}
+		if scene.OnScreenText != "" {
+			c.drawText("TEXT: "+scene.OnScreenText, image.Rect(x, next+6, x+cellW, caption.Max.Y), bodyFace, muted, 1)
+		}
+	}
+	return c.img, nil
+}
+
+// outlineRect draws a one-pixel border around r
+func outlineRect(img *image.RGBA, r image.Rectangle, col color.Color) {
+	for x := r.Min.X; x < r.Max.X; x++ {
+		img.Set(x, r.Min.Y, col)
+		img.Set(x, r.Max.Y-1, col)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		img.Set(r.Min.X, y, col)
+		img.Set(r.Max.X-1, y, col)
+	}
+}
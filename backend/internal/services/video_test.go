@@ -0,0 +1,132 @@
+package services
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bezz-backend/internal/models"
+)
+
+func TestNormalizeSceneDurations(t *testing.T) {
+	cases := []struct {
+		name      string
+		requested []int
+		total     int
+		want      []int
+	}{
+		{"already exact", []int{2, 8, 5}, 15, []int{2, 8, 5}},
+		{"scaled up", []int{3, 3, 4}, 30, []int{9, 9, 12}},
+		{"scaled down", []int{10, 10, 10}, 15, []int{5, 5, 5}},
+		{"missing lengths", []int{0, 0, 0, 0}, 15, []int{4, 4, 4, 3}},
+		{"tiny scene keeps a second", []int{1, 60}, 15, []int{1, 14}},
+	}
+	for _, tc := range cases {
+		got := normalizeSceneDurations(tc.requested, tc.total)
+		assert.Equal(t, tc.want, got, tc.name)
+
+		sum := 0
+		for _, d := range got {
+			sum += d
+			assert.GreaterOrEqual(t, d, 1, tc.name)
+		}
+		assert.Equal(t, tc.total, sum, tc.name)
+	}
+}
+
+func TestBuildVideoAd_TimesScenesAndKeepsClosingScene(t *testing.T) {
+	spec := models.VideoScriptSpec{Duration: 15, Title: "Morning rush"}
+	for i := 0; i < 7; i++ {
+		spec.Scenes = append(spec.Scenes, models.VideoSceneSpec{Duration: 3, VisualPrompt: "scene", Voiceover: strings.Repeat("x", i+1)})
+	}
+	spec.Scenes = append(spec.Scenes, models.VideoSceneSpec{Duration: 2}) // empty scene is dropped
+
+	video := buildVideoAd(spec, videoFormats[15])
+	require.Len(t, video.Scenes, videoFormats[15].maxScenes)
+	assert.Equal(t, "video_15s", video.ID)
+	assert.Equal(t, "9:16", video.AspectRatio)
+	assert.Equal(t, "xxxxxxx", video.Scenes[len(video.Scenes)-1].Voiceover, "the call-to-action scene survives trimming")
+
+	end := 0
+	for i, scene := range video.Scenes {
+		assert.Equal(t, end, scene.StartTime, "scene %d", i)
+		end += scene.Duration
+	}
+	assert.Equal(t, 15, end)
+	assert.Equal(t, "x xx xxx xxxx xxxxxxx", video.Script)
+}
+
+func TestBuildSRT(t *testing.T) {
+	scenes := []models.VideoScene{
+		{StartTime: 0, Duration: 4, Voiceover: "Mornings are chaos."},
+		{StartTime: 4, Duration: 3}, // silent scene has no cue
+		{StartTime: 7, Duration: 8, Voiceover: "Bezz Coffee brews your perfect cup before the alarm even rings, so every morning starts calm, warm and on time."},
+	}
+
+	srt := string(BuildSRT(scenes))
+	cues := strings.Split(strings.TrimSpace(srt), "\n\n")
+	require.Len(t, cues, 3, srt)
+
+	assert.Equal(t, "1\n00:00:00,000 --> 00:00:04,000\nMornings are chaos.", cues[0])
+	assert.True(t, strings.HasPrefix(cues[1], "2\n00:00:07,000 --> "), cues[1])
+	assert.True(t, strings.HasSuffix(strings.Split(cues[2], "\n")[1], " --> 00:00:15,000"), cues[2])
+
+	for _, cue := range cues {
+		lines := strings.Split(cue, "\n")[2:]
+		assert.LessOrEqual(t, len(lines), srtMaxCueLines)
+		for _, line := range lines {
+			assert.LessOrEqual(t, len(line), srtMaxLineChars, line)
+		}
+	}
+}
+
+func TestSRTTimestamp(t *testing.T) {
+	assert.Equal(t, "00:00:00,000", srtTimestamp(0))
+	assert.Equal(t, "00:01:05,250", srtTimestamp(65250))
+	assert.Equal(t, "01:00:00,001", srtTimestamp(3600001))
+}
+
+func TestCheckVideoPlan(t *testing.T) {
+	user := func(plan, status string) *models.User {
+		return &models.User{Subscription: &models.Subscription{Plan: plan, Status: status}}
+	}
+
+	assert.NoError(t, CheckVideoPlan(&models.User{}, nil), "no video requested")
+	assert.Error(t, CheckVideoPlan(&models.User{}, []int{15}), "no subscription")
+	assert.Error(t, CheckVideoPlan(user("starter", "active"), []int{15}))
+	assert.NoError(t, CheckVideoPlan(user("pro", "active"), []int{15, 30}))
+	assert.Error(t, CheckVideoPlan(user("pro", "active"), []int{60}))
+	assert.Error(t, CheckVideoPlan(user("enterprise", "past_due"), []int{15}), "inactive subscription")
+	assert.NoError(t, CheckVideoPlan(user("enterprise", "active"), []int{15, 30, 60}))
+
+	assert.NoError(t, ValidateVideoDurations([]int{15, 30, 60}))
+	assert.Error(t, ValidateVideoDurations([]int{45}))
+}
+
+func TestRenderContactSheet_DrawsPlaceholdersForMissingFrames(t *testing.T) {
+	video := buildVideoAd(models.VideoScriptSpec{
+		Duration: 60,
+		Title:    "Launch film",
+		Scenes: []models.VideoSceneSpec{
+			{Duration: 20, Description: "Wide shot of the city at dawn", VisualPrompt: "city", Voiceover: "Every city wakes up."},
+			{Duration: 20, Description: "Close-up of the product", VisualPrompt: "product", OnScreenText: "Meet Bezz"},
+			{Duration: 20, Description: "Logo end card", VisualPrompt: "end card", Voiceover: "Bezz. Start calm."},
+			{Duration: 20, Description: "Extra scene on a second row", VisualPrompt: "extra"},
+		},
+	}, videoFormats[60])
+
+	frame := image.NewRGBA(image.Rect(0, 0, 1536, 1024))
+	sheet, err := RenderContactSheet(video, []image.Image{frame, nil, frame}, "Bezz", []models.Color{{Hex: "#0A2540", Usage: "primary"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, contactSheetWidth, sheet.Bounds().Dx())
+	// 16:9 storyboards use three columns, so four scenes need two rows
+	cellW := (contactSheetWidth - contactSheetPad*4) / 3
+	assert.Greater(t, sheet.Bounds().Dy(), 2*cellW*9/16)
+
+	_, err = RenderContactSheet(models.VideoAd{ID: "empty"}, nil, "Bezz", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	bezzmodels "bezz-backend/internal/models"
+)
+
+// voiceoverPadding is the breathing room left after a line before the next scene starts
+const voiceoverPadding = 400 * time.Millisecond
+
+// renderVoiceover synthesises each scene's voiceover in the brand voice, fits the scene timings to the audio
+// and stores the clips in GCS. Scenes whose synthesis fails stay silent. The clips are returned, indexed like
+// the scenes, for the animatic.
+func (s *AIService) renderVoiceover(ctx context.Context, video *bezzmodels.VideoAd, baseName string, tone bezzmodels.TonalGuidelines) []*SpeechAudio {
+	if s.speech == nil {
+		return nil
+	}
+
+	voice, instructions := speechVoiceFor(tone.Voice, tone.Personality)
+	audio := synthesizeVoiceover(ctx, s.speech, video.Scenes, voice, instructions)
+
+	if runtime := reconcileSceneTimings(video.Scenes, audio); runtime != video.Duration {
+		log.Printf("⚠️ AI PIPELINE: Voiceover for %s does not fit %ds, runtime is now %ds", video.ID, video.Duration, runtime)
+		video.Duration = runtime
+	}
+	video.Voice = voice
+
+	stored := 0
+	for i, clip := range audio {
+		if clip == nil {
+			continue
+		}
+		scene := &video.Scenes[i]
+		objectName := fmt.Sprintf("%s_%s_voiceover", baseName, scene.ID)
+		audioURL, err := s.uploadBytesToGCS(ctx, clip.Data, objectName+".wav", speechContentType)
+		if err != nil {
+			log.Printf("⚠️ AI PIPELINE: Uploading voiceover for %s %s failed: %v", video.ID, scene.ID, err)
+			continue
+		}
+		scene.VoiceoverURL = audioURL
+		scene.VoiceoverObjectName = objectName
+		scene.VoiceoverDuration = math.Round(clip.Duration.Seconds()*100) / 100
+		stored++
+	}
+	log.Printf("🎙️ AI PIPELINE: Stored %d voiceover clips for %s with %s voice %q", stored, video.ID, s.speech.Name(), voice)
+	return audio
+}
+
+// synthesizeVoiceover renders every scene's voiceover line, re-reading lines that overrun their scene once at a
+// faster pace. The result is indexed like scenes, with nil for silent scenes and failed lines.
+func synthesizeVoiceover(ctx context.Context, provider SpeechProvider, scenes []bezzmodels.VideoScene, voice, instructions string) []*SpeechAudio {
+	audio := make([]*SpeechAudio, len(scenes))
+	for i, scene := range scenes {
+		text := strings.TrimSpace(scene.Voiceover)
+		if text == "" {
+			continue
+		}
+
+		req := SpeechRequest{Text: text, Voice: voice, Instructions: instructions}
+		clip, err := provider.Synthesize(ctx, req)
+		if err != nil {
+			log.Printf("⚠️ AI PIPELINE: Voiceover for %s failed: %v", scene.ID, err)
+			continue
+		}
+
+		slot := time.Duration(scene.Duration)*time.Second - voiceoverPadding
+		if clip.Duration > slot && slot > 0 {
+			req.Speed = math.Min(maxSpeechSpeed, clip.Duration.Seconds()/slot.Seconds())
+			if faster, err := provider.Synthesize(ctx, req); err == nil {
+				clip = faster
+			}
+		}
+		audio[i] = clip
+	}
+	return audio
+}
+
+// reconcileSceneTimings lengthens scenes whose voiceover needs more time, taking whole seconds from scenes
+// with time to spare so the video keeps its length where possible. Start times are recomputed and the
+// resulting runtime is returned.
+func reconcileSceneTimings(scenes []bezzmodels.VideoScene, audio []*SpeechAudio) int {
+	target := 0
+	needed := make([]int, len(scenes))
+	for i, scene := range scenes {
+		target += scene.Duration
+		needed[i] = 1
+		if i < len(audio) && audio[i] != nil {
+			needed[i] = max(1, int(math.Ceil((audio[i].Duration + voiceoverPadding).Seconds())))
+		}
+	}
+
+	runtime := 0
+	for i := range scenes {
+		scenes[i].Duration = max(scenes[i].Duration, needed[i])
+		runtime += scenes[i].Duration
+	}
+
+	// Give back the overrun, one second at a time, from the scene with the most spare time
+	for runtime > target {
+		spare, donor := 0, -1
+		for i := range scenes {
+			if slack := scenes[i].Duration - needed[i]; slack > spare {
+				spare, donor = slack, i
+			}
+		}
+		if donor < 0 {
+			break
+		}
+		scenes[donor].Duration--
+		runtime--
+	}
+
+	start := 0
+	for i := range scenes {
+		scenes[i].StartTime = start
+		start += scenes[i].Duration
+	}
+	return runtime
+}
@@ -85,6 +85,13 @@ func main() {
 			auth.POST("/reset-password", handlerContainer.Auth.ResetPassword)
 		}
 
+		// Style presets and sector taxonomy (public)
+		api.GET("/styles", handlerContainer.BrandBrief.ListStyles)
+		api.GET("/sectors", handlerContainer.Sector.List)
+
+		// Shared brand guidelines (public, read-only)
+		api.GET("/share/:token", handlerContainer.Export.GetSharedMicrosite)
+
 		// Brand briefs routes (protected)
 		briefs := api.Group("/briefs")
 		briefs.Use(middleware.AuthRequired(serviceContainer.Firebase))
@@ -97,6 +104,15 @@ func main() {
 			briefs.DELETE("/:id", handlerContainer.BrandBrief.Delete)
 		}
 
+		// Brand asset uploads (protected)
+		assets := api.Group("/assets")
+		assets.Use(middleware.AuthRequired(serviceContainer.Firebase))
+		{
+			assets.POST("", handlerContainer.Asset.Upload)
+			assets.GET("", handlerContainer.Asset.List)
+			assets.DELETE("/:id", handlerContainer.Asset.Delete)
+		}
+
 		// User routes
 		user := api.Group("/user")
 		user.Use(middleware.AuthRequired(serviceContainer.Firebase))
@@ -121,13 +137,28 @@ func main() {
 		{
 			admin.GET("/metrics", handlerContainer.Admin.GetMetrics)
 			admin.GET("/users", handlerContainer.Admin.GetUsers)
+			admin.GET("/sectors", handlerContainer.Sector.AdminList)
+			admin.PUT("/sectors/:id", handlerContainer.Sector.Upsert)
+			admin.DELETE("/sectors/:id", handlerContainer.Sector.Delete)
+			admin.GET("/moderation", handlerContainer.Admin.GetModerationQueue)
+			admin.POST("/moderation/:id/review", handlerContainer.Admin.ReviewModeration)
 		}
 
 		// Export routes
 		exports := api.Group("/exports")
 		exports.Use(middleware.AuthRequired(serviceContainer.Firebase))
 		{
-			exports.GET("/batch/:briefId", handlerContainer.Export.CreateBatchExport)
+			exports.POST("/batch/:briefId/jobs", handlerContainer.Export.CreateExportJob)
+			exports.GET("/jobs", handlerContainer.Export.ListExportJobs)
+			exports.GET("/jobs/:jobId", handlerContainer.Export.GetExportJob)
+			exports.GET("/templates", handlerContainer.Export.GetBrandBookTemplates)
+			exports.GET("/tokens/:briefId/:format", handlerContainer.Export.GetDesignTokens)
+			exports.GET("/ads/:briefId/:network", handlerContainer.Export.CreateAdNetworkExport)
+			exports.GET("/ad-networks", handlerContainer.Export.GetAdNetworks)
+			exports.GET("/canva/:briefId", handlerContainer.Export.CreateCanvaExport)
+			exports.GET("/site/:briefId", handlerContainer.Export.CreateMicrositeExport)
+			exports.POST("/site/:briefId/share", handlerContainer.Export.ShareMicrosite)
+			exports.DELETE("/site/:briefId/share", handlerContainer.Export.UnshareMicrosite)
 		}
 	}
 
@@ -89,6 +89,28 @@ export const endpoints = {
     refreshUrls: (id: string) => `/api/briefs/${id}/refresh-urls`,
     delete: (id: string) => `/api/briefs/${id}`,
   },
+  // Brand asset uploads
+  assets: {
+    list: '/api/assets',
+    upload: '/api/assets',
+    delete: (id: string) => `/api/assets/${id}`,
+  },
+  // Style presets and sectors
+  styles: '/api/styles',
+  sectors: '/api/sectors',
+  // Exports
+  exports: {
+    batchJob: (briefId: string) => `/api/exports/batch/${briefId}/jobs`,
+    jobs: '/api/exports/jobs',
+    job: (id: string) => `/api/exports/jobs/${id}`,
+    templates: '/api/exports/templates',
+    tokens: (briefId: string, format: string) => `/api/exports/tokens/${briefId}/${format}`,
+    ads: (briefId: string, network: string) => `/api/exports/ads/${briefId}/${network}`,
+    adNetworks: '/api/exports/ad-networks',
+    canva: (briefId: string) => `/api/exports/canva/${briefId}`,
+    site: (briefId: string) => `/api/exports/site/${briefId}`,
+    siteShare: (briefId: string) => `/api/exports/site/${briefId}/share`,
+  },
   // Payments
   payments: {
     createCheckout: '/api/payments/checkout',
@@ -99,6 +121,10 @@ export const endpoints = {
   admin: {
     metrics: '/api/admin/metrics',
     users: '/api/admin/users',
+    sectors: '/api/admin/sectors',
+    sector: (id: string) => `/api/admin/sectors/${id}`,
+    moderation: '/api/admin/moderation',
+    moderationReview: (id: string) => `/api/admin/moderation/${id}/review`,
   },
 }
 
@@ -125,6 +151,29 @@ export const authAPI = {
   },
 }
 
+// Brand asset API functions
+export const assetAPI = {
+  upload: async (kind: 'logo' | 'product', file: File) => {
+    const formData = new FormData()
+    formData.append('kind', kind)
+    formData.append('file', file)
+    const response = await api.post(endpoints.assets.upload, formData, {
+      headers: { 'Content-Type': 'multipart/form-data' },
+    })
+    return response.data
+  },
+
+  list: async () => {
+    const response = await api.get(endpoints.assets.list)
+    return response.data
+  },
+
+  delete: async (assetId: string) => {
+    const response = await api.delete(endpoints.assets.delete(assetId))
+    return response.data
+  },
+}
+
 // Brief API functions
 export const briefAPI = {
   retry: async (briefId: string) => {
@@ -136,6 +185,16 @@ export const briefAPI = {
     const response = await api.post(endpoints.briefs.refreshUrls(briefId))
     return response.data
   },
+
+  listStyles: async () => {
+    const response = await api.get(endpoints.styles)
+    return response.data
+  },
+
+  listSectors: async () => {
+    const response = await api.get(endpoints.sectors)
+    return response.data
+  },
 }
 
 export default api 
\ No newline at end of file
@@ -1,9 +1,9 @@
-import React, { useState } from 'react';
+import React, { useEffect, useState } from 'react';
 import { useNavigate } from 'react-router-dom';
 import { useForm } from 'react-hook-form';
 import { useAuthContext } from '@/contexts/AuthContext';
-import api, { endpoints } from '@/lib/api';
-import { BrandBriefForm } from '@/types';
+import api, { briefAPI, endpoints } from '@/lib/api';
+import { BrandBriefForm, Sector } from '@/types';
 import { 
   SparklesIcon,
   InformationCircleIcon,
@@ -38,6 +38,14 @@ const BrandBriefPage: React.FC = () => {
   const [loading, setLoading] = useState(false);
   const [currentStep, setCurrentStep] = useState(1);
   const [hoveredTone, setHoveredTone] = useState<string | null>(null);
+  const [registrySectors, setRegistrySectors] = useState<Sector[] | null>(null);
+
+  // Load the sector taxonomy; the built-in list below is used until (or if) it fails to load
+  useEffect(() => {
+    briefAPI.listSectors()
+      .then((response) => setRegistrySectors(response.data))
+      .catch((error) => console.error('Failed to load sectors:', error));
+  }, []);
 
   const {
     register,
@@ -78,7 +86,7 @@ const BrandBriefPage: React.FC = () => {
     },
   ];
 
-  const sectors = [
+  const fallbackSectors = [
     { name: 'Technology', icon: '💻', description: 'Software, SaaS, Hardware, AI' },
     { name: 'Healthcare', icon: '🏥', description: 'Medical, Wellness, Pharma, Biotech' },
     { name: 'Finance', icon: '💰', description: 'Banking, Fintech, Insurance, Investment' },
@@ -107,6 +115,14 @@ const BrandBriefPage: React.FC = () => {
     { name: 'Other', icon: '🔧', description: 'Other industries not listed above' }
   ];
 
+  const sectors = registrySectors
+    ? registrySectors.map((sector) => ({
+        name: sector.labels.en,
+        icon: sector.icon || '🏢',
+        description: sector.description || '',
+      }))
+    : fallbackSectors;
+
   const tones = [
     { name: 'Professional', color: 'from-gray-600 to-gray-800', description: 'Formal, authoritative, expert' },
     { name: 'Friendly', color: 'from-yellow-500 to-orange-500', description: 'Warm, approachable, casual' },
@@ -348,6 +364,10 @@ const BrandBriefPage: React.FC = () => {
                       value: 2,
                       message: 'Company name must be at least 2 characters',
                     },
+                    maxLength: {
+                      value: 100,
+                      message: 'Company name must be at most 100 characters',
+                    },
                   })}
                   type="text"
                   className={`
@@ -617,6 +637,10 @@ const BrandBriefPage: React.FC = () => {
                       value: 20,
                       message: 'Please provide a more detailed description (at least 20 characters)',
                     },
+                    maxLength: {
+                      value: 500,
+                      message: 'Please keep the audience description under 500 characters',
+                    },
                   })}
                   rows={5}
                   className={`
@@ -698,6 +722,7 @@ const BrandBriefPage: React.FC = () => {
                 <textarea
                   id="additionalInfo"
                   {...register('additionalInfo')}
+                  maxLength={2000}
                   rows={5}
                   className="w-full px-4 py-3 border border-gray-300 rounded-xl focus:ring-indigo-500 focus:border-indigo-500 transition-all"
                   placeholder="Tell us about your competitors, what makes you unique, your goals, or any specific requirements for your brand..."
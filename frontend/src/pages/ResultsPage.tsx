@@ -1,7 +1,7 @@
 import React, { useState, useEffect } from 'react';
 import { useParams, useNavigate } from 'react-router-dom';
 import api, { endpoints, briefAPI } from '@/lib/api';
-import { BrandBrief } from '@/types';
+import { AdNetworkId, BrandBrief, BrandBookTemplateId, DesignTokenFormat, DesignTokenFormatId, ExportJob } from '@/types';
 import { 
   ClockIcon,
   CheckCircleIcon,
@@ -43,6 +43,16 @@ import { CheckCircleIcon as CheckCircleIconSolid } from '@heroicons/react/24/sol
 import LoadingSpinner from '@/components/ui/LoadingSpinner';
 import toast from 'react-hot-toast';
 
+const DESIGN_TOKEN_FORMATS: DesignTokenFormat[] = [
+  { id: 'css', name: 'CSS variables', filename: 'tokens.css', mimeType: 'text/css' },
+  { id: 'scss', name: 'SCSS', filename: 'tokens.scss', mimeType: 'text/x-scss' },
+  { id: 'tailwind', name: 'Tailwind config', filename: 'tailwind.config.js', mimeType: 'text/javascript' },
+  { id: 'json', name: 'W3C tokens JSON', filename: 'tokens.json', mimeType: 'application/json' },
+  { id: 'tokens-studio', name: 'Tokens Studio (Figma)', filename: 'tokens-studio.json', mimeType: 'application/json' },
+  { id: 'ase', name: 'Adobe swatches (.ase)', filename: 'palette.ase', mimeType: 'application/octet-stream' },
+  { id: 'gpl', name: 'GIMP/Inkscape palette', filename: 'palette.gpl', mimeType: 'text/plain' },
+];
+
 type TabType = 'overview' | 'strategy' | 'campaigns' | 'assets';
 
 const ResultsPage: React.FC = () => {
@@ -55,6 +65,10 @@ const ResultsPage: React.FC = () => {
   const [expandedSegment, setExpandedSegment] = useState<number | null>(null);
   const [previewModal, setPreviewModal] = useState<{isOpen: boolean, campaign: any} | null>(null);
   const [platformFilter, setPlatformFilter] = useState<string>('');
+  const [bookTemplate, setBookTemplate] = useState<BrandBookTemplateId>('classic');
+  const [tokenFormat, setTokenFormat] = useState<DesignTokenFormatId>('css');
+  const [campaignOptions, setCampaignOptions] = useState({ budget: '', countries: '', days: '30', url: '' });
+  const [pastExports, setPastExports] = useState<ExportJob[]>([]);
 
   useEffect(() => {
     if (id) {
@@ -82,6 +96,20 @@ const ResultsPage: React.FC = () => {
     }
   };
 
+  const fetchPastExports = async () => {
+    if (!id) return;
+    try {
+      const response = await api.get(`${endpoints.exports.jobs}?briefId=${id}`);
+      setPastExports(response.data.data || []);
+    } catch (error) {
+      console.error('Failed to load past exports:', error);
+    }
+  };
+
+  useEffect(() => {
+    fetchPastExports();
+  }, [id]);
+
   const refreshImageURLs = async () => {
     if (!brief?.id) return;
     
@@ -194,35 +222,118 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
     toast.success('Brand strategy downloaded!');
   };
 
-  const handleBatchDownload = async () => {
+  // Exports are built in the background; poll the job until its signed download link is ready
+  const runExportJob = async (query: string, label: string) => {
     if (!brief?.id) {
       toast.error('Brief not available for download');
       return;
     }
 
     try {
-      toast.loading('Preparing complete brand kit...', { id: 'batch-download' });
-      
-      const response = await api.get(`/api/exports/batch/${brief.id}?format=zip`, {
+      toast.loading(`Building ${label}...`, { id: 'export-job' });
+
+      const started = await api.post(`${endpoints.exports.batchJob(brief.id)}?${query}`);
+      let job: ExportJob = started.data.data;
+      while (job.status === 'queued' || job.status === 'running') {
+        await new Promise((resolve) => setTimeout(resolve, 2000));
+        const response = await api.get(endpoints.exports.job(job.id));
+        job = response.data.data;
+      }
+
+      if (job.status !== 'completed' || !job.downloadUrl) {
+        throw new Error(job.error || 'Export failed');
+      }
+
+      window.location.assign(job.downloadUrl);
+      toast.success(`${label.charAt(0).toUpperCase()}${label.slice(1)} ready!`, { id: 'export-job' });
+      fetchPastExports();
+    } catch (error) {
+      console.error(`${label} export failed:`, error);
+      toast.error(`Failed to build ${label}`, { id: 'export-job' });
+    }
+  };
+
+  const handleBatchDownload = () => runExportJob('format=zip', 'complete brand kit');
+
+  const downloadDocument = async (path: string, mimeType: string, filename: string, label: string) => {
+    if (!brief?.id) {
+      toast.error('Brief not available for download');
+      return;
+    }
+
+    try {
+      toast.loading(`Building ${label}...`, { id: 'document-export' });
+
+      const response = await api.get(path, {
         responseType: 'blob'
       });
-      
-      // Create download link
-      const blob = new Blob([response.data], { type: 'application/zip' });
+
+      const blob = new Blob([response.data], { type: mimeType });
       const url = window.URL.createObjectURL(blob);
       const a = document.createElement('a');
       a.href = url;
-      a.download = `${brief.companyName}-complete-brand-kit.zip`;
+      a.download = filename;
       document.body.appendChild(a);
       a.click();
       document.body.removeChild(a);
       window.URL.revokeObjectURL(url);
-      
-      toast.success('Complete brand kit downloaded!', { id: 'batch-download' });
+
+      toast.success(`${label.charAt(0).toUpperCase()}${label.slice(1)} downloaded!`, { id: 'document-export' });
+    } catch (error) {
+      console.error(`${label} download failed:`, error);
+      toast.error(`Failed to download ${label}`, { id: 'document-export' });
+    }
+  };
+
+  const handleBrandBookDownload = () => runExportJob(`format=pdf&template=${bookTemplate}`, 'brand book');
+
+  const handlePitchDeckDownload = () => runExportJob('format=pptx', 'pitch deck');
+
+  const handleDesignTokensDownload = () => {
+    const format = DESIGN_TOKEN_FORMATS.find((f) => f.id === tokenFormat) ?? DESIGN_TOKEN_FORMATS[0];
+    downloadDocument(
+      endpoints.exports.tokens(brief?.id ?? '', format.id),
+      format.mimeType,
+      `${brief?.companyName}-${format.filename}`,
+      'design tokens'
+    );
+  };
+
+  const handleCanvaTemplatesDownload = () =>
+    downloadDocument(endpoints.exports.canva(brief?.id ?? ''), 'application/zip', `${brief?.companyName}-canva-templates.zip`, 'Canva templates');
+
+  const handleGuidelinesSiteDownload = () =>
+    downloadDocument(endpoints.exports.site(brief?.id ?? ''), 'application/zip', `${brief?.companyName}-brand-guidelines.zip`, 'brand guidelines site');
+
+  const handleGuidelinesShare = async () => {
+    if (!brief?.id) return;
+    try {
+      const response = await api.post(endpoints.exports.siteShare(brief.id));
+      await navigator.clipboard.writeText(response.data.data.url);
+      toast.success('Share link copied to clipboard!');
     } catch (error) {
-      console.error('Batch download failed:', error);
-      toast.error('Failed to download brand kit', { id: 'batch-download' });
+      console.error('Sharing brand guidelines failed:', error);
+      toast.error('Failed to create share link');
+    }
+  };
+
+  const handleAdNetworkDownload = (network: AdNetworkId, label: string) => {
+    if (!campaignOptions.budget || !campaignOptions.countries || !campaignOptions.url) {
+      toast.error('Enter a budget, countries and landing page URL');
+      return;
     }
+    const params = new URLSearchParams({
+      budget: campaignOptions.budget,
+      countries: campaignOptions.countries.replace(/\s+/g, ''),
+      days: campaignOptions.days || '30',
+      url: campaignOptions.url,
+    });
+    downloadDocument(
+      `${endpoints.exports.ads(brief?.id ?? '', network)}?${params}`,
+      'application/zip',
+      `${brief?.companyName}-${network}-ads.zip`,
+      `${label} bundle`
+    );
   };
 
   const handlePreviewCampaign = (campaign: any) => {
@@ -372,6 +483,7 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
     brandNames: brief.results?.brandNames || [],
     brandIdentity: brief.results?.brandIdentity || null,
     ads: brief.results?.ads || [],
+    videoAds: brief.results?.videoAds || [],
     brief: brief.results?.brief || {}
   };
 
@@ -704,6 +816,24 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                             />
                           </div>
                         )}
+                        {safeResults.brandIdentity.logoVariants && safeResults.brandIdentity.logoVariants.length > 0 && (
+                          <div className="mt-4">
+                            <p className="text-xs font-medium text-gray-700 mb-2">Logo files</p>
+                            <div className="flex flex-wrap gap-2">
+                              {safeResults.brandIdentity.logoVariants.map((variant) => (
+                                <a
+                                  key={variant.name}
+                                  href={variant.imageUrl}
+                                  download={`${variant.name}.${variant.format}`}
+                                  title={variant.usage}
+                                  className="text-xs bg-gray-100 text-gray-700 px-2 py-1 rounded hover:bg-gray-200"
+                                >
+                                  {variant.name}.{variant.format}
+                                </a>
+                              ))}
+                            </div>
+                          </div>
+                        )}
                       </div>
                     </div>
 
@@ -736,6 +866,34 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                             </div>
                           ))}
                         </div>
+                        {safeResults.brandIdentity.typography && (
+                          <div className="mt-6 pt-4 border-t border-gray-100">
+                            <h3 className="text-sm font-semibold text-gray-900 mb-2">Typography</h3>
+                            {[safeResults.brandIdentity.typography.heading, safeResults.brandIdentity.typography.body].map((font, index) => (
+                              <div key={index} className="flex items-center justify-between text-sm mb-1">
+                                <span className="text-gray-700">
+                                  {index === 0 ? 'Headings' : 'Body'}: <span className="font-medium">{font.family}</span>
+                                </span>
+                                <a href={font.sourceUrl} target="_blank" rel="noopener noreferrer" className="text-xs text-indigo-600 hover:underline">
+                                  {font.license}
+                                </a>
+                              </div>
+                            ))}
+                            <p className="text-xs text-gray-500 mt-1">
+                              {safeResults.brandIdentity.typography.scaleName} scale ({safeResults.brandIdentity.typography.scaleRatio})
+                            </p>
+                          </div>
+                        )}
+                        {safeResults.brandIdentity.paletteAnalysis?.issues && safeResults.brandIdentity.paletteAnalysis.issues.length > 0 && (
+                          <div className="mt-4 bg-yellow-50 border border-yellow-200 rounded-lg p-3">
+                            <p className="text-xs font-medium text-yellow-800 mb-1">Contrast notes</p>
+                            <ul className="text-xs text-yellow-700 space-y-1">
+                              {safeResults.brandIdentity.paletteAnalysis.issues.map((issue, index) => (
+                                <li key={index}>{issue}</li>
+                              ))}
+                            </ul>
+                          </div>
+                        )}
                       </div>
                     </div>
                   </div>
@@ -1202,6 +1360,66 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                   );
                 })}
               </div>
+
+              {/* Video Storyboards */}
+              {safeResults.videoAds.length > 0 && (
+                <div className="space-y-4">
+                  <h3 className="text-lg font-semibold text-gray-900">Video Storyboards</h3>
+                  {safeResults.videoAds.map((video) => (
+                    <div key={video.id} className="bg-white rounded-lg border border-gray-200 p-6">
+                      <div className="flex items-center justify-between mb-4">
+                        <div>
+                          <h4 className="font-semibold text-gray-900">{video.title}</h4>
+                          <p className="text-xs text-gray-500">{video.duration}s · {video.aspectRatio} · {video.scenes.length} scenes</p>
+                        </div>
+                        <div className="flex gap-2">
+                          {video.subtitlesUrl && (
+                            <a href={video.subtitlesUrl} download={`${video.id}.srt`} className="text-xs bg-gray-100 text-gray-700 px-2 py-1 rounded hover:bg-gray-200">
+                              Subtitles (.srt)
+                            </a>
+                          )}
+                          {video.storyboardUrl && (
+                            <a href={video.storyboardUrl} download={`${video.id}-storyboard.png`} className="text-xs bg-gray-100 text-gray-700 px-2 py-1 rounded hover:bg-gray-200">
+                              Storyboard
+                            </a>
+                          )}
+                          {video.videoUrl && (
+                            <a href={video.videoUrl} download={`${video.id}.mp4`} className="text-xs bg-gray-100 text-gray-700 px-2 py-1 rounded hover:bg-gray-200">
+                              Animatic (.mp4)
+                            </a>
+                          )}
+                        </div>
+                      </div>
+                      {video.videoUrl && (
+                        <video
+                          src={video.videoUrl}
+                          poster={video.thumbnailUrl}
+                          controls
+                          preload="metadata"
+                          className={`rounded border border-gray-200 bg-black mb-4 ${video.aspectRatio === '16:9' ? 'w-full max-w-2xl' : 'w-64'}`}
+                        />
+                      )}
+                      <div className="grid grid-cols-2 md:grid-cols-4 gap-4">
+                        {video.scenes.map((scene) => (
+                          <div key={scene.id} className="text-xs text-gray-700">
+                            {scene.keyframeUrl ? (
+                              <img src={scene.keyframeUrl} alt={scene.description} className="w-full rounded border border-gray-200 mb-2" />
+                            ) : (
+                              <div className="w-full aspect-[9/16] bg-gray-100 rounded mb-2" />
+                            )}
+                            <p className="font-medium text-gray-900">
+                              {Math.floor(scene.startTime / 60)}:{String(scene.startTime % 60).padStart(2, '0')} · {scene.duration}s
+                            </p>
+                            {scene.voiceover && <p className="mt-1">VO: {scene.voiceover}</p>}
+                            {scene.voiceoverUrl && <audio controls src={scene.voiceoverUrl} className="w-full mt-1 h-8" />}
+                            {scene.onScreenText && <p className="mt-1 text-gray-500">Text: {scene.onScreenText}</p>}
+                          </div>
+                        ))}
+                      </div>
+                    </div>
+                  ))}
+                </div>
+              )}
             </div>
           )}
 
@@ -1247,6 +1465,105 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                     </div>
                   </button>
                   
+                  <div className="p-6 bg-gray-50 rounded-lg hover:bg-green-50 transition-all text-left">
+                    <div className="flex items-start">
+                      <div className="p-2 bg-white rounded-lg mr-4 border border-gray-200">
+                        <DocumentTextIcon className="h-5 w-5 text-green-600" />
+                      </div>
+                      <div className="flex-1">
+                        <h3 className="font-medium text-gray-900 mb-1">Brand Book PDF</h3>
+                        <p className="text-xs text-gray-600 mb-3">Strategy, personas, palette, typography & ad gallery</p>
+                        <div className="flex items-center gap-2">
+                          <select
+                            value={bookTemplate}
+                            onChange={(e) => setBookTemplate(e.target.value as BrandBookTemplateId)}
+                            className="text-xs border border-gray-300 rounded-md px-2 py-1 bg-white"
+                          >
+                            <option value="classic">Classic</option>
+                            <option value="modern">Modern</option>
+                            <option value="minimal">Minimal</option>
+                          </select>
+                          <button
+                            onClick={handleBrandBookDownload}
+                            className="text-xs font-medium text-white bg-green-600 hover:bg-green-700 rounded-md px-3 py-1"
+                          >
+                            Download
+                          </button>
+                        </div>
+                      </div>
+                    </div>
+                  </div>
+
+                  <button
+                    onClick={handlePitchDeckDownload}
+                    className="group p-6 bg-gray-50 rounded-lg hover:bg-green-50 transition-all text-left"
+                  >
+                    <div className="flex items-start">
+                      <div className="p-2 bg-white rounded-lg mr-4 border border-gray-200 group-hover:border-green-200">
+                        <ComputerDesktopIcon className="h-5 w-5 text-green-600" />
+                      </div>
+                      <div>
+                        <h3 className="font-medium text-gray-900 mb-1">Pitch Deck</h3>
+                        <p className="text-xs text-gray-600">PowerPoint slides for client presentations</p>
+                      </div>
+                    </div>
+                  </button>
+
+                  <div className="p-6 bg-gray-50 rounded-lg hover:bg-green-50 transition-all text-left">
+                    <div className="flex items-start">
+                      <div className="p-2 bg-white rounded-lg mr-4 border border-gray-200">
+                        <SwatchIcon className="h-5 w-5 text-green-600" />
+                      </div>
+                      <div className="flex-1">
+                        <h3 className="font-medium text-gray-900 mb-1">Design Tokens</h3>
+                        <p className="text-xs text-gray-600 mb-3">Colors, fonts & spacing for developers and designers</p>
+                        <div className="flex items-center gap-2">
+                          <select
+                            value={tokenFormat}
+                            onChange={(e) => setTokenFormat(e.target.value as DesignTokenFormatId)}
+                            className="text-xs border border-gray-300 rounded-md px-2 py-1 bg-white"
+                          >
+                            {DESIGN_TOKEN_FORMATS.map((format) => (
+                              <option key={format.id} value={format.id}>{format.name}</option>
+                            ))}
+                          </select>
+                          <button
+                            onClick={handleDesignTokensDownload}
+                            className="text-xs font-medium text-white bg-green-600 hover:bg-green-700 rounded-md px-3 py-1"
+                          >
+                            Download
+                          </button>
+                        </div>
+                      </div>
+                    </div>
+                  </div>
+
+                  <div className="p-6 bg-gray-50 rounded-lg hover:bg-green-50 transition-all text-left">
+                    <div className="flex items-start">
+                      <div className="p-2 bg-white rounded-lg mr-4 border border-gray-200">
+                        <GlobeAltIcon className="h-5 w-5 text-green-600" />
+                      </div>
+                      <div className="flex-1">
+                        <h3 className="font-medium text-gray-900 mb-1">Brand Guidelines Site</h3>
+                        <p className="text-xs text-gray-600 mb-3">Shareable HTML guide with colours, logos, voice & ads</p>
+                        <div className="flex items-center gap-2">
+                          <button
+                            onClick={handleGuidelinesSiteDownload}
+                            className="text-xs font-medium text-white bg-green-600 hover:bg-green-700 rounded-md px-3 py-1"
+                          >
+                            Download
+                          </button>
+                          <button
+                            onClick={handleGuidelinesShare}
+                            className="text-xs font-medium text-gray-700 bg-white border border-gray-300 hover:bg-gray-100 rounded-md px-3 py-1"
+                          >
+                            Copy share link
+                          </button>
+                        </div>
+                      </div>
+                    </div>
+                  </div>
+
                   <button className="group p-6 bg-gray-50 rounded-lg hover:bg-green-50 transition-all text-left">
                     <div className="flex items-start">
                       <div className="p-3 bg-gray-100 rounded-lg mr-4 group-hover:bg-gray-200 transition-all">
@@ -1259,20 +1576,82 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                     </div>
                   </button>
                 </div>
+
+                {pastExports.length > 0 && (
+                  <div className="mt-6 border-t border-gray-100 pt-4">
+                    <h3 className="text-sm font-medium text-gray-900 mb-2">Previous Exports</h3>
+                    <ul className="divide-y divide-gray-100">
+                      {pastExports.map((job) => (
+                        <li key={job.id} className="flex items-center justify-between py-2 text-sm">
+                          <div className="flex items-center text-gray-600">
+                            <ClockIcon className="h-4 w-4 mr-2 text-gray-400" />
+                            <span className="uppercase font-medium text-gray-900 mr-2">{job.format}</span>
+                            {new Date(job.createdAt).toLocaleString()}
+                            {job.size ? <span className="ml-2 text-gray-400">{(job.size / (1 << 20)).toFixed(1)} MB</span> : null}
+                          </div>
+                          {job.status === 'completed' && job.downloadUrl ? (
+                            <a href={job.downloadUrl} className="text-blue-600 hover:text-blue-700 font-medium">
+                              Download
+                            </a>
+                          ) : (
+                            <span className={job.status === 'failed' ? 'text-red-600' : 'text-gray-500'}>
+                              {job.status === 'failed' ? 'Failed' : 'In progress'}
+                            </span>
+                          )}
+                        </li>
+                      ))}
+                    </ul>
+                  </div>
+                )}
               </div>
 
               {/* Platform Exports */}
               <div className="bg-white rounded-xl shadow-sm border border-gray-100 p-8">
                 <h2 className="text-xl font-bold text-gray-900 mb-6">Platform Exports</h2>
-                <div className="grid grid-cols-1 md:grid-cols-3 gap-4">
+                <div className="grid grid-cols-2 md:grid-cols-4 gap-3 mb-6">
+                  <input
+                    type="number"
+                    min="1"
+                    value={campaignOptions.budget}
+                    onChange={(e) => setCampaignOptions({ ...campaignOptions, budget: e.target.value })}
+                    placeholder="Total budget"
+                    className="text-sm border border-gray-300 rounded-md px-3 py-2"
+                  />
+                  <input
+                    value={campaignOptions.countries}
+                    onChange={(e) => setCampaignOptions({ ...campaignOptions, countries: e.target.value })}
+                    placeholder="Countries (NG,GH)"
+                    className="text-sm border border-gray-300 rounded-md px-3 py-2"
+                  />
+                  <input
+                    type="number"
+                    min="1"
+                    max="365"
+                    value={campaignOptions.days}
+                    onChange={(e) => setCampaignOptions({ ...campaignOptions, days: e.target.value })}
+                    placeholder="Days"
+                    className="text-sm border border-gray-300 rounded-md px-3 py-2"
+                  />
+                  <input
+                    type="url"
+                    value={campaignOptions.url}
+                    onChange={(e) => setCampaignOptions({ ...campaignOptions, url: e.target.value })}
+                    placeholder="https://your-landing-page.com"
+                    className="text-sm border border-gray-300 rounded-md px-3 py-2"
+                  />
+                </div>
+                <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-4 gap-4">
                   <div className="p-4 border border-gray-200 rounded-lg">
                     <div className="flex items-center justify-between mb-3">
                       <h3 className="font-medium text-gray-900">Canva Templates</h3>
                       <ArrowTopRightOnSquareIcon className="h-5 w-5 text-gray-400" />
                     </div>
-                    <p className="text-sm text-gray-600 mb-4">Edit campaigns in Canva with pre-filled content</p>
-                    <button className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium">
-                      Connect Canva
+                    <p className="text-sm text-gray-600 mb-4">Layered SVG templates per social size for Canva or Figma</p>
+                    <button
+                      onClick={handleCanvaTemplatesDownload}
+                      className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium"
+                    >
+                      Download Templates
                     </button>
                   </div>
                   
@@ -1281,9 +1660,12 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                       <h3 className="font-medium text-gray-900">Meta Ads Manager</h3>
                       <ArrowTopRightOnSquareIcon className="h-5 w-5 text-gray-400" />
                     </div>
-                    <p className="text-sm text-gray-600 mb-4">Import campaigns directly to Facebook & Instagram</p>
-                    <button className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium">
-                      Connect Meta
+                    <p className="text-sm text-gray-600 mb-4">Bulk import sheet and images for Facebook & Instagram</p>
+                    <button
+                      onClick={() => handleAdNetworkDownload('meta', 'Meta Ads')}
+                      className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium"
+                    >
+                      Download Bulk Upload
                     </button>
                   </div>
                   
@@ -1292,9 +1674,26 @@ ${brief.results.strategy.targetSegments?.map((segment, i) =>
                       <h3 className="font-medium text-gray-900">Google Ads</h3>
                       <ArrowTopRightOnSquareIcon className="h-5 w-5 text-gray-400" />
                     </div>
-                    <p className="text-sm text-gray-600 mb-4">Export search and display campaigns</p>
-                    <button className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium">
-                      Coming Soon
+                    <p className="text-sm text-gray-600 mb-4">Responsive search and display ads for Google Ads Editor</p>
+                    <button
+                      onClick={() => handleAdNetworkDownload('google', 'Google Ads')}
+                      className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium"
+                    >
+                      Download Bulk Upload
+                    </button>
+                  </div>
+                  
+                  <div className="p-4 border border-gray-200 rounded-lg">
+                    <div className="flex items-center justify-between mb-3">
+                      <h3 className="font-medium text-gray-900">LinkedIn Campaign Manager</h3>
+                      <ArrowTopRightOnSquareIcon className="h-5 w-5 text-gray-400" />
+                    </div>
+                    <p className="text-sm text-gray-600 mb-4">Bulk sponsored content sheet and images</p>
+                    <button
+                      onClick={() => handleAdNetworkDownload('linkedin', 'LinkedIn')}
+                      className="w-full px-4 py-2 bg-gray-100 text-gray-700 rounded-lg hover:bg-gray-200 transition-all text-sm font-medium"
+                    >
+                      Download Bulk Upload
                     </button>
                   </div>
                 </div>
@@ -24,16 +24,46 @@ export interface BrandBrief {
   companyName: string;
   businessDescription: string;
   sector: string;
+  sectorId?: string;
   tone: string;
   targetAudience: string;
   language: 'en' | 'fr';
   additionalInfo?: string;
-  status: 'processing' | 'completed' | 'failed' | 'strategy_completed';
+  placements?: AdPlacementId[];
+  style?: StylePresetId;
+  adStyles?: Partial<Record<AdPlacementId, StylePresetId>>;
+  assets?: BrandAssets;
+  videoDurations?: VideoDuration[];
+  moderation?: ModerationReport;
+  status: 'processing' | 'completed' | 'failed' | 'strategy_completed' | 'blocked';
+  shareToken?: string; // set while the read-only brand guidelines link is on
   createdAt: string;
   updatedAt: string;
   results?: BrandResults;
 }
 
+// Content-safety outcomes recorded on a brief for admin review
+export interface ModerationReport {
+  status: 'clear' | 'flagged' | 'blocked' | 'unchecked';
+  flags?: ModerationFlag[];
+  needsReview: boolean;
+  checkedAt: string;
+  decision?: 'approved' | 'rejected';
+  reviewedBy?: string;
+  reviewedAt?: string;
+  reviewNote?: string;
+}
+
+export interface ModerationFlag {
+  stage: 'input' | 'ad' | 'video';
+  target: string;
+  field: string;
+  excerpt: string;
+  categories: string[];
+  action: 'blocked' | 'regenerated' | 'dropped' | 'unchecked';
+  flaggedAt: string;
+}
+
 export interface BrandResults {
   brief: ProcessedBrief;
   strategy: BrandStrategy;
@@ -88,15 +118,37 @@ export interface AdCampaign {
   title: string;
   format: 'social' | 'display' | 'video' | 'print';
   platform: string;
+  placement?: AdPlacementId;
+  aspectRatio?: string;
   copy: {
     headline: string;
     body: string;
+    description?: string;
     cta: string;
   };
   imagePrompt: string;
+  style?: StylePresetId;
   imageUrl?: string;
   targetSegment: string;
   objectives: string[];
+  copyRulesVersion?: string;
+  copyViolations?: CopyViolation[];
+  creatives?: AdCreative[];
+}
+
+export interface AdCreative {
+  size: string;
+  width: number;
+  height: number;
+  layout: 'bottom_band' | 'top_headline' | 'side_panel' | 'clean';
+  imageUrl: string;
+  objectName?: string;
+}
+
+export interface CopyViolation {
+  field: 'headline' | 'body' | 'description' | 'cta';
+  rule: string;
+  message: string;
 }
 
 export interface VideoAd {
@@ -104,19 +156,37 @@ export interface VideoAd {
   title: string;
   script: string;
   duration: number;
+  aspectRatio?: string;
   scenes: VideoScene[];
   videoUrl?: string;
+  videoObjectName?: string;
   thumbnailUrl?: string;
+  thumbnailObjectName?: string;
+  subtitlesUrl?: string;
+  subtitlesObjectName?: string;
+  storyboardUrl?: string;
+  storyboardObjectName?: string;
+  voice?: string;
 }
 
 export interface VideoScene {
   id: string;
+  startTime: number;
   duration: number;
   description: string;
   visualPrompt: string;
   voiceover: string;
+  onScreenText?: string;
+  keyframeUrl?: string;
+  keyframeObjectName?: string;
+  voiceoverUrl?: string;
+  voiceoverObjectName?: string;
+  voiceoverDuration?: number;
 }
 
+// Storyboard lengths in seconds; Pro includes 15 and 30, Enterprise adds 60
+export type VideoDuration = 15 | 30 | 60;
+
 export interface BrandNameSuggestion {
   name: string;
   rationale: string;
@@ -127,15 +197,128 @@ export interface BrandIdentity {
   colorPalette: Color[];
   logoImageUrl?: string;
   logoObjectName?: string;
+  paletteAnalysis?: PaletteAnalysis;
+  typography?: Typography;
+  logoVariants?: LogoVariant[];
+}
+
+export interface LogoVariant {
+  kind: 'transparent' | 'icon' | 'wordmark' | 'mono_dark' | 'mono_light' | 'favicon' | 'app_icon' | 'avatar' | 'vector';
+  name: string;
+  usage: string;
+  width: number;
+  height: number;
+  format: 'png' | 'ico' | 'svg';
+  imageUrl?: string;
+  objectName: string;
+}
+
+export interface Typography {
+  heading: FontSelection;
+  body: FontSelection;
+  scaleRatio: number;
+  scaleName: string;
+  scale: TypeScaleStep[];
+  usageRules: string[];
+  rationale?: string;
+}
+
+export interface FontSelection {
+  family: string;
+  category: 'serif' | 'sans-serif' | 'display' | 'monospace' | 'handwriting';
+  weights: number[];
+  license: string;
+  sourceUrl: string;
+  fallback: string;
+}
+
+export interface TypeScaleStep {
+  name: string;
+  family: string;
+  weight: number;
+  sizePx: number;
+  sizeRem: number;
+  lineHeight: number;
+}
+
+export interface PaletteAnalysis {
+  colors: ColorSpec[];
+  contrast: ContrastCheck[];
+  harmony: PaletteHarmony;
+  darkMode: Color[];
+  issues?: string[];
+}
+
+export interface ColorSpec {
+  name: string;
+  hex: string;
+  usage: string;
+  rgb: { r: number; g: number; b: number };
+  hsl: { h: number; s: number; l: number };
+  cmyk: { c: number; m: number; y: number; k: number };
+  tints: string[];
+  shades: string[];
+}
+
+export interface ContrastCheck {
+  foreground: string;
+  background: string;
+  ratio: number;
+  aa: boolean;
+  aaLarge: boolean;
+  aaa: boolean;
+  aaaLarge: boolean;
+  usable: boolean;
+}
+
+export interface PaletteHarmony {
+  scheme: 'monochromatic' | 'analogous' | 'complementary' | 'split_complementary' | 'triadic' | 'neutral' | 'custom';
+  complementary: string;
+  analogous: string[];
+  splitComplementary: string[];
+  triadic: string[];
 }
 
 export interface Color {
   name: string;
   hex: string;
-  usage: 'primary' | 'secondary' | 'accent';
+  usage: 'primary' | 'secondary' | 'accent' | 'neutral' | 'background';
   psychology: string;
 }
 
+export type AdPlacementId =
+  | 'instagram_feed'
+  | 'instagram_story'
+  | 'linkedin_sponsored'
+  | 'google_display'
+  | 'x_post'
+  | 'print';
+
+export interface Sector {
+  id: string;
+  labels: Record<string, string>;
+  description?: string;
+  icon?: string;
+  synonyms?: string[];
+  photographyStyle?: string;
+  promptHints?: string;
+  disabled?: boolean;
+  updatedAt?: string;
+}
+
+export type StylePresetId =
+  | 'photorealistic'
+  | 'flat_illustration'
+  | '3d_render'
+  | 'isometric'
+  | 'hand_drawn';
+
+export interface StylePreset {
+  id: StylePresetId;
+  name: string;
+  description: string;
+}
+
 // Form types
 export interface BrandBriefForm {
   companyName: string;
@@ -145,6 +328,35 @@ export interface BrandBriefForm {
   targetAudience: string;
   language: 'en' | 'fr';
   additionalInfo?: string;
+  placements?: AdPlacementId[];
+  style?: StylePresetId;
+  adStyles?: Partial<Record<AdPlacementId, StylePresetId>>;
+  logoAssetId?: string;
+  productAssetIds?: string[];
+  palette?: Pick<Color, 'hex' | 'usage' | 'name'>[];
+  videoDurations?: VideoDuration[];
+}
+
+export type BrandAssetKind = 'logo' | 'product';
+
+export interface BrandAsset {
+  id: string;
+  userId: string;
+  kind: BrandAssetKind;
+  fileName: string;
+  objectName: string;
+  url?: string;
+  width: number;
+  height: number;
+  palette?: Color[]; // extracted from uploaded logos
+  createdAt: string;
+}
+
+export interface BrandAssets {
+  logo?: BrandAsset;
+  productShots?: BrandAsset[];
+  palette?: Color[];
+  paletteSource?: 'user' | 'logo';
 }
 
 // API response types
@@ -157,6 +369,7 @@ export interface ApiResponse<T> {
 export interface ApiError {
   success: false;
   error: string;
+  code?: 'content_flagged';
   details?: string;
 }
 
@@ -168,4 +381,51 @@ export interface LanguageContent {
     en: string;
     fr: string;
   };
-} 
\ No newline at end of file
+} 
+// PDF brand book layouts, mirroring the backend's template ids
+export type BrandBookTemplateId = 'classic' | 'modern' | 'minimal';
+
+export interface BrandBookTemplate {
+  id: BrandBookTemplateId;
+  name: string;
+  description: string;
+}
+
+// Design token export formats, mirroring the backend's format ids
+export type DesignTokenFormatId = 'css' | 'scss' | 'tailwind' | 'json' | 'tokens-studio' | 'ase' | 'gpl';
+
+export interface DesignTokenFormat {
+  id: DesignTokenFormatId;
+  name: string;
+  filename: string;
+  mimeType: string;
+}
+
+// Ad networks with bulk upload exports, mirroring the backend's network ids
+export type ExportFormat = 'zip' | 'pdf' | 'pptx';
+
+export type ExportJobStatus = 'queued' | 'running' | 'completed' | 'failed';
+
+export interface ExportJob {
+  id: string;
+  briefId: string;
+  companyName: string;
+  format: ExportFormat;
+  template?: BrandBookTemplateId;
+  status: ExportJobStatus;
+  error?: string;
+  filename?: string;
+  size?: number;
+  downloadUrl?: string;
+  expiresAt?: string;
+  createdAt: string;
+  completedAt?: string;
+}
+
+export type AdNetworkId = 'meta' | 'google' | 'linkedin';
+
+export interface AdNetwork {
+  id: AdNetworkId;
+  name: string;
+  description: string;
+}